@@ -2,8 +2,11 @@ package client
 
 import (
 	"io"
+	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client/debug"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 )
 
@@ -35,16 +38,175 @@ func (c APIClient) Binary(filter *debug.Filter, w io.Writer) (retErr error) {
 }
 
 // Dump collects a standard set of debugging information.
-func (c APIClient) Dump(filter *debug.Filter, limit int64, w io.Writer) (retErr error) {
+func (c APIClient) Dump(filter *debug.Filter, limit int64, w io.Writer) error {
+	return c.DumpSections(filter, limit, nil, nil, w)
+}
+
+// DumpSections collects a standard set of debugging information, limited to
+// the sections in include (all of the sections if include is empty) and
+// without the sections in exclude.
+func (c APIClient) DumpSections(filter *debug.Filter, limit int64, include, exclude []string, w io.Writer) (retErr error) {
 	defer func() {
 		retErr = grpcutil.ScrubGRPC(retErr)
 	}()
 	dumpC, err := c.DebugClient.Dump(c.Ctx(), &debug.DumpRequest{
-		Filter: filter,
-		Limit:  limit,
+		Filter:  filter,
+		Limit:   limit,
+		Include: include,
+		Exclude: exclude,
 	})
 	if err != nil {
 		return err
 	}
 	return grpcutil.WriteFromStreamingBytesClient(dumpC, w)
 }
+
+// CaptureLogs raises pachd's log level to level for duration, and collects the
+// logs from that window. The prior log level is restored afterwards.
+func (c APIClient) CaptureLogs(level string, duration time.Duration, w io.Writer) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	logsC, err := c.DebugClient.CaptureLogs(c.Ctx(), &debug.CaptureLogsRequest{
+		Level:    level,
+		Duration: types.DurationProto(duration),
+	})
+	if err != nil {
+		return err
+	}
+	return grpcutil.WriteFromStreamingBytesClient(logsC, w)
+}
+
+// TailLogs follows pachd's logs for duration, and calls cb with each log line
+// as it's logged, in order.
+func (c APIClient) TailLogs(duration time.Duration, cb func(time.Time, string) error) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	linesC, err := c.DebugClient.TailLogs(c.Ctx(), &debug.TailLogsRequest{
+		Duration: types.DurationProto(duration),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		line, err := linesC.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		t, err := types.TimestampFromProto(line.Time)
+		if err != nil {
+			return err
+		}
+		if err := cb(t, line.Line); err != nil {
+			return err
+		}
+	}
+}
+
+// Contention enables pachd's block or mutex profile for duration, and writes a
+// summary of the (at most limit) most contended call sites in that window.
+func (c APIClient) Contention(profile string, duration time.Duration, limit int64, w io.Writer) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	contentionC, err := c.DebugClient.Contention(c.Ctx(), &debug.ContentionRequest{
+		Profile:  profile,
+		Duration: types.DurationProto(duration),
+		Limit:    limit,
+	})
+	if err != nil {
+		return err
+	}
+	return grpcutil.WriteFromStreamingBytesClient(contentionC, w)
+}
+
+// Goroutine returns the stack of the pachd goroutine with the given ID.
+func (c APIClient) Goroutine(id int64) (_ string, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	resp, err := c.DebugClient.Goroutine(c.Ctx(), &debug.GoroutineRequest{Id: id})
+	if err != nil {
+		return "", err
+	}
+	return resp.Stack, nil
+}
+
+// GoroutineCount samples the number of pachd goroutines every interval for
+// duration, and calls cb with the time and count of each sample.
+func (c APIClient) GoroutineCount(interval, duration time.Duration, cb func(time.Time, int64) error) (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	samplesC, err := c.DebugClient.GoroutineCount(c.Ctx(), &debug.GoroutineCountRequest{
+		Interval: types.DurationProto(interval),
+		Duration: types.DurationProto(duration),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		sample, err := samplesC.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		t, err := types.TimestampFromProto(sample.Time)
+		if err != nil {
+			return err
+		}
+		if err := cb(t, sample.Count); err != nil {
+			return err
+		}
+	}
+}
+
+// Counters returns the values of pachd's internal counters with the given
+// names (or all of the counters, if no names are given). If reset is true,
+// the counters are reset after they're read, so that the next call returns
+// their counts since this call.
+func (c APIClient) Counters(names []string, reset bool) (_ []*debug.Counter, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	resp, err := c.DebugClient.Counters(c.Ctx(), &debug.CountersRequest{
+		Names:  names,
+		Reset_: reset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Counters, nil
+}
+
+// SetProfileRate enables pachd's block or mutex profile with the given rate
+// until it's changed again (a rate of zero disables it), and returns the
+// profile's previous rate.
+func (c APIClient) SetProfileRate(profile string, rate int64) (_ int64, retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	resp, err := c.DebugClient.SetProfileRate(c.Ctx(), &debug.SetProfileRateRequest{
+		Profile: profile,
+		Rate:    rate,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.PreviousRate, nil
+}
+
+// ResetProfileRates disables pachd's block and mutex profiles.
+func (c APIClient) ResetProfileRates() (retErr error) {
+	defer func() {
+		retErr = grpcutil.ScrubGRPC(retErr)
+	}()
+	_, err := c.DebugClient.ResetProfileRates(c.Ctx(), &debug.ResetProfileRatesRequest{})
+	return err
+}
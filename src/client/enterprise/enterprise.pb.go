@@ -5,6 +5,7 @@ package enterprise
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	types "github.com/gogo/protobuf/types"
@@ -60,7 +61,25 @@ func (State) EnumDescriptor() ([]byte, []int) {
 type EnterpriseRecord struct {
 	ActivationCode string `protobuf:"bytes,1,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
 	// expires is a timestamp indicating when this activation code will expire.
-	Expires              *types.Timestamp `protobuf:"bytes,2,opt,name=expires,proto3" json:"expires,omitempty"`
+	// If more than one activation code is active, this is the earliest
+	// expiration of the codes.
+	Expires *types.Timestamp `protobuf:"bytes,2,opt,name=expires,proto3" json:"expires,omitempty"`
+	// activation_codes are the active activation codes, if more than one code
+	// has been activated (activation_code is the first of them). It is unset
+	// if only a single code is active.
+	ActivationCodes []string `protobuf:"bytes,3,rep,name=activation_codes,json=activationCodes,proto3" json:"activation_codes,omitempty"`
+	// deactivated is set on the tombstone record that Deactivate writes in place
+	// of the cluster's activation codes, so that a deactivated cluster can be
+	// distinguished from one that was never activated.
+	Deactivated bool `protobuf:"varint,4,opt,name=deactivated,proto3" json:"deactivated,omitempty"`
+	// staged_activation_code is an activation code that replaces the active
+	// activation codes once they expire (see StageActivationCode).
+	StagedActivationCode string `protobuf:"bytes,5,opt,name=staged_activation_code,json=stagedActivationCode,proto3" json:"staged_activation_code,omitempty"`
+	// staged_expires is when the staged activation code expires.
+	StagedExpires *types.Timestamp `protobuf:"bytes,6,opt,name=staged_expires,json=stagedExpires,proto3" json:"staged_expires,omitempty"`
+	// staged_start_after is the earliest time that the staged activation code
+	// replaces the active activation codes.
+	StagedStartAfter     *types.Timestamp `protobuf:"bytes,7,opt,name=staged_start_after,json=stagedStartAfter,proto3" json:"staged_start_after,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -113,6 +132,41 @@ func (m *EnterpriseRecord) GetExpires() *types.Timestamp {
 	return nil
 }
 
+func (m *EnterpriseRecord) GetActivationCodes() []string {
+	if m != nil {
+		return m.ActivationCodes
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetDeactivated() bool {
+	if m != nil {
+		return m.Deactivated
+	}
+	return false
+}
+
+func (m *EnterpriseRecord) GetStagedActivationCode() string {
+	if m != nil {
+		return m.StagedActivationCode
+	}
+	return ""
+}
+
+func (m *EnterpriseRecord) GetStagedExpires() *types.Timestamp {
+	if m != nil {
+		return m.StagedExpires
+	}
+	return nil
+}
+
+func (m *EnterpriseRecord) GetStagedStartAfter() *types.Timestamp {
+	if m != nil {
+		return m.StagedStartAfter
+	}
+	return nil
+}
+
 // TokenInfo contains information about the currently active enterprise token
 type TokenInfo struct {
 	// expires indicates when the current token expires (unset if there is no
@@ -171,10 +225,20 @@ type ActivateRequest struct {
 	// This should not generally be set (it's primarily used for testing), and is
 	// only applied if it's earlier than the signed expiration time in
 	// 'activation_code'.
-	Expires              *types.Timestamp `protobuf:"bytes,2,opt,name=expires,proto3" json:"expires,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Expires *types.Timestamp `protobuf:"bytes,2,opt,name=expires,proto3" json:"expires,omitempty"`
+	// add adds the activation code to the cluster's active activation codes,
+	// rather than replacing them. The earliest expiration of the active codes
+	// governs when the cluster's enterprise state expires.
+	Add bool `protobuf:"varint,3,opt,name=add,proto3" json:"add,omitempty"`
+	// activation_code_ref is a reference to the activation code (by default,
+	// the name of an environment variable in pachd, which may be populated from
+	// a Kubernetes secret), which pachd resolves so that the code isn't sent in
+	// the request. At most one of activation_code and activation_code_ref may be
+	// set.
+	ActivationCodeRef    string   `protobuf:"bytes,4,opt,name=activation_code_ref,json=activationCodeRef,proto3" json:"activation_code_ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ActivateRequest) Reset()         { *m = ActivateRequest{} }
@@ -224,6 +288,20 @@ func (m *ActivateRequest) GetExpires() *types.Timestamp {
 	return nil
 }
 
+func (m *ActivateRequest) GetAdd() bool {
+	if m != nil {
+		return m.Add
+	}
+	return false
+}
+
+func (m *ActivateRequest) GetActivationCodeRef() string {
+	if m != nil {
+		return m.ActivationCodeRef
+	}
+	return ""
+}
+
 type ActivateResponse struct {
 	Info                 *TokenInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -315,7 +393,10 @@ type GetStateResponse struct {
 	Info  *TokenInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
 	// activation_code will always be an empty string,
 	// call GetEnterpriseCode to get the activation code
-	ActivationCode       string   `protobuf:"bytes,3,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
+	ActivationCode string `protobuf:"bytes,3,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
+	// deactivated is true if the state is NONE because the cluster was
+	// deactivated, and false if it was never activated.
+	Deactivated          bool     `protobuf:"varint,4,opt,name=deactivated,proto3" json:"deactivated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -375,6 +456,13 @@ func (m *GetStateResponse) GetActivationCode() string {
 	return ""
 }
 
+func (m *GetStateResponse) GetDeactivated() bool {
+	if m != nil {
+		return m.Deactivated
+	}
+	return false
+}
+
 type GetActivationCodeRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -415,12 +503,25 @@ func (m *GetActivationCodeRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_GetActivationCodeRequest proto.InternalMessageInfo
 
 type GetActivationCodeResponse struct {
-	State                State      `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
-	Info                 *TokenInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
-	ActivationCode       string     `protobuf:"bytes,3,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	State          State      `protobuf:"varint,1,opt,name=state,proto3,enum=enterprise.State" json:"state,omitempty"`
+	Info           *TokenInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	ActivationCode string     `protobuf:"bytes,3,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
+	// activation_codes are all of the cluster's active activation codes
+	// (including activation_code).
+	ActivationCodes []string `protobuf:"bytes,4,rep,name=activation_codes,json=activationCodes,proto3" json:"activation_codes,omitempty"`
+	// deactivated is true if the state is NONE because the cluster was
+	// deactivated, and false if it was never activated.
+	Deactivated bool `protobuf:"varint,5,opt,name=deactivated,proto3" json:"deactivated,omitempty"`
+	// staged_info is the info for the staged activation code, which replaces
+	// the active activation codes once they expire (unset if no code is staged).
+	StagedInfo *TokenInfo `protobuf:"bytes,6,opt,name=staged_info,json=stagedInfo,proto3" json:"staged_info,omitempty"`
+	// entitlement is what the active activation codes grant together: the
+	// union of their features, the total of their seats, and the earliest of
+	// their expirations (unset unless the state is ACTIVE).
+	Entitlement          *Entitlement `protobuf:"bytes,7,opt,name=entitlement,proto3" json:"entitlement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *GetActivationCodeResponse) Reset()         { *m = GetActivationCodeResponse{} }
@@ -477,7 +578,157 @@ func (m *GetActivationCodeResponse) GetActivationCode() string {
 	return ""
 }
 
+func (m *GetActivationCodeResponse) GetActivationCodes() []string {
+	if m != nil {
+		return m.ActivationCodes
+	}
+	return nil
+}
+
+func (m *GetActivationCodeResponse) GetDeactivated() bool {
+	if m != nil {
+		return m.Deactivated
+	}
+	return false
+}
+
+func (m *GetActivationCodeResponse) GetStagedInfo() *TokenInfo {
+	if m != nil {
+		return m.StagedInfo
+	}
+	return nil
+}
+
+func (m *GetActivationCodeResponse) GetEntitlement() *Entitlement {
+	if m != nil {
+		return m.Entitlement
+	}
+	return nil
+}
+
+type StageActivationCodeRequest struct {
+	// activation_code is a Pachyderm enterprise activation code, which replaces
+	// the cluster's active activation codes once they expire.
+	ActivationCode string `protobuf:"bytes,1,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
+	// start_after is the earliest time that the activation code replaces the
+	// active activation codes. It defaults to the expiration of the active
+	// activation codes, and the activation code must not expire before it.
+	StartAfter           *types.Timestamp `protobuf:"bytes,2,opt,name=start_after,json=startAfter,proto3" json:"start_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StageActivationCodeRequest) Reset()         { *m = StageActivationCodeRequest{} }
+func (m *StageActivationCodeRequest) String() string { return proto.CompactTextString(m) }
+func (*StageActivationCodeRequest) ProtoMessage()    {}
+func (*StageActivationCodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{8}
+}
+func (m *StageActivationCodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StageActivationCodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StageActivationCodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StageActivationCodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StageActivationCodeRequest.Merge(m, src)
+}
+func (m *StageActivationCodeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StageActivationCodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StageActivationCodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StageActivationCodeRequest proto.InternalMessageInfo
+
+func (m *StageActivationCodeRequest) GetActivationCode() string {
+	if m != nil {
+		return m.ActivationCode
+	}
+	return ""
+}
+
+func (m *StageActivationCodeRequest) GetStartAfter() *types.Timestamp {
+	if m != nil {
+		return m.StartAfter
+	}
+	return nil
+}
+
+type StageActivationCodeResponse struct {
+	// info is the info for the staged activation code.
+	Info *TokenInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// start_after is the earliest time that the staged activation code
+	// replaces the active activation codes.
+	StartAfter           *types.Timestamp `protobuf:"bytes,2,opt,name=start_after,json=startAfter,proto3" json:"start_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StageActivationCodeResponse) Reset()         { *m = StageActivationCodeResponse{} }
+func (m *StageActivationCodeResponse) String() string { return proto.CompactTextString(m) }
+func (*StageActivationCodeResponse) ProtoMessage()    {}
+func (*StageActivationCodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{9}
+}
+func (m *StageActivationCodeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StageActivationCodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StageActivationCodeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StageActivationCodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StageActivationCodeResponse.Merge(m, src)
+}
+func (m *StageActivationCodeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *StageActivationCodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StageActivationCodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StageActivationCodeResponse proto.InternalMessageInfo
+
+func (m *StageActivationCodeResponse) GetInfo() *TokenInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *StageActivationCodeResponse) GetStartAfter() *types.Timestamp {
+	if m != nil {
+		return m.StartAfter
+	}
+	return nil
+}
+
 type DeactivateRequest struct {
+	// confirmation_token is the token returned by a Deactivate request without
+	// a confirmation token. The cluster is only deactivated when a valid token
+	// is provided.
+	ConfirmationToken    string   `protobuf:"bytes,1,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -487,7 +738,7 @@ func (m *DeactivateRequest) Reset()         { *m = DeactivateRequest{} }
 func (m *DeactivateRequest) String() string { return proto.CompactTextString(m) }
 func (*DeactivateRequest) ProtoMessage()    {}
 func (*DeactivateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88d07275108cec01, []int{8}
+	return fileDescriptor_88d07275108cec01, []int{10}
 }
 func (m *DeactivateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -516,7 +767,18 @@ func (m *DeactivateRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeactivateRequest proto.InternalMessageInfo
 
+func (m *DeactivateRequest) GetConfirmationToken() string {
+	if m != nil {
+		return m.ConfirmationToken
+	}
+	return ""
+}
+
 type DeactivateResponse struct {
+	// confirmation_token is set when the request did not include a
+	// confirmation token. It must be passed to a second Deactivate request
+	// (before it expires) to deactivate the cluster.
+	ConfirmationToken    string   `protobuf:"bytes,1,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -526,7 +788,7 @@ func (m *DeactivateResponse) Reset()         { *m = DeactivateResponse{} }
 func (m *DeactivateResponse) String() string { return proto.CompactTextString(m) }
 func (*DeactivateResponse) ProtoMessage()    {}
 func (*DeactivateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_88d07275108cec01, []int{9}
+	return fileDescriptor_88d07275108cec01, []int{11}
 }
 func (m *DeactivateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -555,828 +817,3646 @@ func (m *DeactivateResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeactivateResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("enterprise.State", State_name, State_value)
-	proto.RegisterType((*EnterpriseRecord)(nil), "enterprise.EnterpriseRecord")
-	proto.RegisterType((*TokenInfo)(nil), "enterprise.TokenInfo")
-	proto.RegisterType((*ActivateRequest)(nil), "enterprise.ActivateRequest")
-	proto.RegisterType((*ActivateResponse)(nil), "enterprise.ActivateResponse")
-	proto.RegisterType((*GetStateRequest)(nil), "enterprise.GetStateRequest")
-	proto.RegisterType((*GetStateResponse)(nil), "enterprise.GetStateResponse")
-	proto.RegisterType((*GetActivationCodeRequest)(nil), "enterprise.GetActivationCodeRequest")
-	proto.RegisterType((*GetActivationCodeResponse)(nil), "enterprise.GetActivationCodeResponse")
-	proto.RegisterType((*DeactivateRequest)(nil), "enterprise.DeactivateRequest")
-	proto.RegisterType((*DeactivateResponse)(nil), "enterprise.DeactivateResponse")
+func (m *DeactivateResponse) GetConfirmationToken() string {
+	if m != nil {
+		return m.ConfirmationToken
+	}
+	return ""
 }
 
-func init() {
-	proto.RegisterFile("client/enterprise/enterprise.proto", fileDescriptor_88d07275108cec01)
+// ExportedState is the enterprise server's state and configuration, as
+// exported by ExportState.
+type ExportedState struct {
+	// record is the cluster's enterprise record, including its activation codes.
+	Record *EnterpriseRecord `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	// config is the server's configuration. It's exported for support, and is
+	// not restored by ImportState (the configuration comes from pachd's
+	// environment).
+	Config               *ExportedConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-var fileDescriptor_88d07275108cec01 = []byte{
-	// 473 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xcd, 0xa6, 0xdf, 0x53, 0xa9, 0x71, 0x16, 0x90, 0x82, 0x81, 0x50, 0xad, 0x40, 0x2d, 0x3d,
-	0xd8, 0x52, 0xe8, 0x95, 0x83, 0xdb, 0x5a, 0x51, 0x0e, 0x94, 0xca, 0x44, 0x08, 0x71, 0x41, 0x8e,
-	0x33, 0x49, 0x57, 0x34, 0x5e, 0xd7, 0xbb, 0x41, 0xf0, 0x2b, 0xb8, 0xf2, 0x93, 0x10, 0x27, 0x7e,
-	0x02, 0xca, 0x2f, 0x41, 0xb5, 0x6b, 0x7b, 0xed, 0x18, 0x85, 0x0b, 0x52, 0x6f, 0xab, 0x37, 0x6f,
-	0xdf, 0x7b, 0x9e, 0x19, 0x2f, 0xb0, 0xe0, 0x8a, 0x63, 0xa8, 0x6c, 0x0c, 0x15, 0xc6, 0x51, 0xcc,
-	0x25, 0x6a, 0x47, 0x2b, 0x8a, 0x85, 0x12, 0x14, 0x0a, 0xc4, 0x7c, 0x3a, 0x15, 0x62, 0x7a, 0x85,
-	0x76, 0x52, 0x19, 0xcd, 0x27, 0xb6, 0xe2, 0x33, 0x94, 0xca, 0x9f, 0x45, 0x29, 0x99, 0x5d, 0x83,
-	0xe1, 0xe6, 0x74, 0x0f, 0x03, 0x11, 0x8f, 0xe9, 0x01, 0xb4, 0xfc, 0x40, 0xf1, 0xcf, 0xbe, 0xe2,
-	0x22, 0xfc, 0x18, 0x88, 0x31, 0x76, 0xc8, 0x3e, 0x39, 0xdc, 0xf1, 0xf6, 0x0a, 0xf8, 0x54, 0x8c,
-	0x91, 0x1e, 0xc3, 0x16, 0x7e, 0x89, 0x78, 0x8c, 0xb2, 0xd3, 0xdc, 0x27, 0x87, 0xbb, 0x3d, 0xd3,
-	0x4a, 0xfd, 0xac, 0xcc, 0xcf, 0x1a, 0x66, 0x7e, 0x5e, 0x46, 0x65, 0x0e, 0xec, 0x0c, 0xc5, 0x27,
-	0x0c, 0x07, 0xe1, 0x44, 0xe8, 0x12, 0xe4, 0xdf, 0x25, 0x22, 0x68, 0x39, 0x69, 0x14, 0xf4, 0xf0,
-	0x7a, 0x8e, 0x52, 0xfd, 0xef, 0xd0, 0xaf, 0xc0, 0x28, 0x1c, 0x65, 0x24, 0x42, 0x89, 0xf4, 0x05,
-	0xac, 0xf3, 0x70, 0x22, 0x6e, 0x83, 0x3f, 0xb0, 0xb4, 0x49, 0xe4, 0x1f, 0xe8, 0x25, 0x14, 0xd6,
-	0x86, 0x56, 0x1f, 0xd5, 0x5b, 0x55, 0x04, 0x66, 0xdf, 0x08, 0x18, 0x05, 0x76, 0x2b, 0x79, 0x00,
-	0x1b, 0xf2, 0x06, 0x48, 0x34, 0xf7, 0x7a, 0x6d, 0x5d, 0x33, 0x65, 0xa6, 0xf5, 0xdc, 0xbb, 0xb9,
-	0xd2, 0xbb, 0xae, 0x33, 0x6b, 0x75, 0x9d, 0x61, 0x26, 0x74, 0xfa, 0xa8, 0x9c, 0x12, 0x98, 0xa5,
-	0xfd, 0x4e, 0xe0, 0x61, 0x4d, 0xf1, 0x2e, 0xc4, 0xbe, 0x07, 0xed, 0x33, 0xf4, 0xcb, 0xeb, 0xc0,
-	0xee, 0x03, 0xd5, 0xc1, 0x34, 0xe7, 0xd1, 0x11, 0x6c, 0x24, 0x71, 0xe8, 0x36, 0xac, 0x9f, 0xbf,
-	0x39, 0x77, 0x8d, 0x06, 0x05, 0xd8, 0x74, 0x4e, 0x87, 0x83, 0x77, 0xae, 0x41, 0xe8, 0x2e, 0x6c,
-	0xb9, 0xef, 0x2f, 0x06, 0x9e, 0x7b, 0x66, 0x34, 0x7b, 0x3f, 0x9b, 0xb0, 0xe6, 0x5c, 0x0c, 0x68,
-	0x1f, 0xb6, 0xb3, 0xc9, 0xd3, 0x47, 0x7a, 0xe0, 0xca, 0x06, 0x9a, 0x8f, 0xeb, 0x8b, 0xa9, 0x35,
-	0x6b, 0xdc, 0x08, 0x65, 0xf3, 0x2e, 0x0b, 0x55, 0x36, 0xa3, 0x2c, 0x54, 0x5d, 0x11, 0xd6, 0xa0,
-	0x23, 0x68, 0x2f, 0x8d, 0x82, 0x3e, 0xab, 0x5c, 0xaa, 0x1d, 0xa3, 0xf9, 0x7c, 0x05, 0x2b, 0xf7,
-	0x78, 0x0d, 0x50, 0xf4, 0x8f, 0x3e, 0xd1, 0xaf, 0x2d, 0x35, 0xdb, 0xec, 0xfe, 0xad, 0x9c, 0xc9,
-	0x9d, 0x9c, 0xfc, 0x58, 0x74, 0xc9, 0xaf, 0x45, 0x97, 0xfc, 0x5e, 0x74, 0xc9, 0x87, 0xe3, 0x29,
-	0x57, 0x97, 0xf3, 0x91, 0x15, 0x88, 0x99, 0x1d, 0xf9, 0xc1, 0xe5, 0xd7, 0x31, 0xc6, 0xfa, 0x49,
-	0xc6, 0x81, 0xbd, 0xf4, 0xd0, 0x8d, 0x36, 0x93, 0xff, 0xf3, 0xe5, 0x9f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x1f, 0x92, 0x92, 0xc4, 0x04, 0x05, 0x00, 0x00,
+func (m *ExportedState) Reset()         { *m = ExportedState{} }
+func (m *ExportedState) String() string { return proto.CompactTextString(m) }
+func (*ExportedState) ProtoMessage()    {}
+func (*ExportedState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{12}
+}
+func (m *ExportedState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportedState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportedState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportedState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportedState.Merge(m, src)
+}
+func (m *ExportedState) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportedState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportedState.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_ExportedState proto.InternalMessageInfo
 
-// APIClient is the client API for API service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type APIClient interface {
-	// Provide a Pachyderm enterprise token, enabling Pachyderm enterprise
-	// features, such as the Pachyderm Dashboard and Auth system
-	Activate(ctx context.Context, in *ActivateRequest, opts ...grpc.CallOption) (*ActivateResponse, error)
-	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error)
-	GetActivationCode(ctx context.Context, in *GetActivationCodeRequest, opts ...grpc.CallOption) (*GetActivationCodeResponse, error)
-	// Deactivate is a testing API. It removes a cluster's enterprise activation
-	// token and sets its enterprise state to NONE (normally, once a cluster has
-	// been activated, the only reachable state is EXPIRED).
-	//
-	// NOTE: This endpoint also calls DeleteAll (and deletes all Pachyderm data in
-	// its cluster). This is to avoid dealing with invalid, intermediate states
-	// (e.g. auth is activated but enterprise state is NONE)
-	Deactivate(ctx context.Context, in *DeactivateRequest, opts ...grpc.CallOption) (*DeactivateResponse, error)
+func (m *ExportedState) GetRecord() *EnterpriseRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
 }
 
-type aPIClient struct {
-	cc *grpc.ClientConn
+func (m *ExportedState) GetConfig() *ExportedConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
 }
 
-func NewAPIClient(cc *grpc.ClientConn) APIClient {
-	return &aPIClient{cc}
+// ExportedConfig is the enterprise server's configuration, with any secrets
+// redacted.
+type ExportedConfig struct {
+	// activation_code_rate_limit and activation_code_rate_burst configure the
+	// per-subject rate limit on GetActivationCode (a zero limit means
+	// unlimited).
+	ActivationCodeRateLimit float64 `protobuf:"fixed64,1,opt,name=activation_code_rate_limit,json=activationCodeRateLimit,proto3" json:"activation_code_rate_limit,omitempty"`
+	ActivationCodeRateBurst int64   `protobuf:"varint,2,opt,name=activation_code_rate_burst,json=activationCodeRateBurst,proto3" json:"activation_code_rate_burst,omitempty"`
+	// webhook_url is the URL that license state changes are posted to, with
+	// its user info and query redacted.
+	WebhookUrl string `protobuf:"bytes,3,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	// expiring_soon_threshold is how long before its expiration an activation
+	// code is reported as expiring soon.
+	ExpiringSoonThreshold *types.Duration `protobuf:"bytes,4,opt,name=expiring_soon_threshold,json=expiringSoonThreshold,proto3" json:"expiring_soon_threshold,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}        `json:"-"`
+	XXX_unrecognized      []byte          `json:"-"`
+	XXX_sizecache         int32           `json:"-"`
 }
 
-func (c *aPIClient) Activate(ctx context.Context, in *ActivateRequest, opts ...grpc.CallOption) (*ActivateResponse, error) {
-	out := new(ActivateResponse)
-	err := c.cc.Invoke(ctx, "/enterprise.API/Activate", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ExportedConfig) Reset()         { *m = ExportedConfig{} }
+func (m *ExportedConfig) String() string { return proto.CompactTextString(m) }
+func (*ExportedConfig) ProtoMessage()    {}
+func (*ExportedConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{13}
 }
-
-func (c *aPIClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error) {
-	out := new(GetStateResponse)
-	err := c.cc.Invoke(ctx, "/enterprise.API/GetState", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportedConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportedConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportedConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *ExportedConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportedConfig.Merge(m, src)
+}
+func (m *ExportedConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportedConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportedConfig.DiscardUnknown(m)
 }
 
-func (c *aPIClient) GetActivationCode(ctx context.Context, in *GetActivationCodeRequest, opts ...grpc.CallOption) (*GetActivationCodeResponse, error) {
-	out := new(GetActivationCodeResponse)
-	err := c.cc.Invoke(ctx, "/enterprise.API/GetActivationCode", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ExportedConfig proto.InternalMessageInfo
+
+func (m *ExportedConfig) GetActivationCodeRateLimit() float64 {
+	if m != nil {
+		return m.ActivationCodeRateLimit
 	}
-	return out, nil
+	return 0
 }
 
-func (c *aPIClient) Deactivate(ctx context.Context, in *DeactivateRequest, opts ...grpc.CallOption) (*DeactivateResponse, error) {
-	out := new(DeactivateResponse)
-	err := c.cc.Invoke(ctx, "/enterprise.API/Deactivate", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportedConfig) GetActivationCodeRateBurst() int64 {
+	if m != nil {
+		return m.ActivationCodeRateBurst
 	}
-	return out, nil
+	return 0
 }
 
-// APIServer is the server API for API service.
-type APIServer interface {
-	// Provide a Pachyderm enterprise token, enabling Pachyderm enterprise
-	// features, such as the Pachyderm Dashboard and Auth system
-	Activate(context.Context, *ActivateRequest) (*ActivateResponse, error)
-	GetState(context.Context, *GetStateRequest) (*GetStateResponse, error)
-	GetActivationCode(context.Context, *GetActivationCodeRequest) (*GetActivationCodeResponse, error)
-	// Deactivate is a testing API. It removes a cluster's enterprise activation
-	// token and sets its enterprise state to NONE (normally, once a cluster has
-	// been activated, the only reachable state is EXPIRED).
-	//
-	// NOTE: This endpoint also calls DeleteAll (and deletes all Pachyderm data in
-	// its cluster). This is to avoid dealing with invalid, intermediate states
-	// (e.g. auth is activated but enterprise state is NONE)
-	Deactivate(context.Context, *DeactivateRequest) (*DeactivateResponse, error)
+func (m *ExportedConfig) GetWebhookUrl() string {
+	if m != nil {
+		return m.WebhookUrl
+	}
+	return ""
 }
 
-// UnimplementedAPIServer can be embedded to have forward compatible implementations.
-type UnimplementedAPIServer struct {
+func (m *ExportedConfig) GetExpiringSoonThreshold() *types.Duration {
+	if m != nil {
+		return m.ExpiringSoonThreshold
+	}
+	return nil
 }
 
-func (*UnimplementedAPIServer) Activate(ctx context.Context, req *ActivateRequest) (*ActivateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Activate not implemented")
+type ExportStateRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (*UnimplementedAPIServer) GetState(ctx context.Context, req *GetStateRequest) (*GetStateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+
+func (m *ExportStateRequest) Reset()         { *m = ExportStateRequest{} }
+func (m *ExportStateRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportStateRequest) ProtoMessage()    {}
+func (*ExportStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{14}
 }
-func (*UnimplementedAPIServer) GetActivationCode(ctx context.Context, req *GetActivationCodeRequest) (*GetActivationCodeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetActivationCode not implemented")
+func (m *ExportStateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedAPIServer) Deactivate(ctx context.Context, req *DeactivateRequest) (*DeactivateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Deactivate not implemented")
+func (m *ExportStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportStateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportStateRequest.Merge(m, src)
+}
+func (m *ExportStateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportStateRequest.DiscardUnknown(m)
 }
 
-func RegisterAPIServer(s *grpc.Server, srv APIServer) {
-	s.RegisterService(&_API_serviceDesc, srv)
+var xxx_messageInfo_ExportStateRequest proto.InternalMessageInfo
+
+type ExportStateResponse struct {
+	// state is the JSON encoding of an ExportedState.
+	State                []byte   `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _API_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ActivateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).Activate(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/enterprise.API/Activate",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).Activate(ctx, req.(*ActivateRequest))
+func (m *ExportStateResponse) Reset()         { *m = ExportStateResponse{} }
+func (m *ExportStateResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportStateResponse) ProtoMessage()    {}
+func (*ExportStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{15}
+}
+func (m *ExportStateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportStateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *ExportStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportStateResponse.Merge(m, src)
+}
+func (m *ExportStateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportStateResponse.DiscardUnknown(m)
 }
 
-func _API_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetStateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).GetState(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/enterprise.API/GetState",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).GetState(ctx, req.(*GetStateRequest))
+var xxx_messageInfo_ExportStateResponse proto.InternalMessageInfo
+
+func (m *ExportStateResponse) GetState() []byte {
+	if m != nil {
+		return m.State
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_GetActivationCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetActivationCodeRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).GetActivationCode(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/enterprise.API/GetActivationCode",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).GetActivationCode(ctx, req.(*GetActivationCodeRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type ImportStateRequest struct {
+	// state is the JSON encoding of an ExportedState, as returned by
+	// ExportState.
+	State                []byte   `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _API_Deactivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeactivateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).Deactivate(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/enterprise.API/Deactivate",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).Deactivate(ctx, req.(*DeactivateRequest))
+func (m *ImportStateRequest) Reset()         { *m = ImportStateRequest{} }
+func (m *ImportStateRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportStateRequest) ProtoMessage()    {}
+func (*ImportStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{16}
+}
+func (m *ImportStateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImportStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImportStateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
-
-var _API_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "enterprise.API",
-	HandlerType: (*APIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Activate",
-			Handler:    _API_Activate_Handler,
-		},
-		{
-			MethodName: "GetState",
-			Handler:    _API_GetState_Handler,
-		},
-		{
-			MethodName: "GetActivationCode",
-			Handler:    _API_GetActivationCode_Handler,
-		},
-		{
-			MethodName: "Deactivate",
-			Handler:    _API_Deactivate_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "client/enterprise/enterprise.proto",
+func (m *ImportStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportStateRequest.Merge(m, src)
+}
+func (m *ImportStateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImportStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportStateRequest.DiscardUnknown(m)
 }
 
-func (m *EnterpriseRecord) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ImportStateRequest proto.InternalMessageInfo
+
+func (m *ImportStateRequest) GetState() []byte {
+	if m != nil {
+		return m.State
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *EnterpriseRecord) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ImportStateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EnterpriseRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Expires != nil {
-		{
-			size, err := m.Expires.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+func (m *ImportStateResponse) Reset()         { *m = ImportStateResponse{} }
+func (m *ImportStateResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportStateResponse) ProtoMessage()    {}
+func (*ImportStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{17}
+}
+func (m *ImportStateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImportStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImportStateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ActivationCode) > 0 {
-		i -= len(m.ActivationCode)
-		copy(dAtA[i:], m.ActivationCode)
-		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *TokenInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ImportStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportStateResponse.Merge(m, src)
+}
+func (m *ImportStateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImportStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportStateResponse.DiscardUnknown(m)
 }
 
-func (m *TokenInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_ImportStateResponse proto.InternalMessageInfo
+
+// Entitlement is what an activation code grants, as decoded from its signed
+// token. The claims that the code doesn't include are left empty.
+type Entitlement struct {
+	Expires              *types.Timestamp `protobuf:"bytes,1,opt,name=expires,proto3" json:"expires,omitempty"`
+	Customer             string           `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+	Features             []string         `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`
+	Seats                int64            `protobuf:"varint,4,opt,name=seats,proto3" json:"seats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *TokenInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Expires != nil {
-		{
-			size, err := m.Expires.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+func (m *Entitlement) Reset()         { *m = Entitlement{} }
+func (m *Entitlement) String() string { return proto.CompactTextString(m) }
+func (*Entitlement) ProtoMessage()    {}
+func (*Entitlement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{18}
+}
+func (m *Entitlement) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Entitlement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Entitlement.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *Entitlement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Entitlement.Merge(m, src)
+}
+func (m *Entitlement) XXX_Size() int {
+	return m.Size()
+}
+func (m *Entitlement) XXX_DiscardUnknown() {
+	xxx_messageInfo_Entitlement.DiscardUnknown(m)
 }
 
-func (m *ActivateRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Entitlement proto.InternalMessageInfo
+
+func (m *Entitlement) GetExpires() *types.Timestamp {
+	if m != nil {
+		return m.Expires
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ActivateRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Entitlement) GetCustomer() string {
+	if m != nil {
+		return m.Customer
+	}
+	return ""
 }
 
-func (m *ActivateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Expires != nil {
-		{
-			size, err := m.Expires.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEnterprise(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ActivationCode) > 0 {
-		i -= len(m.ActivationCode)
-		copy(dAtA[i:], m.ActivationCode)
-		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
-		i--
-		dAtA[i] = 0xa
+func (m *Entitlement) GetFeatures() []string {
+	if m != nil {
+		return m.Features
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *ActivateResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Entitlement) GetSeats() int64 {
+	if m != nil {
+		return m.Seats
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ActivateResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type DecodeActivationCodeRequest struct {
+	ActivationCode       string   `protobuf:"bytes,1,opt,name=activation_code,json=activationCode,proto3" json:"activation_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ActivateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Info != nil {
-		{
-			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+func (m *DecodeActivationCodeRequest) Reset()         { *m = DecodeActivationCodeRequest{} }
+func (m *DecodeActivationCodeRequest) String() string { return proto.CompactTextString(m) }
+func (*DecodeActivationCodeRequest) ProtoMessage()    {}
+func (*DecodeActivationCodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{19}
+}
+func (m *DecodeActivationCodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DecodeActivationCodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DecodeActivationCodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *GetStateRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *DecodeActivationCodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecodeActivationCodeRequest.Merge(m, src)
 }
-
-func (m *GetStateRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DecodeActivationCodeRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *GetStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return len(dAtA) - i, nil
+func (m *DecodeActivationCodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecodeActivationCodeRequest.DiscardUnknown(m)
 }
 
-func (m *GetStateResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DecodeActivationCodeRequest proto.InternalMessageInfo
+
+func (m *DecodeActivationCodeRequest) GetActivationCode() string {
+	if m != nil {
+		return m.ActivationCode
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *GetStateResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type DecodeActivationCodeResponse struct {
+	Entitlement          *Entitlement `protobuf:"bytes,1,opt,name=entitlement,proto3" json:"entitlement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *GetStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ActivationCode) > 0 {
-		i -= len(m.ActivationCode)
-		copy(dAtA[i:], m.ActivationCode)
-		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Info != nil {
-		{
-			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+func (m *DecodeActivationCodeResponse) Reset()         { *m = DecodeActivationCodeResponse{} }
+func (m *DecodeActivationCodeResponse) String() string { return proto.CompactTextString(m) }
+func (*DecodeActivationCodeResponse) ProtoMessage()    {}
+func (*DecodeActivationCodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_88d07275108cec01, []int{20}
+}
+func (m *DecodeActivationCodeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DecodeActivationCodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DecodeActivationCodeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.State != 0 {
-		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
-		i--
-		dAtA[i] = 0x8
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *GetActivationCodeRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *DecodeActivationCodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecodeActivationCodeResponse.Merge(m, src)
 }
-
-func (m *GetActivationCodeRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DecodeActivationCodeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DecodeActivationCodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecodeActivationCodeResponse.DiscardUnknown(m)
 }
 
-func (m *GetActivationCodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_DecodeActivationCodeResponse proto.InternalMessageInfo
+
+func (m *DecodeActivationCodeResponse) GetEntitlement() *Entitlement {
+	if m != nil {
+		return m.Entitlement
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetActivationCodeResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func init() {
+	proto.RegisterEnum("enterprise.State", State_name, State_value)
+	proto.RegisterType((*EnterpriseRecord)(nil), "enterprise.EnterpriseRecord")
+	proto.RegisterType((*TokenInfo)(nil), "enterprise.TokenInfo")
+	proto.RegisterType((*ActivateRequest)(nil), "enterprise.ActivateRequest")
+	proto.RegisterType((*ActivateResponse)(nil), "enterprise.ActivateResponse")
+	proto.RegisterType((*GetStateRequest)(nil), "enterprise.GetStateRequest")
+	proto.RegisterType((*GetStateResponse)(nil), "enterprise.GetStateResponse")
+	proto.RegisterType((*GetActivationCodeRequest)(nil), "enterprise.GetActivationCodeRequest")
+	proto.RegisterType((*GetActivationCodeResponse)(nil), "enterprise.GetActivationCodeResponse")
+	proto.RegisterType((*StageActivationCodeRequest)(nil), "enterprise.StageActivationCodeRequest")
+	proto.RegisterType((*StageActivationCodeResponse)(nil), "enterprise.StageActivationCodeResponse")
+	proto.RegisterType((*DeactivateRequest)(nil), "enterprise.DeactivateRequest")
+	proto.RegisterType((*DeactivateResponse)(nil), "enterprise.DeactivateResponse")
+	proto.RegisterType((*ExportedState)(nil), "enterprise.ExportedState")
+	proto.RegisterType((*ExportedConfig)(nil), "enterprise.ExportedConfig")
+	proto.RegisterType((*ExportStateRequest)(nil), "enterprise.ExportStateRequest")
+	proto.RegisterType((*ExportStateResponse)(nil), "enterprise.ExportStateResponse")
+	proto.RegisterType((*ImportStateRequest)(nil), "enterprise.ImportStateRequest")
+	proto.RegisterType((*ImportStateResponse)(nil), "enterprise.ImportStateResponse")
+	proto.RegisterType((*Entitlement)(nil), "enterprise.Entitlement")
+	proto.RegisterType((*DecodeActivationCodeRequest)(nil), "enterprise.DecodeActivationCodeRequest")
+	proto.RegisterType((*DecodeActivationCodeResponse)(nil), "enterprise.DecodeActivationCodeResponse")
 }
 
-func (m *GetActivationCodeResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func init() {
+	proto.RegisterFile("client/enterprise/enterprise.proto", fileDescriptor_88d07275108cec01)
 }
 
-func (m *GetActivationCodeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+var fileDescriptor_88d07275108cec01 = []byte{
+	// 1029 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xcd, 0x6e, 0xe3, 0x54,
+	0x14, 0xae, 0x9b, 0x36, 0x6d, 0x8e, 0x99, 0x36, 0xb9, 0x6d, 0x69, 0xc6, 0x2d, 0x69, 0x64, 0x01,
+	0xcd, 0x14, 0x91, 0x48, 0xa1, 0x42, 0x42, 0x23, 0x16, 0x69, 0x1b, 0x4a, 0x24, 0x18, 0x8a, 0x5b,
+	0x10, 0xb0, 0x89, 0x9c, 0xf8, 0x24, 0xb1, 0x1a, 0xfb, 0x86, 0xeb, 0x1b, 0x98, 0x59, 0x82, 0xc4,
+	0x03, 0xf0, 0x28, 0xf0, 0x0e, 0x48, 0x2c, 0x59, 0xb2, 0x44, 0x7d, 0x10, 0x84, 0x7c, 0x7d, 0xed,
+	0xd8, 0x8e, 0xd3, 0x74, 0x18, 0x21, 0xb1, 0x73, 0xce, 0xcf, 0x77, 0xfe, 0xfc, 0x9d, 0xe3, 0x80,
+	0xde, 0x1f, 0xdb, 0xe8, 0xf2, 0x06, 0xba, 0x1c, 0xd9, 0x84, 0xd9, 0x1e, 0xc6, 0x1e, 0xeb, 0x13,
+	0x46, 0x39, 0x25, 0x30, 0x93, 0x68, 0x95, 0x21, 0xa5, 0xc3, 0x31, 0x36, 0x84, 0xa6, 0x37, 0x1d,
+	0x34, 0xac, 0x29, 0x33, 0xb9, 0x4d, 0xdd, 0xc0, 0x56, 0x3b, 0x4a, 0xeb, 0xb9, 0xed, 0xa0, 0xc7,
+	0x4d, 0x67, 0x12, 0x18, 0xe8, 0x3f, 0xe4, 0xa0, 0xd8, 0x8e, 0xf0, 0x0c, 0xec, 0x53, 0x66, 0x91,
+	0x63, 0xd8, 0x36, 0xfb, 0xdc, 0xfe, 0x4e, 0x20, 0x75, 0xfb, 0xd4, 0xc2, 0xb2, 0x52, 0x55, 0x6a,
+	0x05, 0x63, 0x6b, 0x26, 0x3e, 0xa7, 0x16, 0x92, 0x53, 0xd8, 0xc0, 0xe7, 0x13, 0x9b, 0xa1, 0x57,
+	0x5e, 0xad, 0x2a, 0x35, 0xb5, 0xa9, 0xd5, 0x83, 0x80, 0xf5, 0x30, 0x60, 0xfd, 0x26, 0x0c, 0x68,
+	0x84, 0xa6, 0xe4, 0x09, 0x14, 0x53, 0xf0, 0x5e, 0x39, 0x57, 0xcd, 0xd5, 0x0a, 0xc6, 0x76, 0x12,
+	0xdf, 0x23, 0x55, 0x50, 0x2d, 0x94, 0x42, 0xb4, 0xca, 0x6b, 0x55, 0xa5, 0xb6, 0x69, 0xc4, 0x45,
+	0xe4, 0x14, 0x5e, 0xf7, 0xb8, 0x39, 0x44, 0xab, 0x9b, 0x4e, 0x79, 0x5d, 0xa4, 0xbc, 0x1b, 0x68,
+	0x5b, 0xc9, 0xc4, 0x5b, 0xb0, 0x25, 0xbd, 0xc2, 0xfc, 0xf3, 0x4b, 0xf3, 0x7f, 0x14, 0x78, 0xb4,
+	0x65, 0x15, 0x1f, 0x03, 0x91, 0x10, 0x1e, 0x37, 0x19, 0xef, 0x9a, 0x03, 0x8e, 0xac, 0xbc, 0xb1,
+	0x14, 0xa6, 0x18, 0x78, 0x5d, 0xfb, 0x4e, 0x2d, 0xdf, 0x47, 0x6f, 0x41, 0xe1, 0x86, 0xde, 0xa2,
+	0xdb, 0x71, 0x07, 0x34, 0xde, 0x52, 0xe5, 0xc1, 0x2d, 0xd5, 0x7f, 0x55, 0x60, 0x5b, 0x96, 0x88,
+	0x06, 0x7e, 0x3b, 0x45, 0x8f, 0xff, 0xd7, 0x53, 0x2c, 0x42, 0xce, 0xb4, 0xac, 0x72, 0x4e, 0x8c,
+	0xc4, 0x7f, 0x24, 0x75, 0xd8, 0x49, 0x05, 0xec, 0x32, 0x1c, 0x88, 0xa1, 0x15, 0x8c, 0x52, 0x32,
+	0xa8, 0x81, 0x03, 0xfd, 0x43, 0x28, 0xce, 0x72, 0xf6, 0x26, 0xd4, 0xf5, 0x90, 0x3c, 0x81, 0x35,
+	0xdb, 0x1d, 0x50, 0x59, 0xfb, 0x5e, 0x3d, 0xf6, 0xf6, 0x47, 0x3d, 0x32, 0x84, 0x89, 0x5e, 0x82,
+	0xed, 0x4b, 0xe4, 0xd7, 0x7c, 0x56, 0xb2, 0xfe, 0x8b, 0x02, 0xc5, 0x99, 0x4c, 0x42, 0x1e, 0xc3,
+	0xba, 0xe7, 0x0b, 0x04, 0xe6, 0x56, 0xb3, 0x14, 0xc7, 0x0c, 0x2c, 0x03, 0x7d, 0x14, 0x7b, 0x75,
+	0x69, 0xec, 0xac, 0xde, 0xe6, 0x32, 0x7b, 0xbb, 0xf4, 0x05, 0xd6, 0x35, 0x28, 0x5f, 0x22, 0x6f,
+	0xa5, 0xba, 0x13, 0xd4, 0xf3, 0xe7, 0x2a, 0x3c, 0xce, 0x50, 0xfe, 0x1f, 0x0a, 0xcb, 0x22, 0xf1,
+	0xda, 0x83, 0x48, 0xbc, 0x3e, 0x4f, 0xe2, 0xf7, 0x41, 0x95, 0x5c, 0x12, 0x79, 0xe6, 0xef, 0xcb,
+	0x13, 0x02, 0x4b, 0xff, 0x99, 0x7c, 0x00, 0x2a, 0xba, 0xdc, 0xe6, 0x63, 0x74, 0xd0, 0xe5, 0x92,
+	0x7c, 0xfb, 0x71, 0xbf, 0xf6, 0x4c, 0x6d, 0xc4, 0x6d, 0xf5, 0x1f, 0x15, 0xd0, 0xae, 0x7d, 0xa4,
+	0xcc, 0xce, 0x3f, 0x9c, 0x3c, 0x4f, 0x41, 0x8d, 0xf3, 0x7f, 0x39, 0x81, 0xc0, 0x9b, 0x31, 0xff,
+	0x27, 0x05, 0x0e, 0x32, 0x93, 0x78, 0x69, 0x36, 0xbc, 0x5a, 0x1e, 0x67, 0x50, 0xba, 0x88, 0xc6,
+	0x11, 0xb6, 0xe0, 0x5d, 0x20, 0x7d, 0xea, 0x0e, 0x6c, 0xe6, 0x04, 0x4d, 0xe0, 0x7e, 0x44, 0xd9,
+	0x85, 0x52, 0x5c, 0x23, 0x52, 0xd1, 0xcf, 0x81, 0xc4, 0x31, 0x64, 0x05, 0x2f, 0x09, 0xf2, 0x02,
+	0x1e, 0xb5, 0x9f, 0x4f, 0x28, 0xe3, 0x62, 0x41, 0x72, 0x7f, 0x37, 0xe5, 0x99, 0x38, 0x4a, 0xb2,
+	0x07, 0x87, 0xa9, 0xe1, 0x26, 0x0e, 0x97, 0x21, 0x6d, 0x49, 0x13, 0xf2, 0x02, 0x7b, 0x18, 0xf5,
+	0x21, 0xee, 0x25, 0x03, 0x9c, 0x0b, 0x0b, 0x43, 0x5a, 0xea, 0x7f, 0x2b, 0xb0, 0x95, 0x54, 0x91,
+	0xa7, 0xa0, 0xcd, 0x2d, 0x34, 0x93, 0x63, 0x77, 0x6c, 0x3b, 0x36, 0x17, 0x09, 0x29, 0xc6, 0x7e,
+	0x6a, 0xaf, 0x99, 0x1c, 0x3f, 0xf1, 0xd5, 0x0b, 0x9d, 0x7b, 0x53, 0xe6, 0x71, 0x91, 0x57, 0x2e,
+	0xcb, 0xf9, 0xcc, 0x57, 0x93, 0x23, 0x50, 0xbf, 0xc7, 0xde, 0x88, 0xd2, 0xdb, 0xee, 0x94, 0x8d,
+	0x25, 0x05, 0x41, 0x8a, 0xbe, 0x60, 0x63, 0xf2, 0x39, 0xec, 0x8b, 0x45, 0x6c, 0xbb, 0xc3, 0xae,
+	0x47, 0xfd, 0xc6, 0x8e, 0x18, 0x7a, 0x23, 0x3a, 0x0e, 0x76, 0x8c, 0xda, 0x7c, 0x3c, 0x37, 0xfa,
+	0x0b, 0xf9, 0x69, 0x60, 0xec, 0x85, 0x9e, 0xd7, 0x94, 0xba, 0x37, 0xa1, 0x9f, 0xbe, 0x0b, 0x24,
+	0xa8, 0x3f, 0xb1, 0x52, 0xdf, 0x81, 0x9d, 0x84, 0x54, 0xce, 0x75, 0x37, 0xbe, 0x7b, 0x5e, 0x93,
+	0x8b, 0x46, 0x3f, 0x01, 0xd2, 0x71, 0xd2, 0x10, 0x0b, 0x6c, 0xf7, 0x60, 0xa7, 0xe3, 0xcc, 0x01,
+	0xeb, 0x3f, 0x2b, 0xa0, 0xc6, 0x48, 0xfb, 0xef, 0xee, 0x21, 0xd1, 0x60, 0xb3, 0x3f, 0xf5, 0x38,
+	0x75, 0x24, 0x15, 0x0a, 0x46, 0xf4, 0xdb, 0xd7, 0x0d, 0xd0, 0xe4, 0x53, 0x16, 0x7d, 0x76, 0x44,
+	0xbf, 0x45, 0xaa, 0x68, 0x72, 0x4f, 0x34, 0x31, 0x67, 0x04, 0x3f, 0xf4, 0x8f, 0xe0, 0xe0, 0x02,
+	0xfd, 0x09, 0xbe, 0xda, 0xae, 0xd0, 0xbf, 0x86, 0xc3, 0x6c, 0x1c, 0xd9, 0xd4, 0xd4, 0x3a, 0x53,
+	0x1e, 0xbe, 0xce, 0x4e, 0x4e, 0x60, 0x3d, 0x20, 0xcc, 0x26, 0xac, 0x3d, 0xfb, 0xec, 0x59, 0xbb,
+	0xb8, 0x42, 0x00, 0xf2, 0xad, 0xf3, 0x9b, 0xce, 0x97, 0xed, 0xa2, 0x42, 0x54, 0xd8, 0x68, 0x7f,
+	0x75, 0xd5, 0x31, 0xda, 0x17, 0xc5, 0xd5, 0xe6, 0x6f, 0xeb, 0x90, 0x6b, 0x5d, 0x75, 0xc8, 0x25,
+	0x6c, 0xca, 0x44, 0x90, 0x1c, 0xc4, 0xa3, 0xa4, 0xbe, 0x24, 0xb4, 0xc3, 0x6c, 0xa5, 0x9c, 0xd8,
+	0x8a, 0x0f, 0x14, 0x5e, 0xdd, 0x24, 0x50, 0xea, 0x3e, 0x6b, 0x87, 0xd9, 0xca, 0x08, 0xa8, 0x07,
+	0xa5, 0xb9, 0x73, 0x47, 0xde, 0x4c, 0x39, 0x65, 0x0e, 0x41, 0x7b, 0x6b, 0x89, 0x55, 0x14, 0x63,
+	0x04, 0x3b, 0x19, 0x2b, 0x97, 0xbc, 0x9d, 0xba, 0x9e, 0x0b, 0x0e, 0x83, 0x76, 0xbc, 0xd4, 0x2e,
+	0x8a, 0x74, 0x05, 0x6a, 0x8c, 0x3a, 0xa4, 0x32, 0xbf, 0x84, 0x12, 0xcd, 0x39, 0x5a, 0xa8, 0x8f,
+	0x23, 0x76, 0x9c, 0x05, 0x88, 0x1d, 0xe7, 0x7e, 0xc4, 0x2c, 0xb2, 0xad, 0x90, 0x5b, 0xd8, 0xcd,
+	0x7a, 0x25, 0x49, 0xa2, 0xcc, 0x7b, 0x5e, 0x7e, 0xad, 0xb6, 0xdc, 0x30, 0x0a, 0xf6, 0x29, 0xc0,
+	0xec, 0x44, 0x90, 0x37, 0x92, 0x9e, 0xa9, 0xf3, 0xa3, 0x55, 0x16, 0xa9, 0x43, 0xb8, 0xb3, 0xb3,
+	0xdf, 0xef, 0x2a, 0xca, 0x1f, 0x77, 0x15, 0xe5, 0xaf, 0xbb, 0x8a, 0xf2, 0xcd, 0xe9, 0xd0, 0xe6,
+	0xa3, 0x69, 0xaf, 0xde, 0xa7, 0x4e, 0x63, 0x62, 0xf6, 0x47, 0x2f, 0x2c, 0x64, 0xf1, 0x27, 0x8f,
+	0xf5, 0x1b, 0x73, 0xff, 0xae, 0x7a, 0x79, 0xb1, 0x45, 0xde, 0xfb, 0x67, 0x00, 0x42, 0xc1, 0x57,
+	0x7a, 0x79, 0x0d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type APIClient interface {
+	// Provide a Pachyderm enterprise token, enabling Pachyderm enterprise
+	// features, such as the Pachyderm Dashboard and Auth system
+	Activate(ctx context.Context, in *ActivateRequest, opts ...grpc.CallOption) (*ActivateResponse, error)
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error)
+	GetActivationCode(ctx context.Context, in *GetActivationCodeRequest, opts ...grpc.CallOption) (*GetActivationCodeResponse, error)
+	// StageActivationCode stages an activation code (e.g. a renewed license),
+	// which automatically replaces the cluster's active activation codes once
+	// they expire.
+	StageActivationCode(ctx context.Context, in *StageActivationCodeRequest, opts ...grpc.CallOption) (*StageActivationCodeResponse, error)
+	// ExportState returns the server's enterprise state and configuration as
+	// JSON, for backups and support. ImportState restores the state onto a
+	// server that hasn't been activated.
+	ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (*ExportStateResponse, error)
+	ImportState(ctx context.Context, in *ImportStateRequest, opts ...grpc.CallOption) (*ImportStateResponse, error)
+	// DecodeActivationCode validates an activation code and returns what it
+	// grants, without activating it or otherwise changing the cluster's state.
+	DecodeActivationCode(ctx context.Context, in *DecodeActivationCodeRequest, opts ...grpc.CallOption) (*DecodeActivationCodeResponse, error)
+	// Deactivate is a testing API. It removes a cluster's enterprise activation
+	// token and sets its enterprise state to NONE (normally, once a cluster has
+	// been activated, the only reachable state is EXPIRED).
+	//
+	// NOTE: This endpoint also calls DeleteAll (and deletes all Pachyderm data in
+	// its cluster). This is to avoid dealing with invalid, intermediate states
+	// (e.g. auth is activated but enterprise state is NONE)
+	//
+	// Deactivation is a two step process: a request without a confirmation
+	// token only returns a short-lived token, and a second request with that
+	// token deactivates the cluster.
+	Deactivate(ctx context.Context, in *DeactivateRequest, opts ...grpc.CallOption) (*DeactivateResponse, error)
+}
+
+type aPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAPIClient(cc *grpc.ClientConn) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) Activate(ctx context.Context, in *ActivateRequest, opts ...grpc.CallOption) (*ActivateResponse, error) {
+	out := new(ActivateResponse)
+	err := c.cc.Invoke(ctx, "/enterprise.API/Activate", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.ActivationCode) > 0 {
-		i -= len(m.ActivationCode)
-		copy(dAtA[i:], m.ActivationCode)
-		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
-		i--
-		dAtA[i] = 0x1a
+	return out, nil
+}
+
+func (c *aPIClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error) {
+	out := new(GetStateResponse)
+	err := c.cc.Invoke(ctx, "/enterprise.API/GetState", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Info != nil {
-		{
-			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintEnterprise(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	return out, nil
+}
+
+func (c *aPIClient) GetActivationCode(ctx context.Context, in *GetActivationCodeRequest, opts ...grpc.CallOption) (*GetActivationCodeResponse, error) {
+	out := new(GetActivationCodeResponse)
+	err := c.cc.Invoke(ctx, "/enterprise.API/GetActivationCode", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.State != 0 {
-		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
-		i--
-		dAtA[i] = 0x8
+	return out, nil
+}
+
+func (c *aPIClient) StageActivationCode(ctx context.Context, in *StageActivationCodeRequest, opts ...grpc.CallOption) (*StageActivationCodeResponse, error) {
+	out := new(StageActivationCodeResponse)
+	err := c.cc.Invoke(ctx, "/enterprise.API/StageActivationCode", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *DeactivateRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *aPIClient) ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (*ExportStateResponse, error) {
+	out := new(ExportStateResponse)
+	err := c.cc.Invoke(ctx, "/enterprise.API/ExportState", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *DeactivateRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *aPIClient) ImportState(ctx context.Context, in *ImportStateRequest, opts ...grpc.CallOption) (*ImportStateResponse, error) {
+	out := new(ImportStateResponse)
+	err := c.cc.Invoke(ctx, "/enterprise.API/ImportState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *DeactivateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (c *aPIClient) DecodeActivationCode(ctx context.Context, in *DecodeActivationCodeRequest, opts ...grpc.CallOption) (*DecodeActivationCodeResponse, error) {
+	out := new(DecodeActivationCodeResponse)
+	err := c.cc.Invoke(ctx, "/enterprise.API/DecodeActivationCode", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *DeactivateResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *aPIClient) Deactivate(ctx context.Context, in *DeactivateRequest, opts ...grpc.CallOption) (*DeactivateResponse, error) {
+	out := new(DeactivateResponse)
+	err := c.cc.Invoke(ctx, "/enterprise.API/Deactivate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *DeactivateResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// APIServer is the server API for API service.
+type APIServer interface {
+	// Provide a Pachyderm enterprise token, enabling Pachyderm enterprise
+	// features, such as the Pachyderm Dashboard and Auth system
+	Activate(context.Context, *ActivateRequest) (*ActivateResponse, error)
+	GetState(context.Context, *GetStateRequest) (*GetStateResponse, error)
+	GetActivationCode(context.Context, *GetActivationCodeRequest) (*GetActivationCodeResponse, error)
+	// StageActivationCode stages an activation code (e.g. a renewed license),
+	// which automatically replaces the cluster's active activation codes once
+	// they expire.
+	StageActivationCode(context.Context, *StageActivationCodeRequest) (*StageActivationCodeResponse, error)
+	// ExportState returns the server's enterprise state and configuration as
+	// JSON, for backups and support. ImportState restores the state onto a
+	// server that hasn't been activated.
+	ExportState(context.Context, *ExportStateRequest) (*ExportStateResponse, error)
+	ImportState(context.Context, *ImportStateRequest) (*ImportStateResponse, error)
+	// DecodeActivationCode validates an activation code and returns what it
+	// grants, without activating it or otherwise changing the cluster's state.
+	DecodeActivationCode(context.Context, *DecodeActivationCodeRequest) (*DecodeActivationCodeResponse, error)
+	// Deactivate is a testing API. It removes a cluster's enterprise activation
+	// token and sets its enterprise state to NONE (normally, once a cluster has
+	// been activated, the only reachable state is EXPIRED).
+	//
+	// NOTE: This endpoint also calls DeleteAll (and deletes all Pachyderm data in
+	// its cluster). This is to avoid dealing with invalid, intermediate states
+	// (e.g. auth is activated but enterprise state is NONE)
+	//
+	// Deactivation is a two step process: a request without a confirmation
+	// token only returns a short-lived token, and a second request with that
+	// token deactivates the cluster.
+	Deactivate(context.Context, *DeactivateRequest) (*DeactivateResponse, error)
 }
 
-func (m *DeactivateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return len(dAtA) - i, nil
+// UnimplementedAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedAPIServer struct {
 }
 
-func encodeVarintEnterprise(dAtA []byte, offset int, v uint64) int {
-	offset -= sovEnterprise(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (*UnimplementedAPIServer) Activate(ctx context.Context, req *ActivateRequest) (*ActivateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Activate not implemented")
 }
-func (m *EnterpriseRecord) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ActivationCode)
-	if l > 0 {
-		n += 1 + l + sovEnterprise(uint64(l))
-	}
-	if m.Expires != nil {
-		l = m.Expires.Size()
-		n += 1 + l + sovEnterprise(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (*UnimplementedAPIServer) GetState(ctx context.Context, req *GetStateRequest) (*GetStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (*UnimplementedAPIServer) GetActivationCode(ctx context.Context, req *GetActivationCodeRequest) (*GetActivationCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivationCode not implemented")
+}
+func (*UnimplementedAPIServer) StageActivationCode(ctx context.Context, req *StageActivationCodeRequest) (*StageActivationCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StageActivationCode not implemented")
+}
+func (*UnimplementedAPIServer) ExportState(ctx context.Context, req *ExportStateRequest) (*ExportStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportState not implemented")
+}
+func (*UnimplementedAPIServer) ImportState(ctx context.Context, req *ImportStateRequest) (*ImportStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportState not implemented")
+}
+func (*UnimplementedAPIServer) DecodeActivationCode(ctx context.Context, req *DecodeActivationCodeRequest) (*DecodeActivationCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodeActivationCode not implemented")
+}
+func (*UnimplementedAPIServer) Deactivate(ctx context.Context, req *DeactivateRequest) (*DeactivateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deactivate not implemented")
 }
 
-func (m *TokenInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func RegisterAPIServer(s *grpc.Server, srv APIServer) {
+	s.RegisterService(&_API_serviceDesc, srv)
+}
+
+func _API_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Expires != nil {
-		l = m.Expires.Size()
-		n += 1 + l + sovEnterprise(uint64(l))
+	if interceptor == nil {
+		return srv.(APIServer).Activate(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/Activate",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Activate(ctx, req.(*ActivateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ActivateRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _API_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.ActivationCode)
-	if l > 0 {
-		n += 1 + l + sovEnterprise(uint64(l))
+	if interceptor == nil {
+		return srv.(APIServer).GetState(ctx, in)
 	}
-	if m.Expires != nil {
-		l = m.Expires.Size()
-		n += 1 + l + sovEnterprise(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/GetState",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetState(ctx, req.(*GetStateRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ActivateResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _API_GetActivationCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActivationCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovEnterprise(uint64(l))
+	if interceptor == nil {
+		return srv.(APIServer).GetActivationCode(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/GetActivationCode",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetActivationCode(ctx, req.(*GetActivationCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetStateRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _API_StageActivationCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StageActivationCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if interceptor == nil {
+		return srv.(APIServer).StageActivationCode(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/StageActivationCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).StageActivationCode(ctx, req.(*StageActivationCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetStateResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _API_ExportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.State != 0 {
-		n += 1 + sovEnterprise(uint64(m.State))
+	if interceptor == nil {
+		return srv.(APIServer).ExportState(ctx, in)
 	}
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovEnterprise(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/ExportState",
 	}
-	l = len(m.ActivationCode)
-	if l > 0 {
-		n += 1 + l + sovEnterprise(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ExportState(ctx, req.(*ExportStateRequest))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ImportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return n
+	if interceptor == nil {
+		return srv.(APIServer).ImportState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/ImportState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ImportState(ctx, req.(*ImportStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetActivationCodeRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _API_DecodeActivationCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecodeActivationCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if interceptor == nil {
+		return srv.(APIServer).DecodeActivationCode(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/DecodeActivationCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DecodeActivationCode(ctx, req.(*DecodeActivationCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetActivationCodeResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _API_Deactivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Deactivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/enterprise.API/Deactivate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Deactivate(ctx, req.(*DeactivateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _API_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "enterprise.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Activate",
+			Handler:    _API_Activate_Handler,
+		},
+		{
+			MethodName: "GetState",
+			Handler:    _API_GetState_Handler,
+		},
+		{
+			MethodName: "GetActivationCode",
+			Handler:    _API_GetActivationCode_Handler,
+		},
+		{
+			MethodName: "StageActivationCode",
+			Handler:    _API_StageActivationCode_Handler,
+		},
+		{
+			MethodName: "ExportState",
+			Handler:    _API_ExportState_Handler,
+		},
+		{
+			MethodName: "ImportState",
+			Handler:    _API_ImportState_Handler,
+		},
+		{
+			MethodName: "DecodeActivationCode",
+			Handler:    _API_DecodeActivationCode_Handler,
+		},
+		{
+			MethodName: "Deactivate",
+			Handler:    _API_Deactivate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "client/enterprise/enterprise.proto",
+}
+
+func (m *EnterpriseRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *EnterpriseRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EnterpriseRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.State != 0 {
-		n += 1 + sovEnterprise(uint64(m.State))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovEnterprise(uint64(l))
+	if m.StagedStartAfter != nil {
+		{
+			size, err := m.StagedStartAfter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
 	}
-	l = len(m.ActivationCode)
-	if l > 0 {
-		n += 1 + l + sovEnterprise(uint64(l))
+	if m.StagedExpires != nil {
+		{
+			size, err := m.StagedExpires.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.StagedActivationCode) > 0 {
+		i -= len(m.StagedActivationCode)
+		copy(dAtA[i:], m.StagedActivationCode)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.StagedActivationCode)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	return n
+	if m.Deactivated {
+		i--
+		if m.Deactivated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ActivationCodes) > 0 {
+		for iNdEx := len(m.ActivationCodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ActivationCodes[iNdEx])
+			copy(dAtA[i:], m.ActivationCodes[iNdEx])
+			i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCodes[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Expires != nil {
+		{
+			size, err := m.Expires.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ActivationCode) > 0 {
+		i -= len(m.ActivationCode)
+		copy(dAtA[i:], m.ActivationCode)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *DeactivateRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TokenInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokenInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Expires != nil {
+		{
+			size, err := m.Expires.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ActivateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ActivateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ActivationCodeRef) > 0 {
+		i -= len(m.ActivationCodeRef)
+		copy(dAtA[i:], m.ActivationCodeRef)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCodeRef)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Add {
+		i--
+		if m.Add {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Expires != nil {
+		{
+			size, err := m.Expires.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ActivationCode) > 0 {
+		i -= len(m.ActivationCode)
+		copy(dAtA[i:], m.ActivationCode)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ActivateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ActivateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Info != nil {
+		{
+			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Deactivated {
+		i--
+		if m.Deactivated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ActivationCode) > 0 {
+		i -= len(m.ActivationCode)
+		copy(dAtA[i:], m.ActivationCode)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Info != nil {
+		{
+			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.State != 0 {
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetActivationCodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetActivationCodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetActivationCodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetActivationCodeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetActivationCodeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetActivationCodeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Entitlement != nil {
+		{
+			size, err := m.Entitlement.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.StagedInfo != nil {
+		{
+			size, err := m.StagedInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Deactivated {
+		i--
+		if m.Deactivated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.ActivationCodes) > 0 {
+		for iNdEx := len(m.ActivationCodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ActivationCodes[iNdEx])
+			copy(dAtA[i:], m.ActivationCodes[iNdEx])
+			i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCodes[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ActivationCode) > 0 {
+		i -= len(m.ActivationCode)
+		copy(dAtA[i:], m.ActivationCode)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Info != nil {
+		{
+			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.State != 0 {
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.State))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StageActivationCodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StageActivationCodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StageActivationCodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.StartAfter != nil {
+		{
+			size, err := m.StartAfter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ActivationCode) > 0 {
+		i -= len(m.ActivationCode)
+		copy(dAtA[i:], m.ActivationCode)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StageActivationCodeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StageActivationCodeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StageActivationCodeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.StartAfter != nil {
+		{
+			size, err := m.StartAfter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Info != nil {
+		{
+			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeactivateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeactivateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeactivateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ConfirmationToken) > 0 {
+		i -= len(m.ConfirmationToken)
+		copy(dAtA[i:], m.ConfirmationToken)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ConfirmationToken)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeactivateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeactivateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeactivateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ConfirmationToken) > 0 {
+		i -= len(m.ConfirmationToken)
+		copy(dAtA[i:], m.ConfirmationToken)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ConfirmationToken)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportedState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportedState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportedState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Config != nil {
+		{
+			size, err := m.Config.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportedConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportedConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportedConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ExpiringSoonThreshold != nil {
+		{
+			size, err := m.ExpiringSoonThreshold.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.WebhookUrl) > 0 {
+		i -= len(m.WebhookUrl)
+		copy(dAtA[i:], m.WebhookUrl)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.WebhookUrl)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ActivationCodeRateBurst != 0 {
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.ActivationCodeRateBurst))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ActivationCodeRateLimit != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ActivationCodeRateLimit))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImportStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImportStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImportStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImportStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Entitlement) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Entitlement) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Entitlement) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Seats != 0 {
+		i = encodeVarintEnterprise(dAtA, i, uint64(m.Seats))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Customer) > 0 {
+		i -= len(m.Customer)
+		copy(dAtA[i:], m.Customer)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.Customer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Expires != nil {
+		{
+			size, err := m.Expires.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DecodeActivationCodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DecodeActivationCodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DecodeActivationCodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ActivationCode) > 0 {
+		i -= len(m.ActivationCode)
+		copy(dAtA[i:], m.ActivationCode)
+		i = encodeVarintEnterprise(dAtA, i, uint64(len(m.ActivationCode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DecodeActivationCodeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DecodeActivationCodeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DecodeActivationCodeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Entitlement != nil {
+		{
+			size, err := m.Entitlement.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEnterprise(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintEnterprise(dAtA []byte, offset int, v uint64) int {
+	offset -= sovEnterprise(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *EnterpriseRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.ActivationCodes) > 0 {
+		for _, s := range m.ActivationCodes {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if m.Deactivated {
+		n += 2
+	}
+	l = len(m.StagedActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.StagedExpires != nil {
+		l = m.StagedExpires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.StagedStartAfter != nil {
+		l = m.StagedStartAfter.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TokenInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ActivateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Add {
+		n += 2
+	}
+	l = len(m.ActivationCodeRef)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ActivateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Deactivated {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetActivationCodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetActivationCodeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovEnterprise(uint64(m.State))
+	}
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.ActivationCodes) > 0 {
+		for _, s := range m.ActivationCodes {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if m.Deactivated {
+		n += 2
+	}
+	if m.StagedInfo != nil {
+		l = m.StagedInfo.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Entitlement != nil {
+		l = m.Entitlement.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StageActivationCodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.StartAfter != nil {
+		l = m.StartAfter.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StageActivationCodeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.StartAfter != nil {
+		l = m.StartAfter.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeactivateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ConfirmationToken)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeactivateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ConfirmationToken)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExportedState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.Config != nil {
+		l = m.Config.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExportedConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ActivationCodeRateLimit != 0 {
+		n += 9
+	}
+	if m.ActivationCodeRateBurst != 0 {
+		n += 1 + sovEnterprise(uint64(m.ActivationCodeRateBurst))
+	}
+	l = len(m.WebhookUrl)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.ExpiringSoonThreshold != nil {
+		l = m.ExpiringSoonThreshold.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExportStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExportStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ImportStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ImportStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Entitlement) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Expires != nil {
+		l = m.Expires.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	l = len(m.Customer)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovEnterprise(uint64(l))
+		}
+	}
+	if m.Seats != 0 {
+		n += 1 + sovEnterprise(uint64(m.Seats))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DecodeActivationCodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ActivationCode)
+	if l > 0 {
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DecodeActivationCodeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Entitlement != nil {
+		l = m.Entitlement.Size()
+		n += 1 + l + sovEnterprise(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovEnterprise(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozEnterprise(x uint64) (n int) {
+	return sovEnterprise(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EnterpriseRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EnterpriseRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &types.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCodes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCodes = append(m.ActivationCodes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deactivated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deactivated = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StagedActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StagedActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StagedExpires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StagedExpires == nil {
+				m.StagedExpires = &types.Timestamp{}
+			}
+			if err := m.StagedExpires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StagedStartAfter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StagedStartAfter == nil {
+				m.StagedStartAfter = &types.Timestamp{}
+			}
+			if err := m.StagedStartAfter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokenInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &types.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &types.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Add", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Add = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCodeRef", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCodeRef = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Info == nil {
+				m.Info = &TokenInfo{}
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= State(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Info == nil {
+				m.Info = &TokenInfo{}
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deactivated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deactivated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetActivationCodeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetActivationCodeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetActivationCodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetActivationCodeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetActivationCodeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetActivationCodeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= State(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Info == nil {
+				m.Info = &TokenInfo{}
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCodes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCodes = append(m.ActivationCodes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deactivated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deactivated = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StagedInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StagedInfo == nil {
+				m.StagedInfo = &TokenInfo{}
+			}
+			if err := m.StagedInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entitlement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Entitlement == nil {
+				m.Entitlement = &Entitlement{}
+			}
+			if err := m.Entitlement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StageActivationCodeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StageActivationCodeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StageActivationCodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartAfter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StartAfter == nil {
+				m.StartAfter = &types.Timestamp{}
+			}
+			if err := m.StartAfter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *DeactivateResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovEnterprise(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozEnterprise(x uint64) (n int) {
-	return sovEnterprise(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
+func (m *StageActivationCodeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1399,17 +4479,17 @@ func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EnterpriseRecord: wiretype end group for non-group")
+			return fmt.Errorf("proto: StageActivationCodeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EnterpriseRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StageActivationCodeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEnterprise
@@ -1419,27 +4499,31 @@ func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEnterprise
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEnterprise
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			if m.Info == nil {
+				m.Info = &TokenInfo{}
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartAfter", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1466,10 +4550,10 @@ func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Expires == nil {
-				m.Expires = &types.Timestamp{}
+			if m.StartAfter == nil {
+				m.StartAfter = &types.Timestamp{}
 			}
-			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.StartAfter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1495,7 +4579,7 @@ func (m *EnterpriseRecord) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TokenInfo) Unmarshal(dAtA []byte) error {
+func (m *DeactivateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1518,17 +4602,17 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TokenInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeactivateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TokenInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeactivateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmationToken", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEnterprise
@@ -1538,27 +4622,23 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEnterprise
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEnterprise
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Expires == nil {
-				m.Expires = &types.Timestamp{}
-			}
-			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ConfirmationToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1582,7 +4662,7 @@ func (m *TokenInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
+func (m *DeactivateResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1605,15 +4685,15 @@ func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ActivateRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeactivateResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActivateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeactivateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmationToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1641,11 +4721,98 @@ func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			m.ConfirmationToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportedState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportedState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportedState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Record == nil {
+				m.Record = &EnterpriseRecord{}
+			}
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1672,10 +4839,10 @@ func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Expires == nil {
-				m.Expires = &types.Timestamp{}
+			if m.Config == nil {
+				m.Config = &ExportedConfig{}
 			}
-			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Config.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1701,7 +4868,7 @@ func (m *ActivateRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ActivateResponse) Unmarshal(dAtA []byte) error {
+func (m *ExportedConfig) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1711,28 +4878,90 @@ func (m *ActivateResponse) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowEnterprise
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportedConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportedConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCodeRateLimit", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ActivationCodeRateLimit = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCodeRateBurst", wireType)
+			}
+			m.ActivationCodeRateBurst = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActivationCodeRateBurst |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WebhookUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActivateResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActivateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.WebhookUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiringSoonThreshold", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1759,10 +4988,10 @@ func (m *ActivateResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Info == nil {
-				m.Info = &TokenInfo{}
+			if m.ExpiringSoonThreshold == nil {
+				m.ExpiringSoonThreshold = &types.Duration{}
 			}
-			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ExpiringSoonThreshold.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1788,7 +5017,7 @@ func (m *ActivateResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetStateRequest) Unmarshal(dAtA []byte) error {
+func (m *ExportStateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1811,10 +5040,10 @@ func (m *GetStateRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetStateRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportStateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -1839,7 +5068,7 @@ func (m *GetStateRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
+func (m *ExportStateResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1862,36 +5091,17 @@ func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetStateResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportStateResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
-			}
-			m.State = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEnterprise
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.State |= State(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEnterprise
@@ -1901,33 +5111,82 @@ func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthEnterprise
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEnterprise
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Info == nil {
-				m.Info = &TokenInfo{}
+			m.State = append(m.State[:0], dAtA[iNdEx:postIndex]...)
+			if m.State == nil {
+				m.State = []byte{}
 			}
-			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEnterprise(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 3:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEnterprise
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEnterprise
@@ -1937,23 +5196,25 @@ func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthEnterprise
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEnterprise
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			m.State = append(m.State[:0], dAtA[iNdEx:postIndex]...)
+			if m.State == nil {
+				m.State = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1977,7 +5238,7 @@ func (m *GetStateResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetActivationCodeRequest) Unmarshal(dAtA []byte) error {
+func (m *ImportStateResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2000,10 +5261,10 @@ func (m *GetActivationCodeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetActivationCodeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportStateResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetActivationCodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -2028,7 +5289,7 @@ func (m *GetActivationCodeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetActivationCodeResponse) Unmarshal(dAtA []byte) error {
+func (m *Entitlement) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2051,17 +5312,17 @@ func (m *GetActivationCodeResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetActivationCodeResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Entitlement: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetActivationCodeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Entitlement: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
 			}
-			m.State = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEnterprise
@@ -2071,16 +5332,33 @@ func (m *GetActivationCodeResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= State(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = &types.Timestamp{}
+			}
+			if err := m.Expires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Customer", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEnterprise
@@ -2090,31 +5368,27 @@ func (m *GetActivationCodeResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEnterprise
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEnterprise
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Info == nil {
-				m.Info = &TokenInfo{}
-			}
-			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Customer = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2142,8 +5416,27 @@ func (m *GetActivationCodeResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seats", wireType)
+			}
+			m.Seats = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seats |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEnterprise(dAtA[iNdEx:])
@@ -2166,7 +5459,7 @@ func (m *GetActivationCodeResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeactivateRequest) Unmarshal(dAtA []byte) error {
+func (m *DecodeActivationCodeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2189,12 +5482,44 @@ func (m *DeactivateRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeactivateRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DecodeActivationCodeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeactivateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DecodeActivationCodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEnterprise(dAtA[iNdEx:])
@@ -2217,7 +5542,7 @@ func (m *DeactivateRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeactivateResponse) Unmarshal(dAtA []byte) error {
+func (m *DecodeActivationCodeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2240,12 +5565,48 @@ func (m *DeactivateResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeactivateResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DecodeActivationCodeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeactivateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DecodeActivationCodeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entitlement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEnterprise
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEnterprise
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Entitlement == nil {
+				m.Entitlement = &Entitlement{}
+			}
+			if err := m.Entitlement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEnterprise(dAtA[iNdEx:])
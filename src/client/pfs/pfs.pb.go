@@ -1194,12 +1194,15 @@ func (m *Compaction) GetInputPrefixes() []string {
 }
 
 type Shard struct {
-	Compaction           *Compaction `protobuf:"bytes,1,opt,name=compaction,proto3" json:"compaction,omitempty"`
-	Range                *PathRange  `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
-	OutputPath           string      `protobuf:"bytes,3,opt,name=output_path,json=outputPath,proto3" json:"output_path,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Compaction *Compaction `protobuf:"bytes,1,opt,name=compaction,proto3" json:"compaction,omitempty"`
+	Range      *PathRange  `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	OutputPath string      `protobuf:"bytes,3,opt,name=output_path,json=outputPath,proto3" json:"output_path,omitempty"`
+	// size_bytes is the size of the content in the shard's range, which bounds
+	// the compaction bytes that are in flight on a worker.
+	SizeBytes            int64    `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Shard) Reset()         { *m = Shard{} }
@@ -1256,6 +1259,13 @@ func (m *Shard) GetOutputPath() string {
 	return ""
 }
 
+func (m *Shard) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
 type PathRange struct {
 	Lower                string   `protobuf:"bytes,1,opt,name=lower,proto3" json:"lower,omitempty"`
 	Upper                string   `protobuf:"bytes,2,opt,name=upper,proto3" json:"upper,omitempty"`
@@ -5175,233 +5185,232 @@ func init() {
 func init() { proto.RegisterFile("client/pfs/pfs.proto", fileDescriptor_b48f014707f6595c) }
 
 var fileDescriptor_b48f014707f6595c = []byte{
-	// 3601 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0x4b, 0x73, 0x1b, 0xc7,
-	0x99, 0xc4, 0x1b, 0xf3, 0x01, 0x24, 0xc1, 0x26, 0x45, 0x41, 0x90, 0xf5, 0x6a, 0xfa, 0x21, 0xcb,
-	0x65, 0x92, 0x26, 0xd7, 0x7a, 0xd1, 0xb2, 0xcc, 0xa7, 0x44, 0x99, 0x2b, 0x72, 0x07, 0x94, 0xb7,
-	0xd6, 0xb5, 0x5b, 0xa8, 0xc1, 0xa0, 0x41, 0x8c, 0x39, 0xc4, 0x60, 0x67, 0x06, 0x92, 0xe8, 0xc3,
-	0x6e, 0x6e, 0x39, 0xe7, 0x9c, 0x4b, 0xca, 0xe7, 0x1c, 0xf2, 0x0f, 0x52, 0x95, 0x5c, 0x5c, 0x95,
-	0x4b, 0x7e, 0x41, 0x2a, 0xa5, 0xca, 0xff, 0x48, 0xaa, 0x1f, 0x33, 0xd3, 0x3d, 0x33, 0x00, 0x48,
-	0x55, 0x72, 0xb0, 0xd5, 0xd3, 0xdf, 0xa3, 0xbf, 0xfe, 0xbe, 0xaf, 0xbf, 0x17, 0x08, 0x0b, 0xa6,
-	0x6d, 0x91, 0xbe, 0xbf, 0x32, 0xe8, 0x7a, 0xf4, 0xbf, 0xe5, 0x81, 0xeb, 0xf8, 0x0e, 0xca, 0x0d,
-	0xba, 0x5e, 0xe3, 0xfa, 0x89, 0xe3, 0x9c, 0xd8, 0x64, 0x85, 0x6d, 0xb5, 0x87, 0xdd, 0x15, 0x72,
-	0x36, 0xf0, 0xcf, 0x39, 0x46, 0xe3, 0x56, 0x1c, 0xe8, 0x5b, 0x67, 0xc4, 0xf3, 0x8d, 0xb3, 0x81,
-	0x40, 0xb8, 0x19, 0x47, 0x78, 0xe3, 0x1a, 0x83, 0x01, 0x71, 0xc5, 0x11, 0x8d, 0x85, 0x13, 0xe7,
-	0xc4, 0x61, 0xcb, 0x15, 0xba, 0x12, 0xbb, 0x8b, 0x42, 0x1c, 0x63, 0xe8, 0xf7, 0xd8, 0xff, 0xf8,
-	0x3e, 0x6e, 0x40, 0x5e, 0x27, 0x03, 0x07, 0x21, 0xc8, 0xf7, 0x8d, 0x33, 0x52, 0xcf, 0xdc, 0xce,
-	0xdc, 0xd5, 0x74, 0xb6, 0xc6, 0x1b, 0x50, 0xdc, 0x72, 0x8d, 0xbe, 0xd9, 0x43, 0x37, 0x20, 0xef,
-	0x92, 0x81, 0xc3, 0xa0, 0x95, 0x35, 0x6d, 0x99, 0x5e, 0x88, 0x92, 0xe9, 0x6c, 0x3b, 0x24, 0xce,
-	0x4a, 0xc4, 0x4f, 0x21, 0xbf, 0x67, 0xd9, 0x04, 0x2d, 0x41, 0xd1, 0x74, 0xce, 0xce, 0x2c, 0x5f,
-	0x10, 0x57, 0x18, 0xf1, 0x36, 0xdb, 0xd2, 0x05, 0x88, 0x32, 0x18, 0x18, 0x7e, 0x2f, 0x60, 0x40,
-	0xd7, 0xf8, 0xef, 0x19, 0x28, 0xd3, 0x33, 0xf6, 0xfb, 0x5d, 0x67, 0x92, 0x00, 0xff, 0x06, 0x25,
-	0xd3, 0x25, 0x86, 0x4f, 0x3a, 0x8c, 0x45, 0x65, 0xad, 0xb1, 0xcc, 0xb5, 0xb4, 0x1c, 0x68, 0x69,
-	0xf9, 0x38, 0x50, 0xa3, 0x1e, 0xa0, 0xa2, 0x1b, 0x00, 0x9e, 0xf5, 0x23, 0x69, 0xb5, 0xcf, 0x7d,
-	0xe2, 0xd5, 0x73, 0xb7, 0x33, 0x77, 0xf3, 0xba, 0x46, 0x77, 0xb6, 0xe8, 0x06, 0xba, 0x0d, 0x95,
-	0x0e, 0xf1, 0x4c, 0xd7, 0x1a, 0xf8, 0x96, 0xd3, 0xaf, 0x17, 0x98, 0x6c, 0xf2, 0x16, 0xfa, 0x04,
-	0xca, 0x6d, 0xa6, 0x20, 0xe2, 0xd5, 0x4b, 0xb7, 0x73, 0xe1, 0xed, 0xb8, 0xd6, 0xf4, 0x10, 0x88,
-	0x96, 0x41, 0xa3, 0x3a, 0x6f, 0x59, 0xfd, 0xae, 0x53, 0x2f, 0x32, 0x09, 0xe7, 0xc2, 0x3b, 0x6c,
-	0x0e, 0xfd, 0x1e, 0xbd, 0xa4, 0x5e, 0x36, 0xc4, 0xea, 0x45, 0xbe, 0x9c, 0xaf, 0x15, 0xf0, 0xd7,
-	0x50, 0x95, 0xe1, 0x68, 0x19, 0xaa, 0x86, 0x69, 0x12, 0xcf, 0x6b, 0xd9, 0xe4, 0x35, 0xb1, 0x99,
-	0x32, 0x66, 0xd6, 0x2a, 0xcb, 0xcc, 0x9c, 0x4d, 0xd3, 0x19, 0x10, 0xbd, 0xc2, 0x11, 0x0e, 0x28,
-	0x1c, 0xff, 0x94, 0x05, 0xe0, 0xa2, 0x30, 0xf2, 0x25, 0x28, 0x72, 0x81, 0xea, 0x79, 0xc9, 0x12,
-	0x42, 0x56, 0x01, 0x42, 0xb7, 0x20, 0xdf, 0x23, 0x46, 0xa0, 0x46, 0xc5, 0x58, 0x0c, 0x80, 0x3e,
-	0x03, 0x18, 0xb8, 0xce, 0x6b, 0xd2, 0x37, 0xfa, 0x26, 0xa9, 0xe7, 0x92, 0xb7, 0x96, 0xc0, 0x14,
-	0xd9, 0x1b, 0xb6, 0x03, 0xe4, 0x42, 0x0a, 0x72, 0x04, 0x46, 0x0f, 0x61, 0xae, 0x63, 0xb9, 0xc4,
-	0xf4, 0x5b, 0xd2, 0x01, 0xc5, 0x24, 0x4d, 0x8d, 0x63, 0x1d, 0x45, 0xc7, 0x7c, 0x0c, 0x25, 0xdf,
-	0xb5, 0x4e, 0x4e, 0x88, 0x5b, 0x2f, 0x31, 0xb9, 0xab, 0x0c, 0xff, 0x98, 0xef, 0xe9, 0x01, 0x30,
-	0xd5, 0xc9, 0x9f, 0x42, 0x25, 0xd2, 0x91, 0x87, 0x56, 0xa1, 0xc2, 0x35, 0xc1, 0x6d, 0x95, 0x61,
-	0xc7, 0xcf, 0x4a, 0xc7, 0x33, 0x4b, 0x41, 0x3b, 0x5c, 0xe3, 0xff, 0x83, 0x92, 0x38, 0x08, 0x2d,
-	0x86, 0x1a, 0xe6, 0x27, 0x04, 0x4a, 0xad, 0x41, 0xce, 0xb0, 0x6d, 0xa6, 0xd3, 0xb2, 0x4e, 0x97,
-	0xe8, 0x3a, 0x68, 0xa6, 0xeb, 0xf4, 0x5b, 0xde, 0x80, 0x98, 0xcc, 0xf3, 0x34, 0xbd, 0x4c, 0x37,
-	0x9a, 0x03, 0x62, 0x52, 0x31, 0xa9, 0x17, 0x32, 0x33, 0x69, 0x3a, 0x5b, 0xa3, 0x3a, 0x94, 0xf8,
-	0x5b, 0xf1, 0x98, 0x23, 0xe6, 0xf4, 0xe0, 0x13, 0xaf, 0x43, 0x95, 0x1b, 0xe8, 0xd0, 0xb5, 0x4e,
-	0xac, 0x3e, 0x5a, 0x82, 0xfc, 0xa9, 0xd5, 0xef, 0x08, 0xef, 0xe0, 0xa2, 0x73, 0xd0, 0xb7, 0x56,
-	0xbf, 0xa3, 0x33, 0x20, 0x7e, 0x0a, 0x45, 0x4e, 0x34, 0xe9, 0x65, 0x2d, 0x42, 0xd6, 0xe2, 0xde,
-	0xa0, 0x6d, 0x15, 0xdf, 0xfd, 0xe5, 0x56, 0x76, 0x7f, 0x47, 0xcf, 0x5a, 0x1d, 0xdc, 0x84, 0x8a,
-	0x70, 0x0b, 0xa3, 0x7f, 0x42, 0xd0, 0x1d, 0x28, 0xd8, 0xce, 0x1b, 0xe2, 0xa6, 0x3d, 0x72, 0x0e,
-	0xa1, 0x28, 0x43, 0x1a, 0xa7, 0xd2, 0x5c, 0x8b, 0x43, 0xf0, 0x7f, 0x43, 0x8d, 0x6f, 0x48, 0xb6,
-	0xbd, 0x50, 0xfc, 0x88, 0x5c, 0x3b, 0x3b, 0xd2, 0xb5, 0xf1, 0xdf, 0x0a, 0x00, 0x9c, 0x2e, 0x78,
-	0x0e, 0x97, 0x61, 0x3c, 0x3b, 0xfa, 0xcd, 0x7c, 0x0a, 0x45, 0x87, 0x29, 0xb8, 0x3e, 0x27, 0x3d,
-	0x6d, 0xd9, 0x28, 0xba, 0x40, 0x88, 0xc7, 0x94, 0x72, 0x32, 0xa6, 0xac, 0xc2, 0xf4, 0xc0, 0x70,
-	0x49, 0xdf, 0x6f, 0x09, 0xe9, 0x52, 0xd4, 0x55, 0xe5, 0x18, 0xc2, 0x82, 0xab, 0x30, 0x6d, 0xf6,
-	0x2c, 0xbb, 0xd3, 0x0a, 0x1c, 0xa4, 0x22, 0xbd, 0x99, 0x80, 0x82, 0x61, 0xf0, 0x0f, 0x8f, 0x86,
-	0x4b, 0xcf, 0x37, 0x5c, 0x1a, 0x2e, 0x73, 0x93, 0xc3, 0xa5, 0x40, 0x45, 0xf7, 0xa1, 0xdc, 0xb5,
-	0xfa, 0x96, 0xd7, 0x23, 0x1d, 0x11, 0x41, 0xc6, 0x91, 0x85, 0xb8, 0xb1, 0x30, 0x5b, 0x88, 0x87,
-	0xd9, 0x2f, 0x95, 0x80, 0x52, 0x63, 0xb2, 0x5f, 0x91, 0x64, 0x8f, 0x7c, 0x41, 0x09, 0x2d, 0x9f,
-	0x42, 0xcd, 0x25, 0x46, 0xe7, 0x5c, 0x0e, 0x16, 0x55, 0xf6, 0x32, 0x66, 0xd9, 0xbe, 0xe4, 0x42,
-	0xab, 0x4a, 0x14, 0xd2, 0xd8, 0x09, 0x35, 0x59, 0x3b, 0xd4, 0x85, 0x95, 0x50, 0xf4, 0x18, 0xae,
-	0x05, 0x5f, 0x81, 0x1d, 0xbc, 0x96, 0x37, 0x64, 0xb1, 0xb5, 0x8e, 0xd8, 0x29, 0x57, 0x43, 0x04,
-	0xa1, 0xd5, 0x26, 0x07, 0xa7, 0xd3, 0x76, 0x0d, 0xcb, 0x1e, 0xba, 0xa4, 0x3e, 0x9f, 0x4e, 0xbb,
-	0xc7, 0xc1, 0xe8, 0x3e, 0x5c, 0x4d, 0xd2, 0xfa, 0x8e, 0x6f, 0xd8, 0xf5, 0x05, 0x46, 0x79, 0x25,
-	0x4e, 0x79, 0x4c, 0x81, 0x2f, 0xf2, 0xe5, 0x62, 0xad, 0xf4, 0x22, 0x5f, 0x86, 0x5a, 0x05, 0xff,
-	0x21, 0x03, 0x65, 0x9a, 0x79, 0x83, 0xbc, 0xd9, 0xb5, 0x6c, 0xa2, 0xbc, 0x6e, 0x0a, 0xd4, 0xd9,
-	0x36, 0xba, 0x07, 0x1a, 0xfd, 0xb7, 0xe5, 0x9f, 0x0f, 0x78, 0xf6, 0x9e, 0x59, 0x9b, 0x0e, 0x71,
-	0x8e, 0xcf, 0x07, 0x84, 0x9a, 0x91, 0xaf, 0x26, 0x65, 0xcb, 0x87, 0xa0, 0x71, 0x81, 0xa9, 0x57,
-	0xc1, 0x44, 0xf7, 0x88, 0x90, 0x69, 0xb8, 0xeb, 0x19, 0x5e, 0x8f, 0x85, 0xee, 0xaa, 0xce, 0xd6,
-	0x78, 0x9d, 0x3d, 0xd5, 0x81, 0x61, 0xb2, 0x37, 0xf1, 0x11, 0xcc, 0x58, 0xfd, 0xc1, 0x90, 0x26,
-	0x06, 0xd2, 0xb5, 0xde, 0x12, 0xaf, 0x9e, 0xbd, 0x9d, 0xbb, 0xab, 0xe9, 0xd3, 0x6c, 0xf7, 0x48,
-	0x6c, 0xe2, 0xff, 0x87, 0x42, 0xb3, 0x67, 0xb8, 0x1d, 0xb4, 0x02, 0x60, 0x86, 0xd4, 0xe2, 0xee,
-	0xb3, 0x81, 0xc1, 0xc5, 0xb6, 0x2e, 0xa1, 0xa0, 0x0f, 0xa1, 0xe0, 0x52, 0x27, 0x10, 0x8f, 0x6d,
-	0x86, 0xe1, 0x1e, 0x19, 0x7e, 0x8f, 0xbb, 0x06, 0x07, 0xa2, 0x5b, 0x50, 0x71, 0x86, 0x3e, 0x93,
-	0x83, 0x16, 0x2b, 0x3c, 0x6c, 0x03, 0xdf, 0xa2, 0xc8, 0xf8, 0x01, 0x68, 0x21, 0x11, 0x5a, 0x90,
-	0x43, 0xa2, 0x16, 0x44, 0xc1, 0x05, 0x39, 0x0a, 0x6a, 0x41, 0xe0, 0x73, 0x61, 0x6e, 0x9b, 0x15,
-	0x25, 0x2c, 0xf2, 0x92, 0xff, 0x1d, 0x12, 0x6f, 0x62, 0x64, 0x8e, 0x85, 0x92, 0x5c, 0x32, 0x94,
-	0x2c, 0x42, 0x71, 0x38, 0xe8, 0x18, 0x3e, 0xcf, 0x24, 0x65, 0x5d, 0x7c, 0xbd, 0xc8, 0x97, 0xb3,
-	0xb5, 0x1c, 0x5e, 0x07, 0xb4, 0xdf, 0xa7, 0xf9, 0xc7, 0xbf, 0xf8, 0xa1, 0xf8, 0x2a, 0xcc, 0x1e,
-	0x58, 0x9e, 0x4c, 0xf1, 0x22, 0x5f, 0xce, 0xd4, 0xb2, 0xf8, 0x6b, 0xa8, 0x45, 0x00, 0x6f, 0xe0,
-	0xf4, 0x3d, 0xe6, 0x5d, 0x94, 0x48, 0xce, 0xa4, 0xd3, 0x21, 0x43, 0x5e, 0xf1, 0xb8, 0x62, 0x85,
-	0xbf, 0x87, 0xb9, 0x1d, 0x62, 0x93, 0x4b, 0x69, 0x60, 0x01, 0x0a, 0x5d, 0xc7, 0x35, 0x89, 0x48,
-	0xac, 0xfc, 0x23, 0x48, 0xb6, 0xb9, 0x30, 0xd9, 0xe2, 0xdf, 0x65, 0x00, 0x35, 0x69, 0x10, 0x13,
-	0xcf, 0x5d, 0x70, 0x5f, 0x82, 0x22, 0x8f, 0xa3, 0xa9, 0x09, 0x80, 0x83, 0xe2, 0x5a, 0xce, 0xa7,
-	0x6a, 0x59, 0xa4, 0x88, 0x9c, 0x92, 0xf4, 0xd5, 0xb8, 0x56, 0xb8, 0x60, 0x5c, 0x13, 0xc6, 0xf9,
-	0x55, 0x06, 0xe6, 0xf7, 0x58, 0x00, 0x4d, 0xc8, 0x3c, 0x39, 0x69, 0xc5, 0x64, 0xce, 0x26, 0x65,
-	0x56, 0xdf, 0x72, 0x31, 0xfe, 0x96, 0x17, 0xa0, 0xc0, 0x5a, 0x12, 0xe1, 0x37, 0xfc, 0x03, 0xf7,
-	0x61, 0x41, 0x38, 0xcc, 0x7b, 0xc8, 0xf4, 0x05, 0x54, 0xda, 0xb6, 0x63, 0x9e, 0xb6, 0x3c, 0x9f,
-	0x3a, 0x24, 0x8f, 0x35, 0x72, 0x10, 0x6e, 0xd2, 0x7d, 0x1d, 0x18, 0x12, 0x5b, 0xe3, 0x9f, 0x32,
-	0x30, 0x47, 0x7d, 0x4a, 0x3d, 0x6d, 0x82, 0x4f, 0xdc, 0x82, 0x7c, 0xd7, 0x75, 0xce, 0x52, 0xeb,
-	0x57, 0x0a, 0x40, 0xd7, 0x21, 0xeb, 0x3b, 0x22, 0xed, 0x29, 0xe0, 0xac, 0x4f, 0xab, 0x9d, 0x62,
-	0x7f, 0x78, 0xd6, 0x26, 0x2e, 0xbb, 0x79, 0x5e, 0x17, 0x5f, 0xb4, 0xfa, 0x72, 0xc9, 0x6b, 0xe2,
-	0x7a, 0x84, 0xe5, 0xaf, 0xb2, 0x1e, 0x7c, 0xd2, 0xf2, 0x31, 0xaa, 0x29, 0x58, 0xf9, 0xc8, 0x2f,
-	0x9c, 0x2c, 0x1f, 0x23, 0x34, 0x16, 0x7a, 0xc4, 0x1a, 0x3f, 0x86, 0x79, 0xee, 0xf8, 0x97, 0x57,
-	0x2a, 0x36, 0x00, 0xed, 0xd9, 0xc3, 0xb8, 0x8f, 0x7c, 0x14, 0x95, 0x8a, 0x99, 0x64, 0x25, 0x10,
-	0xc0, 0xd0, 0x87, 0x50, 0xf6, 0x9d, 0x16, 0x55, 0x1a, 0x0f, 0xa7, 0x8a, 0x32, 0x4b, 0xbe, 0x43,
-	0xff, 0xf5, 0xf0, 0x1f, 0x33, 0xb0, 0xd8, 0x1c, 0xb6, 0xa9, 0xeb, 0xb4, 0xc9, 0xa5, 0x2c, 0xb1,
-	0xa8, 0xd4, 0x64, 0x9a, 0x54, 0x2d, 0xe5, 0xa9, 0xbb, 0x33, 0x45, 0x8e, 0x7c, 0x11, 0x0c, 0x25,
-	0x34, 0x66, 0x6e, 0x94, 0x31, 0x3f, 0x86, 0x02, 0xf7, 0xa7, 0xfc, 0x08, 0x7f, 0xe2, 0x60, 0xfc,
-	0x08, 0xd0, 0xb6, 0x4d, 0x0c, 0xf7, 0x3d, 0x74, 0xfc, 0xa7, 0x0c, 0xcc, 0xf3, 0xd8, 0x2c, 0xaa,
-	0x3e, 0x41, 0x1c, 0x34, 0x4a, 0x99, 0x51, 0x8d, 0xd2, 0x35, 0x28, 0x7b, 0x2d, 0x45, 0x03, 0x25,
-	0x4f, 0xb4, 0xd3, 0x4b, 0x4a, 0xc8, 0x18, 0x51, 0x55, 0xaa, 0x8d, 0x56, 0x7e, 0x7c, 0xa3, 0x25,
-	0x75, 0x40, 0x85, 0x31, 0x1d, 0x10, 0xde, 0x08, 0xdf, 0xb0, 0x7a, 0x9b, 0x25, 0xa5, 0x73, 0x19,
-	0x51, 0x40, 0x1f, 0xf0, 0xf7, 0xa8, 0x52, 0x4e, 0xf0, 0x02, 0xe9, 0xe5, 0x64, 0xd5, 0x97, 0x73,
-	0x14, 0x38, 0xfe, 0xe5, 0x25, 0x49, 0x8f, 0xfc, 0xf8, 0x17, 0x59, 0x80, 0xcd, 0xc1, 0x80, 0xf4,
-	0x3b, 0x6c, 0xf2, 0xf0, 0x01, 0x68, 0xce, 0x6b, 0xe2, 0xbe, 0x71, 0x2d, 0x9f, 0x17, 0x40, 0x65,
-	0x3d, 0xda, 0xa0, 0x69, 0xc2, 0x37, 0x4e, 0x84, 0x65, 0xe8, 0x12, 0x7d, 0x05, 0xb3, 0xae, 0xf1,
-	0xa6, 0xc5, 0x0a, 0x22, 0xcf, 0x19, 0xba, 0xac, 0xbd, 0xa5, 0x22, 0x20, 0x7e, 0x29, 0xe3, 0x0d,
-	0x65, 0xdb, 0x64, 0x90, 0xe7, 0x53, 0xfa, 0xb4, 0x2b, 0x6f, 0x50, 0x6a, 0xdf, 0x70, 0x15, 0xea,
-	0xbc, 0x44, 0x7d, 0x6c, 0xb8, 0x2a, 0xb5, 0x2f, 0x6f, 0x50, 0xea, 0xa1, 0x6b, 0x2b, 0xd4, 0x05,
-	0x89, 0xfa, 0x95, 0x7e, 0xa0, 0x52, 0x0f, 0x5d, 0x3b, 0xda, 0xd8, 0x2a, 0x43, 0x91, 0x13, 0xe1,
-	0x7d, 0x98, 0x56, 0xe4, 0x0c, 0x27, 0x2b, 0x99, 0x68, 0xb2, 0x42, 0xf7, 0x3a, 0x86, 0x6f, 0xb0,
-	0xbb, 0x57, 0x75, 0xb6, 0xa6, 0xea, 0xd8, 0x3d, 0xdc, 0x0b, 0xb2, 0xe6, 0xee, 0xe1, 0x1e, 0x5e,
-	0x82, 0x69, 0x45, 0xe8, 0x90, 0x2c, 0x13, 0x91, 0xe1, 0x26, 0x4c, 0x2b, 0xb2, 0xa5, 0x9e, 0x57,
-	0x83, 0xdc, 0x2b, 0xfd, 0x20, 0x50, 0xf5, 0x2b, 0xfd, 0x80, 0x9a, 0xc6, 0x25, 0xe6, 0xd0, 0xf5,
-	0xac, 0xd7, 0x44, 0x9c, 0x19, 0x6d, 0xe0, 0x35, 0x00, 0xee, 0x19, 0xcc, 0x8c, 0x48, 0x2a, 0x61,
-	0x35, 0x51, 0xb7, 0x26, 0x8c, 0x47, 0x73, 0xfc, 0xdc, 0xbf, 0x3b, 0x1d, 0xab, 0x7b, 0xce, 0xca,
-	0xdb, 0xcb, 0xa4, 0xa6, 0x35, 0xa8, 0x18, 0xcc, 0x6b, 0x98, 0xfa, 0x45, 0xe6, 0xe0, 0x31, 0x3b,
-	0xf2, 0xa6, 0xe7, 0x53, 0x3a, 0x18, 0x91, 0x6f, 0xad, 0xd1, 0x14, 0x4b, 0x45, 0xe4, 0x34, 0x39,
-	0x89, 0x26, 0x12, 0x9d, 0xd2, 0x74, 0xc2, 0xaf, 0xad, 0x19, 0xa8, 0x9e, 0x51, 0x09, 0x2d, 0xd3,
-	0xa0, 0x49, 0x18, 0x5b, 0x30, 0xbb, 0xed, 0x0c, 0x14, 0x79, 0xaf, 0x43, 0xce, 0x73, 0xcd, 0x64,
-	0xb5, 0x4e, 0x77, 0x29, 0xb0, 0xe3, 0x05, 0xfd, 0xa0, 0x0c, 0xec, 0x78, 0xbe, 0xea, 0xec, 0xb9,
-	0x98, 0xb3, 0xe3, 0x15, 0x98, 0x79, 0x46, 0x7c, 0xf9, 0xa4, 0xf1, 0x8d, 0x81, 0x54, 0x1c, 0x5e,
-	0x82, 0x68, 0x87, 0x17, 0x87, 0x17, 0xa7, 0x60, 0xb6, 0x1d, 0x86, 0x93, 0x11, 0xb6, 0xc6, 0xab,
-	0x30, 0xfb, 0x9f, 0x86, 0x7d, 0x7a, 0x89, 0x73, 0x8f, 0x60, 0xf6, 0x99, 0xed, 0xb4, 0x2f, 0x6d,
-	0xf8, 0x3a, 0x94, 0x06, 0x86, 0xef, 0x13, 0x37, 0xa8, 0x91, 0x82, 0x4f, 0xfc, 0x06, 0x66, 0x77,
-	0xac, 0x6e, 0x57, 0xe6, 0xf8, 0x21, 0x94, 0xfb, 0x84, 0x47, 0x87, 0xa4, 0x1c, 0xa5, 0x3e, 0x61,
-	0x8f, 0x8e, 0x62, 0x39, 0xb6, 0xe2, 0x48, 0x32, 0x96, 0x63, 0x73, 0xef, 0xa9, 0x43, 0xc9, 0xeb,
-	0x19, 0xb6, 0xed, 0xbc, 0x11, 0xa6, 0x0a, 0x3e, 0x71, 0x17, 0x6a, 0xd1, 0xc1, 0xa2, 0x8c, 0xbe,
-	0x9b, 0x38, 0x39, 0xea, 0xd1, 0x58, 0x39, 0x11, 0x9e, 0x7e, 0x37, 0x71, 0x7a, 0x1c, 0x53, 0x48,
-	0x80, 0x6f, 0x41, 0x65, 0xcf, 0x33, 0x4f, 0x83, 0xcb, 0xd5, 0x20, 0xd7, 0xb5, 0xde, 0x8a, 0x20,
-	0x49, 0x97, 0xf8, 0x3e, 0x54, 0x39, 0x82, 0x10, 0x42, 0xc2, 0xd0, 0x18, 0x06, 0x2b, 0x12, 0x5d,
-	0xd7, 0x09, 0x3b, 0x19, 0xf6, 0x81, 0xef, 0xc3, 0x15, 0x9e, 0x2d, 0xe9, 0x31, 0x1e, 0xf1, 0x43,
-	0x06, 0x37, 0x00, 0xba, 0x7c, 0xab, 0x65, 0x75, 0x04, 0x1f, 0x4d, 0xec, 0xec, 0x77, 0xf0, 0x2b,
-	0x98, 0xd7, 0x89, 0xb8, 0x07, 0x23, 0x0b, 0x2c, 0x3f, 0x8e, 0x8a, 0x76, 0x64, 0xbe, 0x6f, 0xb7,
-	0x3c, 0x62, 0x3a, 0xfd, 0x8e, 0xc7, 0x24, 0xc9, 0xe9, 0xe0, 0xfb, 0x76, 0x93, 0xef, 0xe0, 0xeb,
-	0x50, 0xd8, 0xa2, 0x15, 0x65, 0xd8, 0x64, 0x8a, 0x28, 0xc2, 0x9a, 0xcc, 0x0f, 0xa0, 0x78, 0xd8,
-	0xfe, 0x81, 0x98, 0x7e, 0x2a, 0xf4, 0x1a, 0xe4, 0x8e, 0x8d, 0x93, 0xd4, 0x99, 0xe1, 0x03, 0xd0,
-	0x68, 0xa1, 0x9c, 0xd2, 0xe7, 0xe5, 0x53, 0xfb, 0xbc, 0x7c, 0xd0, 0xe7, 0xe9, 0x50, 0x66, 0xe2,
-	0xe8, 0xa4, 0x8b, 0x6e, 0x43, 0x81, 0x15, 0xbb, 0xc2, 0xa6, 0xc0, 0xf3, 0x1c, 0x83, 0x72, 0x40,
-	0x7a, 0x57, 0x1a, 0x1e, 0x2c, 0xba, 0x52, 0xfc, 0x3f, 0x00, 0xfc, 0x16, 0xc1, 0x54, 0xcb, 0x61,
-	0x5f, 0x8a, 0xe3, 0x73, 0x04, 0x5d, 0x80, 0x68, 0x63, 0xc6, 0x8b, 0x71, 0x97, 0x74, 0x15, 0x47,
-	0x09, 0x84, 0xd3, 0xcb, 0x6d, 0xb1, 0xc2, 0xbf, 0xcf, 0x01, 0xda, 0x1a, 0x86, 0xc3, 0xa3, 0x4b,
-	0x35, 0x4f, 0x8b, 0xca, 0xc4, 0x59, 0x4b, 0x19, 0x98, 0x55, 0x27, 0x0d, 0xcc, 0xd4, 0x2e, 0xaa,
-	0x78, 0xd1, 0xe9, 0xd0, 0x2d, 0xc8, 0xfb, 0x2e, 0x21, 0x4a, 0x7d, 0x25, 0x94, 0xc0, 0x00, 0xe8,
-	0x0e, 0x14, 0xe8, 0xbf, 0xea, 0xdc, 0x5e, 0x60, 0x70, 0x08, 0xbd, 0x62, 0xc7, 0xf0, 0x87, 0x67,
-	0x1e, 0x1b, 0xd3, 0xc5, 0x55, 0xc9, 0x41, 0x68, 0x06, 0xb2, 0xfb, 0x3b, 0xe2, 0xb7, 0x81, 0xec,
-	0xfe, 0x4e, 0xac, 0xb3, 0xd2, 0xe2, 0x9d, 0x95, 0x34, 0x79, 0x83, 0xf7, 0x9b, 0xbc, 0x55, 0x2e,
-	0x3e, 0x79, 0x13, 0xbd, 0x64, 0x0f, 0x6a, 0x47, 0x43, 0x5f, 0xc8, 0x2d, 0xcc, 0xb7, 0x00, 0x85,
-	0xd7, 0x86, 0x3d, 0x24, 0x22, 0x99, 0xf3, 0x0f, 0xf4, 0x01, 0xe4, 0x7d, 0xe3, 0x24, 0x68, 0x07,
-	0xca, 0xa2, 0x70, 0x39, 0xd1, 0xd9, 0x6e, 0xe4, 0xb0, 0xb9, 0x11, 0x0e, 0x8b, 0xbb, 0x41, 0xa9,
-	0xac, 0x1e, 0xf6, 0x4f, 0xf7, 0xc9, 0x5f, 0x67, 0x60, 0xee, 0x19, 0x11, 0x57, 0xf2, 0xa4, 0xbe,
-	0x87, 0xf3, 0x52, 0xfb, 0x1e, 0x71, 0x4e, 0x00, 0x43, 0x77, 0xa0, 0xea, 0x74, 0xbb, 0x34, 0xa2,
-	0x70, 0x1b, 0xf1, 0x07, 0x5a, 0xe1, 0x7b, 0xdc, 0x4a, 0x13, 0x46, 0x5d, 0x37, 0x00, 0xd8, 0x4c,
-	0xae, 0x15, 0x4e, 0xe9, 0xf3, 0xba, 0xc6, 0x76, 0x9a, 0xd6, 0x8f, 0xb4, 0x06, 0x9b, 0x3d, 0x1a,
-	0xfa, 0x42, 0x6c, 0x2e, 0xda, 0xe4, 0xb7, 0x1e, 0x1a, 0x24, 0x2b, 0x19, 0x04, 0xaf, 0xc3, 0xec,
-	0x33, 0x72, 0x49, 0x56, 0xf8, 0x37, 0x19, 0xa8, 0x05, 0x54, 0xa1, 0x72, 0x3e, 0x13, 0xea, 0xd5,
-	0x49, 0xd7, 0x53, 0x66, 0x31, 0xa1, 0x7a, 0x23, 0xf8, 0xbf, 0x5e, 0x45, 0x88, 0x4f, 0x8b, 0xe4,
-	0x8b, 0xe1, 0x57, 0x50, 0x3b, 0x36, 0x4e, 0xde, 0xc3, 0x73, 0xc6, 0x7a, 0x2d, 0x5e, 0x00, 0x44,
-	0x8f, 0x52, 0x7d, 0x85, 0x96, 0x0c, 0x74, 0xf7, 0xd8, 0x38, 0x09, 0x35, 0xb4, 0x08, 0x45, 0x3e,
-	0x5e, 0x0c, 0x7e, 0xbc, 0xe1, 0x5f, 0x7c, 0xf8, 0x68, 0xda, 0xc3, 0x0e, 0x69, 0x09, 0x59, 0x78,
-	0xb5, 0x32, 0x2d, 0x76, 0x39, 0x67, 0xdc, 0xe4, 0x57, 0xe2, 0x1c, 0x45, 0xce, 0x6b, 0xf0, 0x32,
-	0x95, 0xcb, 0x1e, 0x09, 0xc6, 0xba, 0x8d, 0xe8, 0x6a, 0xd9, 0x91, 0x57, 0xc3, 0x4f, 0x60, 0x81,
-	0x97, 0x93, 0xef, 0xe5, 0xea, 0xf8, 0x2a, 0x5c, 0x89, 0x91, 0x73, 0xc1, 0xf0, 0x17, 0xc1, 0xb4,
-	0x4d, 0x56, 0x40, 0xa0, 0xc7, 0xcc, 0x28, 0x3d, 0xca, 0x24, 0x82, 0x11, 0x6d, 0xac, 0x7b, 0xc4,
-	0x3c, 0xbd, 0xbc, 0xd9, 0xf0, 0xe7, 0x30, 0xaf, 0x90, 0x0a, 0x9d, 0x2d, 0x42, 0x91, 0xbc, 0xb5,
-	0x3c, 0x76, 0x33, 0x36, 0xb4, 0xe4, 0x5f, 0x78, 0x15, 0x4a, 0xe2, 0x16, 0x17, 0xbd, 0xfd, 0x13,
-	0x98, 0xe7, 0x71, 0x6f, 0x87, 0xfd, 0x5e, 0x28, 0xd5, 0x3a, 0x4e, 0xfb, 0x87, 0xa0, 0x92, 0x71,
-	0xda, 0x3f, 0x8c, 0x78, 0x7b, 0x9f, 0xc0, 0x3c, 0x8f, 0x31, 0x13, 0xc8, 0xf1, 0x73, 0x58, 0x0c,
-	0xb5, 0xac, 0xe2, 0x2e, 0x2a, 0x7a, 0xd0, 0x42, 0x8f, 0x8d, 0x5c, 0x2d, 0x2b, 0xbb, 0x1a, 0xfe,
-	0x65, 0x16, 0x2a, 0x41, 0x2e, 0xef, 0x90, 0xb7, 0xe8, 0x41, 0xfc, 0xa2, 0x37, 0xa4, 0x8b, 0x32,
-	0x14, 0xb1, 0xf6, 0x76, 0xfb, 0xbe, 0x7b, 0x1e, 0xc5, 0xb8, 0x65, 0xe5, 0x49, 0x34, 0x12, 0x54,
-	0xd4, 0x86, 0x9c, 0x84, 0xe1, 0x35, 0xf6, 0xa1, 0x2a, 0x33, 0xa2, 0x97, 0x3c, 0x25, 0xe7, 0xc1,
-	0x25, 0x4f, 0xc9, 0x39, 0x5a, 0x92, 0x75, 0x94, 0x88, 0x1d, 0x1c, 0xf6, 0x38, 0xfb, 0x30, 0xd3,
-	0xd8, 0x01, 0x2d, 0xe4, 0x9e, 0xc2, 0xe7, 0x8e, 0xca, 0x47, 0xcd, 0xbb, 0x21, 0x17, 0xfc, 0x31,
-	0xcc, 0x1c, 0x06, 0xdd, 0x0b, 0xd7, 0xc5, 0x02, 0x14, 0x2c, 0xba, 0x60, 0xcc, 0x72, 0x3a, 0xff,
-	0xb8, 0x77, 0x0f, 0x20, 0xfa, 0x6d, 0x13, 0x95, 0x21, 0xff, 0xaa, 0xb9, 0xab, 0xd7, 0xa6, 0xe8,
-	0x6a, 0xf3, 0xd5, 0xf1, 0x61, 0x2d, 0x43, 0x57, 0x7b, 0xcd, 0xed, 0x6f, 0x6b, 0xd9, 0x7b, 0x9f,
-	0xf1, 0xdf, 0x45, 0xd8, 0x8f, 0x19, 0x55, 0x28, 0xeb, 0xbb, 0xcd, 0x5d, 0xfd, 0xbb, 0xdd, 0x1d,
-	0x8e, 0xbd, 0xb7, 0x7f, 0xb0, 0x5b, 0xcb, 0xa0, 0x12, 0xe4, 0x76, 0xf6, 0xf5, 0x5a, 0xf6, 0xde,
-	0x7a, 0x30, 0xd7, 0x63, 0x73, 0x24, 0x54, 0x81, 0x52, 0xf3, 0x78, 0x53, 0x3f, 0x66, 0xe8, 0x1a,
-	0x14, 0xf4, 0xdd, 0xcd, 0x9d, 0xff, 0xaa, 0x65, 0x28, 0x9f, 0xbd, 0xfd, 0x97, 0xfb, 0xcd, 0xe7,
-	0xbb, 0x3b, 0xb5, 0xec, 0xbd, 0x0d, 0xd0, 0x76, 0x88, 0x6d, 0x9d, 0x59, 0x3e, 0x71, 0x29, 0xd3,
-	0x97, 0x87, 0x2f, 0x77, 0x39, 0xfb, 0x17, 0xcd, 0xc3, 0x97, 0x5c, 0x98, 0x83, 0xfd, 0x97, 0xbb,
-	0xb5, 0x2c, 0x3d, 0xa8, 0xf9, 0x1f, 0x07, 0xb5, 0x1c, 0x5d, 0x6c, 0x37, 0xbf, 0xab, 0xe5, 0xd7,
-	0x7e, 0x9e, 0x86, 0xdc, 0xe6, 0xd1, 0x3e, 0xfa, 0x1a, 0x20, 0xfa, 0x2d, 0x00, 0x2d, 0xf2, 0x5a,
-	0x27, 0xfe, 0xe3, 0x40, 0x63, 0x31, 0x51, 0x00, 0xec, 0xb2, 0x21, 0xed, 0x14, 0x7a, 0x00, 0x15,
-	0x69, 0xae, 0x8f, 0xae, 0x32, 0x06, 0xc9, 0x49, 0x7f, 0x43, 0x1d, 0xc5, 0xe3, 0x29, 0xf4, 0x08,
-	0xca, 0xc1, 0x08, 0x1f, 0x2d, 0x30, 0x60, 0x6c, 0xd4, 0xdf, 0xb8, 0x12, 0xdb, 0x15, 0x41, 0x60,
-	0x8a, 0xca, 0x1c, 0x4d, 0xef, 0x85, 0xcc, 0x89, 0x71, 0xfe, 0x18, 0x99, 0xbf, 0x84, 0x8a, 0x34,
-	0xa0, 0x17, 0x32, 0x27, 0x47, 0xf6, 0x0d, 0xb9, 0xca, 0xc4, 0x53, 0x68, 0x0b, 0xaa, 0xf2, 0x90,
-	0x1c, 0xd5, 0x45, 0xb7, 0x93, 0x98, 0x9b, 0x8f, 0x39, 0xfa, 0x09, 0x4c, 0x2b, 0x53, 0x6d, 0x74,
-	0x4d, 0x56, 0x98, 0xca, 0x25, 0x3e, 0xc8, 0x65, 0x4a, 0x83, 0x68, 0x46, 0x2d, 0x6e, 0x9e, 0x18,
-	0x5a, 0xa7, 0x10, 0xae, 0x66, 0xa8, 0xf4, 0xf2, 0xe4, 0x57, 0x48, 0x9f, 0x32, 0x0c, 0x1e, 0x23,
-	0xfd, 0x06, 0x54, 0xa4, 0x09, 0xb0, 0x50, 0x5c, 0x72, 0x26, 0x9c, 0x2e, 0xc0, 0x36, 0xcc, 0xc6,
-	0x46, 0xbb, 0xe8, 0x3a, 0xd7, 0x7c, 0xea, 0xc0, 0x37, 0x9d, 0xc9, 0x37, 0x50, 0x91, 0x46, 0xab,
-	0x42, 0x82, 0xe4, 0xb0, 0x75, 0xcc, 0x1d, 0xb6, 0xa0, 0x2a, 0x0f, 0x58, 0x85, 0x1e, 0x52, 0x66,
-	0xae, 0x17, 0xb2, 0xa2, 0x60, 0xa2, 0x58, 0x51, 0xe5, 0x12, 0xff, 0x6b, 0x0e, 0x3c, 0x85, 0x1e,
-	0x72, 0x2b, 0x0a, 0xda, 0xc8, 0x8a, 0x2a, 0x61, 0x2d, 0x46, 0xe8, 0x71, 0xe1, 0xe5, 0x29, 0xa6,
-	0x62, 0xc4, 0x8b, 0x0a, 0xff, 0x0d, 0x40, 0x34, 0xba, 0x12, 0xa7, 0x27, 0x66, 0x59, 0xa3, 0xe9,
-	0xef, 0x66, 0xd0, 0x63, 0x28, 0x07, 0xa3, 0x24, 0xf1, 0x74, 0x63, 0x93, 0xa5, 0x31, 0xa7, 0x3f,
-	0x85, 0x92, 0x98, 0x0d, 0xa1, 0x79, 0x46, 0xaa, 0x4e, 0x8a, 0x1a, 0xd7, 0x13, 0x94, 0xac, 0xc4,
-	0xfb, 0x8e, 0x25, 0x49, 0xea, 0x01, 0x51, 0xc0, 0x61, 0x4c, 0x94, 0x80, 0x23, 0x33, 0x52, 0x67,
-	0x11, 0x78, 0x0a, 0xad, 0xf3, 0x80, 0x23, 0x49, 0x1d, 0x1b, 0x1f, 0x25, 0x48, 0x56, 0x33, 0x94,
-	0x28, 0x18, 0x0f, 0x09, 0xa2, 0xd8, 0xb4, 0x68, 0x04, 0x51, 0x30, 0x21, 0x12, 0x44, 0xb1, 0x81,
-	0x51, 0x1a, 0xd1, 0x06, 0x94, 0x83, 0x59, 0x8c, 0x20, 0x8a, 0xcd, 0x84, 0x44, 0x3c, 0x8c, 0x0f,
-	0x6c, 0x18, 0xf1, 0x13, 0x96, 0x0a, 0x88, 0x4f, 0x36, 0x6d, 0x1b, 0x8d, 0x50, 0xfe, 0x18, 0xa3,
-	0xac, 0x40, 0x7e, 0xcf, 0x33, 0x4f, 0x11, 0x77, 0x39, 0x69, 0x54, 0xd3, 0x98, 0x93, 0x76, 0xa4,
-	0xf3, 0x9e, 0xc1, 0xb4, 0x32, 0x77, 0x19, 0xe9, 0x46, 0x0d, 0xe9, 0x75, 0xc5, 0x66, 0x34, 0xcc,
-	0x95, 0xb6, 0xa0, 0x2a, 0x0f, 0x62, 0x84, 0x43, 0xa7, 0xcc, 0x66, 0x46, 0x4b, 0xbf, 0xf6, 0xdb,
-	0x0a, 0x68, 0x3c, 0xa7, 0xd3, 0x84, 0xb6, 0x0e, 0x5a, 0xd8, 0x7f, 0x22, 0xae, 0xb2, 0x78, 0x3f,
-	0xda, 0x90, 0xeb, 0x00, 0x26, 0xc6, 0x23, 0x98, 0x09, 0x91, 0x9a, 0x03, 0xdb, 0x1a, 0x49, 0x59,
-	0x95, 0x28, 0x3d, 0x46, 0xfa, 0x14, 0x20, 0xc4, 0xf2, 0x46, 0x91, 0x8d, 0x7b, 0x4d, 0x61, 0x40,
-	0x12, 0x32, 0xcb, 0x01, 0xe9, 0x82, 0x5c, 0xd0, 0x23, 0xd0, 0xc2, 0x0e, 0x15, 0xc9, 0xb7, 0x9b,
-	0xfc, 0x9e, 0x76, 0x01, 0xa2, 0xe6, 0x56, 0xd8, 0x31, 0xd1, 0xed, 0x4e, 0x66, 0xf3, 0x15, 0x94,
-	0x83, 0x36, 0x54, 0xb8, 0x6f, 0xac, 0x2b, 0x1d, 0xab, 0x83, 0x4d, 0x28, 0x07, 0x3d, 0x64, 0xf0,
-	0x62, 0xd4, 0x46, 0x74, 0xb2, 0x00, 0xdb, 0x4c, 0x05, 0xbc, 0x0d, 0x15, 0x66, 0x88, 0xb7, 0xa5,
-	0x93, 0x99, 0xac, 0x81, 0x16, 0x76, 0x8a, 0x28, 0xaa, 0x3f, 0x14, 0x49, 0xa4, 0x1e, 0x58, 0xdc,
-	0x5c, 0x0b, 0x3b, 0x49, 0x41, 0x13, 0xef, 0x2c, 0xc7, 0x3e, 0xbd, 0x20, 0x95, 0xa4, 0x59, 0x6f,
-	0x56, 0xa9, 0xa5, 0x59, 0x18, 0xdb, 0x82, 0x8a, 0xd4, 0xc8, 0x04, 0x19, 0x30, 0xd1, 0x15, 0x35,
-	0xea, 0x49, 0x40, 0x58, 0x40, 0x6d, 0x40, 0x45, 0xea, 0x52, 0x05, 0x8f, 0x64, 0xdf, 0x9a, 0x72,
-	0xfc, 0x6a, 0x06, 0x3d, 0x87, 0x69, 0xa5, 0xcd, 0x13, 0xc9, 0x2f, 0xad, 0x73, 0x14, 0xcf, 0x3f,
-	0xbd, 0x2b, 0xa4, 0x11, 0xb9, 0xf8, 0x8c, 0xd0, 0x1e, 0x16, 0x85, 0xed, 0xdf, 0x64, 0x13, 0x7d,
-	0x0a, 0x20, 0x14, 0xa6, 0x12, 0xa6, 0xa8, 0x6a, 0x83, 0x47, 0x7c, 0xda, 0x20, 0x48, 0x11, 0x5f,
-	0x6a, 0x42, 0xa5, 0x12, 0x53, 0xe9, 0x33, 0xe9, 0x39, 0x4f, 0x83, 0x22, 0x93, 0x91, 0xcb, 0x45,
-	0xa6, 0xcc, 0xe0, 0x6a, 0x62, 0x5f, 0x52, 0x72, 0x49, 0xfc, 0xfd, 0xcf, 0x7b, 0x44, 0xe4, 0x1d,
-	0xa8, 0xca, 0xdd, 0xa4, 0x08, 0x0a, 0x29, 0x0d, 0xe6, 0xd8, 0x67, 0xb5, 0x0f, 0x55, 0xb9, 0xa9,
-	0x14, 0x5c, 0x52, 0xfa, 0xcc, 0xc9, 0x6a, 0x7f, 0x0e, 0xb3, 0xb1, 0xb6, 0x53, 0x54, 0x6f, 0xe9,
-	0xcd, 0xe8, 0x68, 0xb1, 0xb6, 0x36, 0x7e, 0x7e, 0x77, 0x33, 0xf3, 0xe7, 0x77, 0x37, 0x33, 0x7f,
-	0x7d, 0x77, 0x33, 0xf3, 0xfd, 0xe7, 0x27, 0x96, 0xdf, 0x1b, 0xb6, 0x97, 0x4d, 0xe7, 0x6c, 0x65,
-	0x60, 0x98, 0xbd, 0xf3, 0x0e, 0x71, 0xe5, 0x95, 0xe7, 0x9a, 0x2b, 0xd1, 0xdf, 0xb6, 0xb7, 0x8b,
-	0x8c, 0xdd, 0xfa, 0x3f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xba, 0xc6, 0xa8, 0x6e, 0xf0, 0x2e, 0x00,
-	0x00,
+	// 3597 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0x5d, 0x6f, 0x1b, 0x47,
+	0x92, 0x1a, 0x72, 0x48, 0xce, 0x14, 0x29, 0x69, 0xd4, 0x92, 0x65, 0x9a, 0x8e, 0x3f, 0xd2, 0xce,
+	0x87, 0xe3, 0x20, 0x92, 0x22, 0x5d, 0xfc, 0xa5, 0x38, 0x8e, 0xbe, 0x2d, 0x47, 0x67, 0xe9, 0x86,
+	0x72, 0x0e, 0x17, 0xdc, 0x81, 0x18, 0x92, 0x4d, 0x71, 0xe2, 0x11, 0x87, 0x37, 0x33, 0xb4, 0xad,
+	0x3c, 0x1c, 0xee, 0xed, 0x9e, 0xef, 0xf9, 0x80, 0xc3, 0x22, 0xcf, 0xfb, 0xb0, 0xff, 0x60, 0x81,
+	0xdd, 0x97, 0x00, 0xfb, 0xb2, 0xbf, 0x60, 0xb1, 0x30, 0xf6, 0x7f, 0xec, 0xa2, 0x3f, 0x66, 0xa6,
+	0xe7, 0x83, 0xa2, 0x64, 0xec, 0x3e, 0x24, 0xee, 0xa9, 0xaf, 0xae, 0xae, 0xaa, 0xae, 0xae, 0x2a,
+	0x0a, 0x16, 0x3a, 0x8e, 0x4d, 0x06, 0xc1, 0xf2, 0xb0, 0xe7, 0xd3, 0xff, 0x96, 0x86, 0x9e, 0x1b,
+	0xb8, 0xa8, 0x38, 0xec, 0xf9, 0x8d, 0xeb, 0x27, 0xae, 0x7b, 0xe2, 0x90, 0x65, 0x06, 0x6a, 0x8f,
+	0x7a, 0xcb, 0xe4, 0x74, 0x18, 0x9c, 0x71, 0x8a, 0xc6, 0xad, 0x34, 0x32, 0xb0, 0x4f, 0x89, 0x1f,
+	0x58, 0xa7, 0x43, 0x41, 0x70, 0x33, 0x4d, 0xf0, 0xc6, 0xb3, 0x86, 0x43, 0xe2, 0x89, 0x2d, 0x1a,
+	0x0b, 0x27, 0xee, 0x89, 0xcb, 0x96, 0xcb, 0x74, 0x25, 0xa0, 0x8b, 0x42, 0x1d, 0x6b, 0x14, 0xf4,
+	0xd9, 0xff, 0x38, 0x1c, 0x37, 0x40, 0x35, 0xc9, 0xd0, 0x45, 0x08, 0xd4, 0x81, 0x75, 0x4a, 0xea,
+	0xca, 0x6d, 0xe5, 0xae, 0x6e, 0xb2, 0x35, 0x5e, 0x87, 0xf2, 0xa6, 0x67, 0x0d, 0x3a, 0x7d, 0x74,
+	0x03, 0x54, 0x8f, 0x0c, 0x5d, 0x86, 0xad, 0xae, 0xea, 0x4b, 0xf4, 0x40, 0x94, 0xcd, 0x54, 0x3d,
+	0x99, 0xb9, 0x20, 0x31, 0x3f, 0x05, 0x75, 0xd7, 0x76, 0x08, 0xba, 0x03, 0xe5, 0x8e, 0x7b, 0x7a,
+	0x6a, 0x07, 0x82, 0xb9, 0xca, 0x98, 0xb7, 0x18, 0xc8, 0x14, 0x28, 0x2a, 0x60, 0x68, 0x05, 0xfd,
+	0x50, 0x00, 0x5d, 0xe3, 0xbf, 0x2a, 0xa0, 0xd1, 0x3d, 0xf6, 0x07, 0x3d, 0x77, 0x92, 0x02, 0xff,
+	0x04, 0x95, 0x8e, 0x47, 0xac, 0x80, 0x74, 0x99, 0x88, 0xea, 0x6a, 0x63, 0x89, 0x5b, 0x69, 0x29,
+	0xb4, 0xd2, 0xd2, 0x71, 0x68, 0x46, 0x33, 0x24, 0x45, 0x37, 0x00, 0x7c, 0xfb, 0x27, 0xd2, 0x6a,
+	0x9f, 0x05, 0xc4, 0xaf, 0x17, 0x6f, 0x2b, 0x77, 0x55, 0x53, 0xa7, 0x90, 0x4d, 0x0a, 0x40, 0xb7,
+	0xa1, 0xda, 0x25, 0x7e, 0xc7, 0xb3, 0x87, 0x81, 0xed, 0x0e, 0xea, 0x25, 0xa6, 0x9b, 0x0c, 0x42,
+	0x9f, 0x82, 0xd6, 0x66, 0x06, 0x22, 0x7e, 0xbd, 0x72, 0xbb, 0x18, 0x9d, 0x8e, 0x5b, 0xcd, 0x8c,
+	0x90, 0x68, 0x09, 0x74, 0x6a, 0xf3, 0x96, 0x3d, 0xe8, 0xb9, 0xf5, 0x32, 0xd3, 0x70, 0x2e, 0x3a,
+	0xc3, 0xc6, 0x28, 0xe8, 0xd3, 0x43, 0x9a, 0x9a, 0x25, 0x56, 0xcf, 0x55, 0x4d, 0x35, 0x4a, 0xf8,
+	0x1b, 0xa8, 0xc9, 0x78, 0xb4, 0x04, 0x35, 0xab, 0xd3, 0x21, 0xbe, 0xdf, 0x72, 0xc8, 0x6b, 0xe2,
+	0x30, 0x63, 0xcc, 0xac, 0x56, 0x97, 0x98, 0x3b, 0x9b, 0x1d, 0x77, 0x48, 0xcc, 0x2a, 0x27, 0x38,
+	0xa0, 0x78, 0xfc, 0x73, 0x01, 0x80, 0xab, 0xc2, 0xd8, 0xef, 0x40, 0x99, 0x2b, 0x54, 0x57, 0x25,
+	0x4f, 0x08, 0x5d, 0x05, 0x0a, 0xdd, 0x02, 0xb5, 0x4f, 0xac, 0xd0, 0x8c, 0x09, 0x67, 0x31, 0x04,
+	0xfa, 0x1c, 0x60, 0xe8, 0xb9, 0xaf, 0xc9, 0xc0, 0x1a, 0x74, 0x48, 0xbd, 0x98, 0x3d, 0xb5, 0x84,
+	0xa6, 0xc4, 0xfe, 0xa8, 0x1d, 0x12, 0x97, 0x72, 0x88, 0x63, 0x34, 0x7a, 0x08, 0x73, 0x5d, 0xdb,
+	0x23, 0x9d, 0xa0, 0x25, 0x6d, 0x50, 0xce, 0xf2, 0x18, 0x9c, 0xea, 0x28, 0xde, 0xe6, 0x13, 0xa8,
+	0x04, 0x9e, 0x7d, 0x72, 0x42, 0xbc, 0x7a, 0x85, 0xe9, 0x5d, 0x63, 0xf4, 0xc7, 0x1c, 0x66, 0x86,
+	0xc8, 0xdc, 0x20, 0x7f, 0x0a, 0xd5, 0xd8, 0x46, 0x3e, 0x5a, 0x81, 0x2a, 0xb7, 0x04, 0xf7, 0x95,
+	0xc2, 0xb6, 0x9f, 0x95, 0xb6, 0x67, 0x9e, 0x82, 0x76, 0xb4, 0xc6, 0xff, 0x05, 0x15, 0xb1, 0x11,
+	0x5a, 0x8c, 0x2c, 0xcc, 0x77, 0x10, 0x5f, 0xc8, 0x80, 0xa2, 0xe5, 0x38, 0xcc, 0xa6, 0x9a, 0x49,
+	0x97, 0xe8, 0x3a, 0xe8, 0x1d, 0xcf, 0x1d, 0xb4, 0xfc, 0x21, 0xe9, 0xb0, 0xc8, 0xd3, 0x4d, 0x8d,
+	0x02, 0x9a, 0x43, 0xd2, 0xa1, 0x6a, 0xd2, 0x28, 0x64, 0x6e, 0xd2, 0x4d, 0xb6, 0x46, 0x75, 0xa8,
+	0xf0, 0xbb, 0xe2, 0xb3, 0x40, 0x2c, 0x9a, 0xe1, 0x27, 0x5e, 0x83, 0x1a, 0x77, 0xd0, 0xa1, 0x67,
+	0x9f, 0xd8, 0x03, 0x74, 0x07, 0xd4, 0x57, 0xf6, 0xa0, 0x2b, 0xa2, 0x83, 0xab, 0xce, 0x51, 0xdf,
+	0xd9, 0x83, 0xae, 0xc9, 0x90, 0xf8, 0x29, 0x94, 0x39, 0xd3, 0xa4, 0x9b, 0xb5, 0x08, 0x05, 0x9b,
+	0x47, 0x83, 0xbe, 0x59, 0x7e, 0xf7, 0xa7, 0x5b, 0x85, 0xfd, 0x6d, 0xb3, 0x60, 0x77, 0x71, 0x13,
+	0xaa, 0x22, 0x2c, 0xac, 0xc1, 0x09, 0x41, 0x1f, 0x42, 0xc9, 0x71, 0xdf, 0x10, 0x2f, 0xef, 0x92,
+	0x73, 0x0c, 0x25, 0x19, 0xd1, 0x3c, 0x95, 0x17, 0x5a, 0x1c, 0x83, 0xff, 0x1d, 0x0c, 0x0e, 0x90,
+	0x7c, 0x7b, 0xa1, 0xfc, 0x11, 0x87, 0x76, 0x61, 0x6c, 0x68, 0xe3, 0xbf, 0x94, 0x00, 0x38, 0x5f,
+	0x78, 0x1d, 0x2e, 0x23, 0x78, 0x76, 0xfc, 0x9d, 0xf9, 0x0c, 0xca, 0x2e, 0x33, 0x70, 0x7d, 0x4e,
+	0xba, 0xda, 0xb2, 0x53, 0x4c, 0x41, 0x90, 0xce, 0x29, 0x5a, 0x36, 0xa7, 0xac, 0xc0, 0xf4, 0xd0,
+	0xf2, 0xc8, 0x20, 0x68, 0x09, 0xed, 0x72, 0xcc, 0x55, 0xe3, 0x14, 0xfc, 0x8b, 0x72, 0x74, 0xfa,
+	0xb6, 0xd3, 0x6d, 0x85, 0x01, 0x52, 0x95, 0xee, 0x4c, 0xc8, 0xc1, 0x28, 0xf8, 0x87, 0x4f, 0xd3,
+	0xa5, 0x1f, 0x58, 0x1e, 0x4d, 0x97, 0xc5, 0xc9, 0xe9, 0x52, 0x90, 0xa2, 0xfb, 0xa0, 0xf5, 0xec,
+	0x81, 0xed, 0xf7, 0x49, 0xb7, 0xae, 0x4e, 0x64, 0x8b, 0x68, 0x53, 0x69, 0xb6, 0x94, 0x4e, 0xb3,
+	0x5f, 0x25, 0x12, 0x8a, 0xc1, 0x74, 0xbf, 0x22, 0xe9, 0x1e, 0xc7, 0x42, 0x22, 0xb5, 0x7c, 0x06,
+	0x86, 0x47, 0xac, 0xee, 0x99, 0x9c, 0x2c, 0x6a, 0xec, 0x66, 0xcc, 0x32, 0x78, 0xcc, 0x86, 0x56,
+	0x12, 0x59, 0x48, 0x67, 0x3b, 0x18, 0xb2, 0x75, 0x68, 0x08, 0x27, 0x52, 0xd1, 0x63, 0xb8, 0x16,
+	0x7e, 0x85, 0x7e, 0xf0, 0x5b, 0xfe, 0x88, 0xe5, 0xd6, 0x3a, 0x62, 0xbb, 0x5c, 0x8d, 0x08, 0x84,
+	0x55, 0x9b, 0x1c, 0x9d, 0xcf, 0xdb, 0xb3, 0x6c, 0x67, 0xe4, 0x91, 0xfa, 0x7c, 0x3e, 0xef, 0x2e,
+	0x47, 0xa3, 0xfb, 0x70, 0x35, 0xcb, 0x1b, 0xb8, 0x81, 0xe5, 0xd4, 0x17, 0x18, 0xe7, 0x95, 0x34,
+	0xe7, 0x31, 0x45, 0x3e, 0x57, 0xb5, 0xb2, 0x51, 0x79, 0xae, 0x6a, 0x60, 0x54, 0xf1, 0xef, 0x14,
+	0xd0, 0xe8, 0xcb, 0x1b, 0xbe, 0x9b, 0x3d, 0xdb, 0x21, 0x89, 0xdb, 0x4d, 0x91, 0x26, 0x03, 0xa3,
+	0x7b, 0xa0, 0xd3, 0x7f, 0x5b, 0xc1, 0xd9, 0x90, 0xbf, 0xde, 0x33, 0xab, 0xd3, 0x11, 0xcd, 0xf1,
+	0xd9, 0x90, 0x50, 0x37, 0xf2, 0xd5, 0xa4, 0xd7, 0xf2, 0x21, 0xe8, 0x5c, 0x61, 0x1a, 0x55, 0x30,
+	0x31, 0x3c, 0x62, 0x62, 0x9a, 0xee, 0xfa, 0x96, 0xdf, 0x67, 0xa9, 0xbb, 0x66, 0xb2, 0x35, 0x5e,
+	0x63, 0x57, 0x75, 0x68, 0x75, 0xd8, 0x9d, 0xf8, 0x18, 0x66, 0xec, 0xc1, 0x70, 0x44, 0x1f, 0x06,
+	0xd2, 0xb3, 0xdf, 0x12, 0xbf, 0x5e, 0xb8, 0x5d, 0xbc, 0xab, 0x9b, 0xd3, 0x0c, 0x7a, 0x24, 0x80,
+	0xf8, 0xff, 0x15, 0x28, 0x35, 0xfb, 0x96, 0xd7, 0x45, 0xcb, 0x00, 0x9d, 0x88, 0x5d, 0x1c, 0x7e,
+	0x36, 0xf4, 0xb8, 0x00, 0x9b, 0x12, 0x09, 0xfa, 0x08, 0x4a, 0x1e, 0x8d, 0x02, 0x71, 0xdb, 0x66,
+	0x18, 0xed, 0x91, 0x15, 0xf4, 0x79, 0x6c, 0x70, 0x24, 0xba, 0x05, 0x55, 0x77, 0x14, 0x30, 0x45,
+	0x68, 0xb5, 0xc2, 0xf3, 0x36, 0x70, 0x10, 0x25, 0x4e, 0xd9, 0x48, 0x65, 0x2e, 0x8b, 0x6d, 0x84,
+	0x1f, 0x80, 0x1e, 0xc9, 0x44, 0x0b, 0x72, 0xca, 0xd4, 0xc3, 0x2c, 0xb9, 0x20, 0x67, 0x49, 0x3d,
+	0x4c, 0x8c, 0x1e, 0xcc, 0x6d, 0xb1, 0xa2, 0x85, 0x65, 0x66, 0xf2, 0x9f, 0x23, 0xe2, 0x4f, 0xcc,
+	0xdc, 0xa9, 0x54, 0x53, 0xcc, 0xa6, 0x9a, 0x45, 0x28, 0x8f, 0x86, 0x5d, 0x2b, 0xe0, 0x2f, 0x8d,
+	0x66, 0x8a, 0xaf, 0xe7, 0xaa, 0x56, 0x30, 0x8a, 0x78, 0x0d, 0xd0, 0xfe, 0x80, 0xbe, 0x4f, 0xc1,
+	0xc5, 0x37, 0xc5, 0x57, 0x61, 0xf6, 0xc0, 0xf6, 0x65, 0x8e, 0xe7, 0xaa, 0xa6, 0x18, 0x05, 0xfc,
+	0x0d, 0x18, 0x31, 0xc2, 0x1f, 0xba, 0x03, 0x9f, 0x45, 0x1f, 0x65, 0x92, 0x5f, 0xda, 0xe9, 0x48,
+	0x20, 0xaf, 0x88, 0x3c, 0xb1, 0xc2, 0x3f, 0xc0, 0xdc, 0x36, 0x71, 0xc8, 0xa5, 0x2c, 0xb0, 0x00,
+	0xa5, 0x9e, 0xeb, 0x75, 0x88, 0x78, 0x78, 0xf9, 0x47, 0xf8, 0x18, 0x17, 0xa3, 0xc7, 0x18, 0xff,
+	0x46, 0x01, 0xd4, 0xa4, 0x49, 0x4e, 0xa4, 0x03, 0x21, 0xfd, 0x0e, 0x94, 0x79, 0x9e, 0xcd, 0x7d,
+	0x20, 0x38, 0x2a, 0x6d, 0x65, 0x35, 0xd7, 0xca, 0xe2, 0x09, 0x29, 0x26, 0x8a, 0x82, 0x64, 0xde,
+	0x2b, 0x5d, 0x30, 0xef, 0x09, 0xe7, 0xfc, 0xaf, 0x02, 0xf3, 0xbb, 0x2c, 0xc1, 0x66, 0x74, 0x9e,
+	0xfc, 0xa8, 0xa5, 0x74, 0x2e, 0x64, 0x75, 0x4e, 0xc6, 0x71, 0x39, 0x7d, 0xd7, 0x17, 0xa0, 0xc4,
+	0x5a, 0x16, 0x11, 0x37, 0xfc, 0x03, 0x0f, 0x60, 0x41, 0x04, 0xcc, 0x7b, 0xe8, 0xf4, 0x25, 0x54,
+	0xdb, 0x8e, 0xdb, 0x79, 0xd5, 0xf2, 0x03, 0x1a, 0x90, 0x3c, 0x17, 0xc9, 0x49, 0xba, 0x49, 0xe1,
+	0x26, 0x30, 0x22, 0xb6, 0xc6, 0x3f, 0x2b, 0x30, 0x47, 0x63, 0x2a, 0xb9, 0xdb, 0x84, 0x98, 0xb8,
+	0x05, 0x6a, 0xcf, 0x73, 0x4f, 0x73, 0xeb, 0x5b, 0x8a, 0x40, 0xd7, 0xa1, 0x10, 0xb8, 0xf5, 0x62,
+	0x16, 0x5d, 0x08, 0x68, 0x35, 0x54, 0x1e, 0x8c, 0x4e, 0xdb, 0xc4, 0x63, 0x27, 0x57, 0x4d, 0xf1,
+	0x45, 0xab, 0x33, 0x8f, 0xbc, 0x26, 0x9e, 0x4f, 0xd8, 0xfb, 0xa6, 0x99, 0xe1, 0x27, 0x2d, 0x2f,
+	0xe3, 0x9a, 0x83, 0x95, 0x97, 0xfc, 0xc0, 0xd9, 0xf2, 0x32, 0x26, 0x63, 0x99, 0x49, 0xac, 0xf1,
+	0x63, 0x98, 0xe7, 0x81, 0x7f, 0x79, 0xa3, 0x62, 0x0b, 0xd0, 0xae, 0x33, 0x4a, 0xc7, 0xc8, 0xc7,
+	0x71, 0x29, 0xa9, 0x64, 0x2b, 0x85, 0x10, 0x87, 0x3e, 0x02, 0x2d, 0x70, 0x5b, 0xd4, 0x68, 0x3c,
+	0xdd, 0x26, 0x8c, 0x59, 0x09, 0x5c, 0xfa, 0xaf, 0x8f, 0x7f, 0xaf, 0xc0, 0x62, 0x73, 0xd4, 0xa6,
+	0xa1, 0xd3, 0x26, 0x97, 0xf2, 0xc4, 0x62, 0xa2, 0x66, 0xd3, 0xa5, 0x6a, 0x4a, 0xa5, 0xe1, 0xce,
+	0x0c, 0x39, 0xf6, 0x46, 0x30, 0x92, 0xc8, 0x99, 0xc5, 0x71, 0xce, 0xfc, 0x04, 0x4a, 0x3c, 0x9e,
+	0xd4, 0x31, 0xf1, 0xc4, 0xd1, 0xf8, 0x11, 0xa0, 0x2d, 0x87, 0x58, 0xde, 0x7b, 0xd8, 0xf8, 0x0f,
+	0x0a, 0xcc, 0xf3, 0xdc, 0x2c, 0xaa, 0x42, 0xc1, 0x1c, 0x36, 0x52, 0xca, 0xb8, 0x46, 0xea, 0x1a,
+	0x68, 0x7e, 0x2b, 0x61, 0x81, 0x8a, 0xcf, 0x45, 0x48, 0x55, 0x67, 0x71, 0x7c, 0xd5, 0x99, 0x6c,
+	0xc4, 0xd4, 0xf3, 0x1b, 0x31, 0xa9, 0x43, 0x2a, 0x9d, 0xd3, 0x21, 0xe1, 0xf5, 0xe8, 0x0e, 0x27,
+	0x4f, 0x73, 0x27, 0xd1, 0xd9, 0x8c, 0x29, 0xb0, 0x0f, 0xf8, 0x7d, 0x4c, 0x72, 0x4e, 0x88, 0x02,
+	0xe9, 0xe6, 0x14, 0x92, 0x37, 0xe7, 0x28, 0x0c, 0xfc, 0xcb, 0x6b, 0x92, 0x9f, 0xf9, 0xf1, 0x7f,
+	0x17, 0x00, 0x36, 0x86, 0x43, 0x32, 0xe8, 0xb2, 0xc9, 0xc4, 0x07, 0xa0, 0xbb, 0xaf, 0x89, 0xf7,
+	0xc6, 0xb3, 0x03, 0x5e, 0x20, 0x69, 0x66, 0x0c, 0xa0, 0xcf, 0x44, 0x60, 0x9d, 0x08, 0xcf, 0xd0,
+	0x25, 0xfa, 0x1a, 0x66, 0x3d, 0xeb, 0x4d, 0x8b, 0x15, 0x4c, 0xbe, 0x3b, 0xf2, 0x58, 0xfb, 0x4b,
+	0x55, 0x40, 0xfc, 0x50, 0xd6, 0x1b, 0x2a, 0xb6, 0xc9, 0x30, 0xcf, 0xa6, 0xcc, 0x69, 0x4f, 0x06,
+	0x50, 0xee, 0xc0, 0xf2, 0x12, 0xdc, 0xaa, 0xc4, 0x7d, 0x6c, 0x79, 0x49, 0xee, 0xc0, 0xf2, 0x92,
+	0xdc, 0x23, 0xcf, 0x49, 0x70, 0x97, 0x24, 0xee, 0x97, 0xe6, 0x41, 0x92, 0x7b, 0xe4, 0x39, 0x31,
+	0x60, 0x53, 0x83, 0x32, 0x67, 0xc2, 0xfb, 0x30, 0x9d, 0xd0, 0x33, 0x9a, 0xbc, 0x28, 0xf1, 0xe4,
+	0x85, 0xc2, 0xba, 0x56, 0x60, 0xb1, 0xb3, 0xd7, 0x4c, 0xb6, 0xa6, 0xe6, 0xd8, 0x39, 0xdc, 0x0d,
+	0x5f, 0xcd, 0x9d, 0xc3, 0x5d, 0x7c, 0x07, 0xa6, 0x13, 0x4a, 0x47, 0x6c, 0x4a, 0xcc, 0x86, 0x9b,
+	0x30, 0x9d, 0xd0, 0x2d, 0x77, 0x3f, 0x03, 0x8a, 0x2f, 0xcd, 0x83, 0xd0, 0xd4, 0x2f, 0xcd, 0x03,
+	0xea, 0x1a, 0x8f, 0x74, 0x46, 0x9e, 0x6f, 0xbf, 0x26, 0x62, 0xcf, 0x18, 0x80, 0x57, 0x01, 0x78,
+	0x64, 0x30, 0x37, 0x22, 0xa9, 0xc4, 0xd5, 0x45, 0x5d, 0x9b, 0x71, 0x1e, 0x7d, 0xe3, 0xe7, 0xfe,
+	0xd9, 0xed, 0xda, 0xbd, 0x33, 0xca, 0x74, 0xa9, 0xa7, 0x69, 0x15, 0xaa, 0x16, 0x8b, 0x1a, 0x66,
+	0x7e, 0xf1, 0x72, 0xf0, 0x9c, 0x1d, 0x47, 0xd3, 0xb3, 0x29, 0x13, 0xac, 0xe8, 0x8b, 0xf2, 0x74,
+	0x99, 0x8a, 0x9c, 0xa7, 0x28, 0xf1, 0xc4, 0xaa, 0x53, 0x9e, 0x6e, 0xf4, 0xb5, 0x39, 0x03, 0xb5,
+	0x53, 0xaa, 0xa1, 0xdd, 0xb1, 0xe8, 0x23, 0x8c, 0x6d, 0x98, 0xdd, 0x72, 0x87, 0x09, 0x7d, 0xaf,
+	0x43, 0xd1, 0xf7, 0x3a, 0xd9, 0x6a, 0x9e, 0x42, 0x29, 0xb2, 0xeb, 0x87, 0xfd, 0xa2, 0x8c, 0xec,
+	0xfa, 0x41, 0x32, 0xd8, 0x8b, 0xa9, 0x60, 0xc7, 0xcb, 0x30, 0xb3, 0x47, 0x02, 0x79, 0xa7, 0xf3,
+	0x1b, 0x07, 0xa9, 0x38, 0xbc, 0x04, 0xd3, 0x36, 0x2f, 0x0e, 0x2f, 0xce, 0xc1, 0x7c, 0x3b, 0x8a,
+	0x26, 0x27, 0x6c, 0x8d, 0x57, 0x60, 0xf6, 0x5f, 0x2d, 0xe7, 0xd5, 0x25, 0xf6, 0x3d, 0x82, 0xd9,
+	0x3d, 0xc7, 0x6d, 0x5f, 0xda, 0xf1, 0x75, 0xa8, 0x0c, 0xad, 0x20, 0x20, 0x5e, 0x58, 0x23, 0x85,
+	0x9f, 0xf8, 0x0d, 0xcc, 0x6e, 0xdb, 0xbd, 0x9e, 0x2c, 0xf1, 0x23, 0xd0, 0x06, 0x84, 0x67, 0x87,
+	0xac, 0x1e, 0x95, 0x01, 0x61, 0x97, 0x8e, 0x52, 0xb9, 0x4e, 0x22, 0x90, 0x64, 0x2a, 0xd7, 0xe1,
+	0xd1, 0x53, 0x87, 0x8a, 0xdf, 0xb7, 0x1c, 0xc7, 0x7d, 0x23, 0x5c, 0x15, 0x7e, 0xe2, 0x1e, 0x18,
+	0xf1, 0xc6, 0xa2, 0x8c, 0xbe, 0x9b, 0xd9, 0x39, 0xee, 0xe1, 0x58, 0x39, 0x11, 0xed, 0x7e, 0x37,
+	0xb3, 0x7b, 0x9a, 0x52, 0x68, 0x80, 0x6f, 0x41, 0x75, 0xd7, 0xef, 0xbc, 0x0a, 0x0f, 0x67, 0x40,
+	0xb1, 0x67, 0xbf, 0x15, 0x49, 0x92, 0x2e, 0xf1, 0x7d, 0xa8, 0x71, 0x02, 0xa1, 0x84, 0x44, 0xa1,
+	0x33, 0x0a, 0x56, 0x24, 0x7a, 0x9e, 0x1b, 0x75, 0x32, 0xec, 0x03, 0xdf, 0x87, 0x2b, 0xfc, 0xb5,
+	0xa4, 0xdb, 0xf8, 0x24, 0x88, 0x04, 0xdc, 0x00, 0xe8, 0x71, 0x50, 0xcb, 0xee, 0x0a, 0x39, 0xba,
+	0x80, 0xec, 0x77, 0xf1, 0x4b, 0x98, 0x37, 0x89, 0x38, 0x07, 0x63, 0x0b, 0x3d, 0x7f, 0x1e, 0x17,
+	0x6d, 0xd8, 0x82, 0xc0, 0x69, 0xf9, 0xa4, 0xe3, 0x0e, 0xba, 0x3e, 0xd3, 0xa4, 0x68, 0x42, 0x10,
+	0x38, 0x4d, 0x0e, 0xc1, 0xd7, 0xa1, 0xb4, 0x49, 0x2b, 0xca, 0xa8, 0x09, 0x15, 0x59, 0x84, 0xae,
+	0xf1, 0x07, 0x50, 0x3e, 0x6c, 0xff, 0x48, 0x3a, 0x41, 0x2e, 0xf6, 0x1a, 0x14, 0x8f, 0xad, 0x93,
+	0xdc, 0x99, 0xe2, 0x03, 0xd0, 0x69, 0xa1, 0x9c, 0xd3, 0xe7, 0xa9, 0xb9, 0x7d, 0x9e, 0x1a, 0xf6,
+	0x79, 0x26, 0x68, 0x4c, 0x1d, 0x93, 0xf4, 0xd0, 0x6d, 0x28, 0xb1, 0x62, 0x57, 0xf8, 0x14, 0xf8,
+	0x3b, 0xc7, 0xb0, 0x1c, 0x91, 0xdf, 0xb4, 0x46, 0x1b, 0x8b, 0xa6, 0x15, 0xff, 0x07, 0x00, 0x3f,
+	0x45, 0x38, 0xf5, 0x72, 0xd9, 0x57, 0x22, 0xf0, 0x39, 0x81, 0x29, 0x50, 0xb4, 0x31, 0xe3, 0xc5,
+	0xb8, 0x47, 0x7a, 0x89, 0x40, 0x09, 0x95, 0x33, 0xb5, 0xb6, 0x58, 0xe1, 0xdf, 0x16, 0x01, 0x6d,
+	0x8e, 0xa2, 0xe1, 0xd2, 0xa5, 0x9a, 0xa7, 0xc5, 0xc4, 0x44, 0x5a, 0xcf, 0x19, 0xa8, 0xd5, 0x26,
+	0x0d, 0xd4, 0x92, 0x5d, 0x54, 0xf9, 0xa2, 0xd3, 0xa3, 0x5b, 0xa0, 0x06, 0x1e, 0x21, 0xf5, 0x62,
+	0xd6, 0x08, 0x0c, 0x41, 0xa7, 0x95, 0xf4, 0xdf, 0xe4, 0x5c, 0x5f, 0x50, 0x70, 0x0c, 0x3d, 0x62,
+	0xd7, 0x0a, 0x46, 0xa7, 0x3e, 0x1b, 0xe3, 0xa5, 0x4d, 0xc9, 0x51, 0x68, 0x06, 0x0a, 0xfb, 0xdb,
+	0xe2, 0xb7, 0x83, 0xc2, 0xfe, 0x76, 0xaa, 0xb3, 0xd2, 0xd3, 0x9d, 0x95, 0x34, 0x99, 0x83, 0xf7,
+	0x9b, 0xcc, 0x55, 0x2f, 0x3e, 0x99, 0x13, 0xbd, 0x64, 0x1f, 0x8c, 0xa3, 0x51, 0x20, 0xf4, 0x16,
+	0xee, 0x5b, 0x80, 0xd2, 0x6b, 0xcb, 0x19, 0x11, 0xf1, 0x98, 0xf3, 0x0f, 0xf4, 0x01, 0xa8, 0x81,
+	0x75, 0x12, 0xb6, 0x03, 0x9a, 0x28, 0x5c, 0x4e, 0x4c, 0x06, 0x8d, 0x03, 0xb6, 0x38, 0x26, 0x60,
+	0x71, 0x2f, 0x2c, 0x95, 0x93, 0x9b, 0xfd, 0xdd, 0x63, 0xf2, 0xff, 0x14, 0x98, 0xdb, 0x23, 0xe2,
+	0x48, 0xbe, 0xd4, 0xf7, 0x70, 0x59, 0xc9, 0xbe, 0x47, 0xec, 0x13, 0xe2, 0xd0, 0x87, 0x50, 0x73,
+	0x7b, 0x3d, 0x9a, 0x51, 0xb8, 0x8f, 0xf8, 0x05, 0xad, 0x72, 0x18, 0xf7, 0xd2, 0x84, 0x51, 0xd8,
+	0x0d, 0x00, 0x36, 0xb3, 0x6b, 0x45, 0x53, 0x7c, 0xd5, 0xd4, 0x19, 0xa4, 0x69, 0xff, 0x44, 0x6b,
+	0xb0, 0xd9, 0xa3, 0x51, 0x20, 0xd4, 0xe6, 0xaa, 0x4d, 0xbe, 0xeb, 0x91, 0x43, 0x0a, 0x92, 0x43,
+	0xf0, 0x1a, 0xcc, 0xee, 0x91, 0x4b, 0x8a, 0xc2, 0xbf, 0x52, 0xc0, 0x08, 0xb9, 0x22, 0xe3, 0x7c,
+	0x2e, 0xcc, 0x6b, 0x92, 0x9e, 0x9f, 0x98, 0xc5, 0x44, 0xe6, 0x8d, 0xf1, 0xff, 0x78, 0x13, 0x21,
+	0x3e, 0x2d, 0x92, 0x0f, 0x86, 0x5f, 0x82, 0x71, 0x6c, 0x9d, 0xbc, 0x47, 0xe4, 0x9c, 0x1b, 0xb5,
+	0x78, 0x01, 0x10, 0xdd, 0x2a, 0x19, 0x2b, 0xb4, 0x64, 0xa0, 0xd0, 0x63, 0xeb, 0x24, 0xb2, 0xd0,
+	0x22, 0x94, 0xf9, 0xf8, 0x31, 0xfc, 0x71, 0x87, 0x7f, 0xf1, 0xe1, 0x64, 0xc7, 0x19, 0x75, 0x49,
+	0x4b, 0xe8, 0xc2, 0xab, 0x95, 0x69, 0x01, 0xe5, 0x92, 0x71, 0x13, 0x8c, 0x58, 0xa2, 0x78, 0xf3,
+	0x1a, 0xbc, 0x4c, 0xe5, 0xba, 0xc7, 0x8a, 0x51, 0xa0, 0x74, 0xb4, 0xc2, 0xd8, 0xa3, 0xe1, 0x27,
+	0xb0, 0xc0, 0xcb, 0xc9, 0xf7, 0x0a, 0x75, 0x7c, 0x15, 0xae, 0xa4, 0xd8, 0xb9, 0x62, 0xf8, 0xcb,
+	0x70, 0xda, 0x26, 0x1b, 0x20, 0xb4, 0xa3, 0x32, 0xce, 0x8e, 0x32, 0x8b, 0x10, 0x44, 0x1b, 0xeb,
+	0x3e, 0xe9, 0xbc, 0xba, 0xbc, 0xdb, 0xf0, 0x17, 0x30, 0x9f, 0x60, 0x15, 0x36, 0x5b, 0x84, 0x32,
+	0x79, 0x6b, 0xfb, 0xec, 0x64, 0x6c, 0x68, 0xc9, 0xbf, 0xf0, 0x0a, 0x54, 0xc4, 0x29, 0x2e, 0x7a,
+	0xfa, 0x27, 0x30, 0xcf, 0xf3, 0xde, 0xb6, 0xed, 0x49, 0xca, 0x19, 0x50, 0x74, 0xdb, 0x3f, 0x86,
+	0x95, 0x8c, 0xdb, 0xfe, 0x71, 0xcc, 0xdd, 0xfb, 0x14, 0xe6, 0xf7, 0xc8, 0x05, 0xd8, 0xf1, 0x33,
+	0x58, 0x8c, 0xac, 0x9c, 0xa4, 0x5d, 0x4c, 0xd8, 0x41, 0x8f, 0x22, 0x36, 0x0e, 0xb5, 0x82, 0x1c,
+	0x6a, 0xf8, 0x7f, 0x0a, 0x50, 0x0d, 0xdf, 0xf2, 0x2e, 0x79, 0x8b, 0x1e, 0xa4, 0x0f, 0x7a, 0x43,
+	0x3a, 0x28, 0x23, 0x11, 0x6b, 0x7f, 0x67, 0x10, 0x78, 0x67, 0x71, 0x8e, 0x5b, 0x4a, 0x5c, 0x89,
+	0x46, 0x86, 0x8b, 0xfa, 0x90, 0xb3, 0x30, 0xba, 0xc6, 0x3e, 0xd4, 0x64, 0x41, 0xf4, 0x90, 0xaf,
+	0xc8, 0x59, 0x78, 0xc8, 0x57, 0xe4, 0x0c, 0xdd, 0x91, 0x6d, 0x94, 0xc9, 0x1d, 0x1c, 0xf7, 0xb8,
+	0xf0, 0x50, 0x69, 0x6c, 0x83, 0x1e, 0x49, 0xcf, 0x91, 0xf3, 0x61, 0x52, 0x4e, 0xf2, 0xdd, 0x8d,
+	0xa4, 0xe0, 0x4f, 0x60, 0xe6, 0x30, 0xec, 0x5e, 0xb8, 0x2d, 0x16, 0xa0, 0x64, 0xd3, 0x05, 0x13,
+	0x56, 0x34, 0xf9, 0xc7, 0xbd, 0x7b, 0x00, 0xf1, 0x6f, 0x9f, 0x48, 0x03, 0xf5, 0x65, 0x73, 0xc7,
+	0x34, 0xa6, 0xe8, 0x6a, 0xe3, 0xe5, 0xf1, 0xa1, 0xa1, 0xd0, 0xd5, 0x6e, 0x73, 0xeb, 0x3b, 0xa3,
+	0x70, 0xef, 0x73, 0xfe, 0xbb, 0x09, 0xfb, 0xb1, 0xa3, 0x06, 0x9a, 0xb9, 0xd3, 0xdc, 0x31, 0xbf,
+	0xdf, 0xd9, 0xe6, 0xd4, 0xbb, 0xfb, 0x07, 0x3b, 0x86, 0x82, 0x2a, 0x50, 0xdc, 0xde, 0x37, 0x8d,
+	0xc2, 0xbd, 0x35, 0xa8, 0x4a, 0x73, 0x24, 0x54, 0x85, 0x4a, 0xf3, 0x78, 0xc3, 0x3c, 0x66, 0xe4,
+	0x3a, 0x94, 0xcc, 0x9d, 0x8d, 0xed, 0x7f, 0x33, 0x14, 0x2a, 0x67, 0x77, 0xff, 0xc5, 0x7e, 0xf3,
+	0xd9, 0xce, 0xb6, 0x51, 0xb8, 0xb7, 0x0e, 0xfa, 0x36, 0x71, 0xec, 0x53, 0x3b, 0x20, 0x1e, 0x15,
+	0xfa, 0xe2, 0xf0, 0xc5, 0x0e, 0x17, 0xff, 0xbc, 0x79, 0xf8, 0x82, 0x2b, 0x73, 0xb0, 0xff, 0x62,
+	0xc7, 0x28, 0xd0, 0x8d, 0x9a, 0xff, 0x72, 0x60, 0x14, 0xe9, 0x62, 0xab, 0xf9, 0xbd, 0xa1, 0xae,
+	0xfe, 0x32, 0x0d, 0xc5, 0x8d, 0xa3, 0x7d, 0xf4, 0x0d, 0x40, 0xfc, 0x5b, 0x00, 0x5a, 0xe4, 0xb5,
+	0x4e, 0xfa, 0xc7, 0x81, 0xc6, 0x62, 0xa6, 0x00, 0xd8, 0x61, 0x43, 0xda, 0x29, 0xf4, 0x00, 0xaa,
+	0xd2, 0x5c, 0x1f, 0x5d, 0x65, 0x02, 0xb2, 0x93, 0xfe, 0x46, 0x72, 0x14, 0x8f, 0xa7, 0xd0, 0x23,
+	0xd0, 0xc2, 0x11, 0x3e, 0x5a, 0x60, 0xc8, 0xd4, 0xa8, 0xbf, 0x71, 0x25, 0x05, 0x15, 0x49, 0x60,
+	0x8a, 0xea, 0x1c, 0x4f, 0xef, 0x85, 0xce, 0x99, 0x71, 0xfe, 0x39, 0x3a, 0x7f, 0x05, 0x55, 0x69,
+	0x40, 0x2f, 0x74, 0xce, 0x8e, 0xec, 0x1b, 0x72, 0x95, 0x89, 0xa7, 0xd0, 0x26, 0xd4, 0xe4, 0x21,
+	0x39, 0xaa, 0x8b, 0x6e, 0x27, 0x33, 0x37, 0x3f, 0x67, 0xeb, 0x27, 0x30, 0x9d, 0x98, 0x6a, 0xa3,
+	0x6b, 0xb2, 0xc1, 0x92, 0x52, 0xd2, 0x83, 0x5c, 0x66, 0x34, 0x88, 0x67, 0xd4, 0xe2, 0xe4, 0x99,
+	0xa1, 0x75, 0x0e, 0xe3, 0x8a, 0x42, 0xb5, 0x97, 0x27, 0xbf, 0x42, 0xfb, 0x9c, 0x61, 0xf0, 0x39,
+	0xda, 0xaf, 0x43, 0x55, 0x9a, 0x00, 0x0b, 0xc3, 0x65, 0x67, 0xc2, 0xf9, 0x0a, 0x6c, 0xc1, 0x6c,
+	0x6a, 0xb4, 0x8b, 0xae, 0x73, 0xcb, 0xe7, 0x0e, 0x7c, 0xf3, 0x85, 0x7c, 0x0b, 0x55, 0x69, 0xb4,
+	0x2a, 0x34, 0xc8, 0x0e, 0x5b, 0xcf, 0x39, 0xc3, 0x26, 0xd4, 0xe4, 0x01, 0xab, 0xb0, 0x43, 0xce,
+	0xcc, 0xf5, 0x42, 0x5e, 0x14, 0x42, 0x12, 0x5e, 0x4c, 0x4a, 0x49, 0xff, 0xb5, 0x07, 0x9e, 0x42,
+	0x0f, 0xb9, 0x17, 0x05, 0x6f, 0xec, 0xc5, 0x24, 0xa3, 0x91, 0x62, 0xf4, 0xb9, 0xf2, 0xf2, 0x14,
+	0x33, 0xe1, 0xc4, 0x8b, 0x2a, 0xff, 0x2d, 0x40, 0x3c, 0xba, 0x12, 0xbb, 0x67, 0x66, 0x59, 0xe3,
+	0xf9, 0xef, 0x2a, 0xe8, 0x31, 0x68, 0xe1, 0x28, 0x49, 0x5c, 0xdd, 0xd4, 0x64, 0xe9, 0x9c, 0xdd,
+	0x9f, 0x42, 0x45, 0xcc, 0x86, 0xd0, 0x3c, 0x63, 0x4d, 0x4e, 0x8a, 0x1a, 0xd7, 0x33, 0x9c, 0xac,
+	0xc4, 0xfb, 0x9e, 0x3d, 0x92, 0x34, 0x02, 0xe2, 0x84, 0xc3, 0x84, 0x24, 0x12, 0x8e, 0x2c, 0x28,
+	0x39, 0x8b, 0xc0, 0x53, 0x68, 0x8d, 0x27, 0x1c, 0x49, 0xeb, 0xd4, 0xf8, 0x28, 0xc3, 0xb2, 0xa2,
+	0x50, 0xa6, 0x70, 0x3c, 0x24, 0x98, 0x52, 0xd3, 0xa2, 0x31, 0x4c, 0xe1, 0x84, 0x48, 0x30, 0xa5,
+	0x06, 0x46, 0x79, 0x4c, 0xeb, 0xa0, 0x85, 0xb3, 0x18, 0xc1, 0x94, 0x9a, 0x09, 0x35, 0xae, 0xa4,
+	0xa0, 0x61, 0x3e, 0x5c, 0x51, 0xd0, 0x13, 0xf6, 0x14, 0x90, 0x80, 0x6c, 0x38, 0x0e, 0x1a, 0x63,
+	0xfc, 0x73, 0x9c, 0xb2, 0x0c, 0x2a, 0x1d, 0xbf, 0x20, 0x1e, 0x72, 0xd2, 0xa8, 0xa6, 0x31, 0x27,
+	0x41, 0xa4, 0xfd, 0xf6, 0x60, 0x3a, 0x31, 0x77, 0x19, 0x1b, 0x46, 0x0d, 0xe9, 0x76, 0xa5, 0x66,
+	0x34, 0x2c, 0x94, 0x36, 0xa1, 0x26, 0x0f, 0x62, 0x44, 0x40, 0xe7, 0xcc, 0x66, 0xc6, 0x6b, 0xbf,
+	0xfa, 0xeb, 0x2a, 0xe8, 0xfc, 0x4d, 0xa7, 0x0f, 0xda, 0x1a, 0xe8, 0x51, 0xff, 0x89, 0xb8, 0xc9,
+	0xd2, 0xfd, 0x68, 0x43, 0xae, 0x03, 0x98, 0x1a, 0x8f, 0x60, 0x26, 0x22, 0x6a, 0x0e, 0x1d, 0x7b,
+	0x2c, 0x67, 0x4d, 0xe2, 0xf4, 0x19, 0xeb, 0x53, 0x80, 0x88, 0xca, 0x1f, 0xc7, 0x76, 0xde, 0x6d,
+	0x8a, 0x12, 0x92, 0xd0, 0x59, 0x4e, 0x48, 0x17, 0x94, 0x82, 0x1e, 0x81, 0x1e, 0x75, 0xa8, 0x48,
+	0x3e, 0xdd, 0xe4, 0xfb, 0xb4, 0x03, 0x10, 0xb1, 0xfa, 0xc2, 0x8f, 0x99, 0x6e, 0x77, 0xb2, 0x98,
+	0xaf, 0x41, 0x0b, 0xdb, 0x50, 0x11, 0xbe, 0xa9, 0xae, 0xf4, 0x5c, 0x1b, 0x6c, 0x80, 0xb6, 0x47,
+	0x12, 0xdc, 0xa9, 0x46, 0x74, 0xb2, 0x02, 0x5b, 0xa0, 0x87, 0x3c, 0xa1, 0x1b, 0xd2, 0x6d, 0xe9,
+	0x64, 0x21, 0xab, 0xa0, 0x47, 0x9d, 0x22, 0x8a, 0xeb, 0x8f, 0x84, 0x26, 0x52, 0x0f, 0x2c, 0x4e,
+	0xae, 0x47, 0x9d, 0xa4, 0xe0, 0x49, 0x77, 0x96, 0xe7, 0x5e, 0xbd, 0xf0, 0x29, 0xc9, 0xf3, 0xde,
+	0x6c, 0xa2, 0x96, 0x66, 0x69, 0x6c, 0x13, 0xaa, 0x52, 0x23, 0x13, 0xbe, 0x80, 0x99, 0xae, 0xa8,
+	0x51, 0xcf, 0x22, 0xa2, 0x02, 0x6a, 0x1d, 0xaa, 0x52, 0x97, 0x2a, 0x64, 0x64, 0xfb, 0xd6, 0x9c,
+	0xed, 0x57, 0x14, 0xf4, 0x0c, 0xa6, 0x13, 0x6d, 0x9e, 0x78, 0xfc, 0xf2, 0x3a, 0xc7, 0x46, 0x23,
+	0x0f, 0x15, 0xa9, 0xb1, 0x06, 0xe5, 0x3d, 0x42, 0x7b, 0x58, 0x14, 0xb5, 0x7f, 0x93, 0x5d, 0xf4,
+	0x19, 0x80, 0x30, 0x58, 0x92, 0x31, 0xc7, 0x54, 0xeb, 0x3c, 0xe3, 0xd3, 0x06, 0x41, 0xca, 0xf8,
+	0x52, 0x13, 0xda, 0xb8, 0x92, 0x82, 0x4a, 0x29, 0xee, 0x69, 0x58, 0x64, 0x32, 0x76, 0xb9, 0xc8,
+	0x94, 0x05, 0x5c, 0xcd, 0xc0, 0x25, 0x23, 0x57, 0xc4, 0x9f, 0x07, 0xbd, 0x47, 0x46, 0xde, 0x86,
+	0x9a, 0xdc, 0x4d, 0x8a, 0xa4, 0x90, 0xd3, 0x60, 0x9e, 0x7b, 0xad, 0xf6, 0xa1, 0xb6, 0x47, 0x32,
+	0x52, 0x72, 0xfa, 0xcc, 0xc9, 0x66, 0x7f, 0x06, 0xb3, 0xa9, 0xb6, 0x53, 0x54, 0x6f, 0xf9, 0xcd,
+	0xe8, 0x78, 0xb5, 0x36, 0xd7, 0x7f, 0x79, 0x77, 0x53, 0xf9, 0xe3, 0xbb, 0x9b, 0xca, 0x9f, 0xdf,
+	0xdd, 0x54, 0x7e, 0xf8, 0xe2, 0xc4, 0x0e, 0xfa, 0xa3, 0xf6, 0x52, 0xc7, 0x3d, 0x5d, 0x1e, 0x5a,
+	0x9d, 0xfe, 0x59, 0x97, 0x78, 0xf2, 0xca, 0xf7, 0x3a, 0xcb, 0xf1, 0xdf, 0xbe, 0xb7, 0xcb, 0x4c,
+	0xdc, 0xda, 0xdf, 0x06, 0x00, 0x67, 0x35, 0x1a, 0x93, 0x10, 0x2f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -9068,6 +9077,11 @@ func (m *Shard) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.SizeBytes != 0 {
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.OutputPath) > 0 {
 		i -= len(m.OutputPath)
 		copy(dAtA[i:], m.OutputPath)
@@ -12808,6 +12822,9 @@ func (m *Shard) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPfs(uint64(l))
 	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -16780,6 +16797,25 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 			}
 			m.OutputPath = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+			}
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
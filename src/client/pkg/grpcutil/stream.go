@@ -170,6 +170,94 @@ func (s *streamingBytesWriter) Write(data []byte) (int, error) {
 	return bytesWritten, nil
 }
 
+// NewChunkedStreamingBytesWriter returns an io.WriteCloser for a
+// StreamingBytesServer that buffers writes into chunks of chunkSize bytes
+// before sending them. Chunks are sent synchronously, so a client that is slow
+// to receive applies backpressure through the stream's send window rather than
+// the data being buffered in memory. Close must be called to send the final
+// chunk.
+func NewChunkedStreamingBytesWriter(streamingBytesServer StreamingBytesServer, chunkSize int) io.WriteCloser {
+	if chunkSize <= 0 || chunkSize > MaxMsgPayloadSize {
+		chunkSize = MaxMsgPayloadSize
+	}
+	return &chunkedStreamingBytesWriter{
+		streamingBytesServer: streamingBytesServer,
+		buf:                  make([]byte, 0, chunkSize),
+	}
+}
+
+type chunkedStreamingBytesWriter struct {
+	streamingBytesServer StreamingBytesServer
+	buf                  []byte
+}
+
+func (s *chunkedStreamingBytesWriter) Write(data []byte) (int, error) {
+	var bytesWritten int
+	for len(s.buf)+len(data) >= cap(s.buf) {
+		i := cap(s.buf) - len(s.buf)
+		s.buf = append(s.buf, data[:i]...)
+		if err := s.send(); err != nil {
+			return bytesWritten, err
+		}
+		bytesWritten += i
+		data = data[i:]
+	}
+	s.buf = append(s.buf, data...)
+	bytesWritten += len(data)
+	return bytesWritten, nil
+}
+
+func (s *chunkedStreamingBytesWriter) send() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+	// Send blocks while the stream's send window is exhausted. The message
+	// must not be modified after it has been sent, so the next chunk gets a
+	// fresh buffer.
+	err := s.streamingBytesServer.Send(&types.BytesValue{Value: s.buf})
+	s.buf = make([]byte, 0, cap(s.buf))
+	return err
+}
+
+func (s *chunkedStreamingBytesWriter) Close() error {
+	return s.send()
+}
+
+// NewChunkedStreamingBytesReader returns an io.ReadCloser that reassembles the
+// chunks received from a StreamingBytesClient into a single stream. Unlike
+// NewStreamingBytesReader, chunks are read in place rather than being copied
+// into an intermediate buffer, so at most one chunk is held in memory at a
+// time.
+func NewChunkedStreamingBytesReader(streamingBytesClient StreamingBytesClient, cancel context.CancelFunc) io.ReadCloser {
+	return &chunkedStreamingBytesReader{streamingBytesClient: streamingBytesClient, cancel: cancel}
+}
+
+type chunkedStreamingBytesReader struct {
+	streamingBytesClient StreamingBytesClient
+	chunk                []byte
+	cancel               context.CancelFunc
+}
+
+func (s *chunkedStreamingBytesReader) Read(p []byte) (int, error) {
+	for len(s.chunk) == 0 {
+		value, err := s.streamingBytesClient.Recv()
+		if err != nil {
+			return 0, err
+		}
+		s.chunk = value.Value
+	}
+	n := copy(p, s.chunk)
+	s.chunk = s.chunk[n:]
+	return n, nil
+}
+
+func (s *chunkedStreamingBytesReader) Close() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
+
 // ReaderWrapper wraps a reader for the following reason: Go's io.CopyBuffer
 // has an annoying optimization wherein if the reader has the WriteTo function
 // defined, it doesn't actually use the given buffer.  As a result, we might
@@ -0,0 +1,75 @@
+package grpcutil
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+
+	units "github.com/docker/go-units"
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+// testStream is an in-memory stream with a bounded number of in flight
+// messages, which emulates a stream with a small send window.
+type testStream struct {
+	msgs      chan *types.BytesValue
+	maxChunk  int
+	numChunks int
+}
+
+func newTestStream(window int) *testStream {
+	return &testStream{msgs: make(chan *types.BytesValue, window)}
+}
+
+func (s *testStream) Send(bytesValue *types.BytesValue) error {
+	if len(bytesValue.Value) > s.maxChunk {
+		s.maxChunk = len(bytesValue.Value)
+	}
+	s.numChunks++
+	s.msgs <- bytesValue
+	return nil
+}
+
+func (s *testStream) Recv() (*types.BytesValue, error) {
+	bytesValue, ok := <-s.msgs
+	if !ok {
+		return nil, io.EOF
+	}
+	return bytesValue, nil
+}
+
+func TestChunkedStreamingBytes(t *testing.T) {
+	chunkSize := 64 * units.KiB
+	// Synthetic profile that is much larger than the chunk size and not a
+	// multiple of it.
+	profile := make([]byte, 50*units.MiB+123)
+	rand.New(rand.NewSource(0)).Read(profile)
+	s := newTestStream(1)
+	go func() {
+		defer close(s.msgs)
+		w := NewChunkedStreamingBytesWriter(s, chunkSize)
+		// Write in uneven pieces to exercise the buffering.
+		data := profile
+		for len(data) > 0 {
+			n := rand.Intn(3 * chunkSize)
+			if n > len(data) {
+				n = len(data)
+			}
+			_, err := w.Write(data[:n])
+			require.NoError(t, err)
+			data = data[n:]
+		}
+		require.NoError(t, w.Close())
+	}()
+	r := NewChunkedStreamingBytesReader(s, nil)
+	result, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.True(t, bytes.Equal(profile, result))
+	require.Equal(t, chunkSize, s.maxChunk)
+	require.Equal(t, (len(profile)+chunkSize-1)/chunkSize, s.numChunks)
+}
@@ -636,6 +636,18 @@ func (c *enterpriseBuilderClient) GetState(ctx context.Context, req *enterprise.
 func (c *enterpriseBuilderClient) GetActivationCode(ctx context.Context, req *enterprise.GetActivationCodeRequest, opts ...grpc.CallOption) (*enterprise.GetActivationCodeResponse, error) {
 	return nil, unsupportedError("GetActivationCode")
 }
+func (c *enterpriseBuilderClient) StageActivationCode(ctx context.Context, req *enterprise.StageActivationCodeRequest, opts ...grpc.CallOption) (*enterprise.StageActivationCodeResponse, error) {
+	return nil, unsupportedError("StageActivationCode")
+}
+func (c *enterpriseBuilderClient) ExportState(ctx context.Context, req *enterprise.ExportStateRequest, opts ...grpc.CallOption) (*enterprise.ExportStateResponse, error) {
+	return nil, unsupportedError("ExportState")
+}
+func (c *enterpriseBuilderClient) ImportState(ctx context.Context, req *enterprise.ImportStateRequest, opts ...grpc.CallOption) (*enterprise.ImportStateResponse, error) {
+	return nil, unsupportedError("ImportState")
+}
+func (c *enterpriseBuilderClient) DecodeActivationCode(ctx context.Context, req *enterprise.DecodeActivationCodeRequest, opts ...grpc.CallOption) (*enterprise.DecodeActivationCodeResponse, error) {
+	return nil, unsupportedError("DecodeActivationCode")
+}
 func (c *enterpriseBuilderClient) Deactivate(ctx context.Context, req *enterprise.DeactivateRequest, opts ...grpc.CallOption) (*enterprise.DeactivateResponse, error) {
 	return nil, unsupportedError("Deactivate")
 }
@@ -5,9 +5,11 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"path"
 	"runtime/debug"
 	"runtime/pprof"
+	"syscall"
 
 	adminclient "github.com/pachyderm/pachyderm/src/client/admin"
 	authclient "github.com/pachyderm/pachyderm/src/client/auth"
@@ -209,7 +211,9 @@ func doSidecarMode(config interface{}) (retErr error) {
 	}
 	if err := logGRPCServerSetup("Enterprise API", func() error {
 		enterpriseAPIServer, err := eprsserver.NewEnterpriseServer(
-			env, path.Join(env.EtcdPrefix, env.EnterpriseEtcdPrefix))
+			env, path.Join(env.EtcdPrefix, env.EnterpriseEtcdPrefix),
+			eprsserver.WithActivationCodeRateLimit(env.EnterpriseActivationCodeRateLimit, env.EnterpriseActivationCodeRateBurst),
+			eprsserver.WithReadOnly(env.EnterpriseReadOnly))
 		if err != nil {
 			return err
 		}
@@ -241,7 +245,12 @@ func doSidecarMode(config interface{}) (retErr error) {
 	if _, err := server.ListenTCP("", env.PeerPort); err != nil {
 		return err
 	}
-	return server.Wait()
+	errChan := make(chan error, 1)
+	go waitForShutdown(errChan, pfsAPIServer.Close)
+	go func() {
+		errChan <- server.Wait()
+	}()
+	return <-errChan
 }
 
 func doFullMode(config interface{}) (retErr error) {
@@ -327,6 +336,8 @@ func doFullMode(config interface{}) (retErr error) {
 		env.PostgresServicePort,
 	)
 
+	// closers stop the background work of the servers when pachd shuts down.
+	var closers []func()
 	if err := logGRPCServerSetup("External Pachd", func() error {
 		txnEnv := &txnenv.TransactionEnv{}
 		var pfsAPIServer pfs_server.APIServer
@@ -335,6 +346,7 @@ func doFullMode(config interface{}) (retErr error) {
 			if err != nil {
 				return err
 			}
+			closers = append(closers, pfsAPIServer.Close)
 			pfsclient.RegisterAPIServer(externalServer.Server, pfsAPIServer)
 			return nil
 		}); err != nil {
@@ -418,8 +430,17 @@ func doFullMode(config interface{}) (retErr error) {
 			return err
 		}
 		if err := logGRPCServerSetup("Enterprise API", func() error {
+			enterpriseOpts := []eprsserver.Option{
+				eprsserver.WithActivationCodeRateLimit(env.EnterpriseActivationCodeRateLimit, env.EnterpriseActivationCodeRateBurst),
+				eprsserver.WithReadOnly(env.EnterpriseReadOnly),
+			}
+			// Only the external enterprise server notifies the webhook, so
+			// that each license state change is posted once per pachd.
+			if !env.EnterpriseWebhookDisabled {
+				enterpriseOpts = append(enterpriseOpts, eprsserver.WithWebhook(env.EnterpriseWebhookURL))
+			}
 			enterpriseAPIServer, err := eprsserver.NewEnterpriseServer(
-				env, path.Join(env.EtcdPrefix, env.EnterpriseEtcdPrefix))
+				env, path.Join(env.EtcdPrefix, env.EnterpriseEtcdPrefix), enterpriseOpts...)
 			if err != nil {
 				return err
 			}
@@ -487,6 +508,7 @@ func doFullMode(config interface{}) (retErr error) {
 			if err != nil {
 				return err
 			}
+			closers = append(closers, pfsAPIServer.Close)
 			pfsclient.RegisterAPIServer(internalServer.Server, pfsAPIServer)
 			return nil
 		}); err != nil {
@@ -574,7 +596,9 @@ func doFullMode(config interface{}) (retErr error) {
 		}
 		if err := logGRPCServerSetup("Enterprise API", func() error {
 			enterpriseAPIServer, err := eprsserver.NewEnterpriseServer(
-				env, path.Join(env.EtcdPrefix, env.EnterpriseEtcdPrefix))
+				env, path.Join(env.EtcdPrefix, env.EnterpriseEtcdPrefix),
+				eprsserver.WithActivationCodeRateLimit(env.EnterpriseActivationCodeRateLimit, env.EnterpriseActivationCodeRateBurst),
+				eprsserver.WithReadOnly(env.EnterpriseReadOnly))
 			if err != nil {
 				return err
 			}
@@ -618,6 +642,7 @@ func doFullMode(config interface{}) (retErr error) {
 	// Any server error is considered critical and will cause Pachd to exit.
 	// The first server that errors will have its error message logged.
 	errChan := make(chan error, 1)
+	go waitForShutdown(errChan, closers...)
 	go waitForError("External Pachd GRPC Server", errChan, true, func() error {
 		return externalServer.Wait()
 	})
@@ -717,6 +742,20 @@ func logGRPCServerSetup(name string, f func() error) (retErr error) {
 	return f()
 }
 
+// waitForShutdown waits for a termination signal, then calls closers (e.g. to
+// drain the PFS compaction workers) and sends nil to errChan, so that pachd
+// exits cleanly.
+func waitForShutdown(errChan chan error, closers ...func()) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM, os.Interrupt)
+	sig := <-sigChan
+	log.Infof("received %v, shutting down", sig)
+	for _, closer := range closers {
+		closer()
+	}
+	errChan <- nil
+}
+
 func waitForError(name string, errChan chan error, required bool, f func() error) {
 	if err := f(); !errors.Is(err, http.ErrServerClosed) {
 		if !required {
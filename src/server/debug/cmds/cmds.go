@@ -1,7 +1,11 @@
 package cmds
 
 import (
+	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -18,13 +22,15 @@ func Cmds() []*cobra.Command {
 	var commands []*cobra.Command
 
 	var duration time.Duration
+	var samples int64
+	var format string
 	var pachd bool
 	var pipeline string
 	var worker string
 	profile := &cobra.Command{
 		Use:   "{{alias}} <profile> <file>",
 		Short: "Collect a set of pprof profiles.",
-		Long:  "Collect a set of pprof profiles.",
+		Long:  "Collect a set of pprof profiles. In addition to the runtime's profiles (e.g. cpu, goroutine and heap), the alloc_space and alloc_objects profiles collect the heap profile with allocations as the default sample type.",
 		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
 			client, err := client.NewOnUserMachine("debug-profile")
 			if err != nil {
@@ -35,9 +41,15 @@ func Cmds() []*cobra.Command {
 			if duration != 0 {
 				d = types.DurationProto(duration)
 			}
+			formatValue, ok := debug.Profile_Format_value[strings.ToUpper(format)]
+			if !ok {
+				return errors.Errorf("unrecognized profile format: %v", format)
+			}
 			p := &debug.Profile{
 				Name:     args[0],
 				Duration: d,
+				Format:   debug.Profile_Format(formatValue),
+				Samples:  samples,
 			}
 			filter, err := createFilter(pachd, pipeline, worker)
 			if err != nil {
@@ -48,7 +60,9 @@ func Cmds() []*cobra.Command {
 			})
 		}),
 	}
-	profile.Flags().DurationVarP(&duration, "duration", "d", time.Minute, "Duration to run a CPU profile for.")
+	profile.Flags().DurationVarP(&duration, "duration", "d", time.Minute, "Duration to run a CPU profile for (clamped to each node's max profile duration).")
+	profile.Flags().Int64Var(&samples, "samples", 0, "Number of samples to collect for a CPU profile, the profile is stopped once the samples are collected or the duration elapses (0 means profile for the full duration).")
+	profile.Flags().StringVarP(&format, "format", "f", "verbose", "Format to write non-CPU profiles in, one of: verbose, text, proto (readable by the pprof tool) or collapsed (readable by flame graph tools, and also applies to CPU profiles).")
 	profile.Flags().BoolVar(&pachd, "pachd", false, "Only collect the profile from pachd.")
 	profile.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Only collect the profile from the worker pods for the given pipeline.")
 	profile.Flags().StringVarP(&worker, "worker", "w", "", "Only collect the profile from the given worker pod.")
@@ -79,6 +93,7 @@ func Cmds() []*cobra.Command {
 	commands = append(commands, cmdutil.CreateAlias(binary, "debug binary"))
 
 	var limit int64
+	var include, exclude []string
 	dump := &cobra.Command{
 		Use:   "{{alias}} <file>",
 		Short: "Collect a standard set of debugging information.",
@@ -94,7 +109,7 @@ func Cmds() []*cobra.Command {
 				return err
 			}
 			return withFile(args[0], func(f *os.File) error {
-				return client.Dump(filter, limit, f)
+				return client.DumpSections(filter, limit, include, exclude, f)
 			})
 		}),
 	}
@@ -102,8 +117,184 @@ func Cmds() []*cobra.Command {
 	dump.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Only collect the dump from the worker pods for the given pipeline.")
 	dump.Flags().StringVarP(&worker, "worker", "w", "", "Only collect the dump from the given worker pod.")
 	dump.Flags().Int64VarP(&limit, "limit", "l", 0, "Limit sets the limit for the number of commits / jobs that are returned for each repo / pipeline in the dump.")
+	dump.Flags().StringSliceVar(&include, "include", nil, "Only collect these sections of the dump (e.g. goroutine,logs), all of the sections are collected by default.")
+	dump.Flags().StringSliceVar(&exclude, "exclude", nil, "Don't collect these sections of the dump. The heap profiles are usually the largest sections, so excluding heap makes for a much smaller and faster dump that can't be used to diagnose memory usage.")
 	commands = append(commands, cmdutil.CreateAlias(dump, "debug dump"))
 
+	var level string
+	logs := &cobra.Command{
+		Use:   "{{alias}} <file>",
+		Short: "Capture pachd's logs at a raised log level.",
+		Long:  "Capture pachd's logs at a raised log level. The log level is raised for the duration of the capture, then the prior log level is restored.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := client.NewOnUserMachine("debug-logs")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return withFile(args[0], func(f *os.File) error {
+				return client.CaptureLogs(level, duration, f)
+			})
+		}),
+	}
+	logs.Flags().StringVar(&level, "level", "debug", "Log level to raise pachd's logging to while the logs are captured.")
+	logs.Flags().DurationVarP(&duration, "duration", "d", time.Minute, "Duration to capture the logs for.")
+	commands = append(commands, cmdutil.CreateAlias(logs, "debug logs"))
+
+	tailLogs := &cobra.Command{
+		Short: "Follow pachd's logs.",
+		Long:  "Follow pachd's logs for the duration, and print each log line as it's logged. The log level is not changed, use 'debug logs' to capture logs at a raised log level.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := client.NewOnUserMachine("debug-tail-logs")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.TailLogs(duration, func(_ time.Time, line string) error {
+				fmt.Print(line)
+				return nil
+			})
+		}),
+	}
+	tailLogs.Flags().DurationVarP(&duration, "duration", "d", time.Minute, "Duration to follow the logs for.")
+	commands = append(commands, cmdutil.CreateAlias(tailLogs, "debug tail-logs"))
+
+	var contentionProfile string
+	var contentionLimit int64
+	contention := &cobra.Command{
+		Use:   "{{alias}} <file>",
+		Short: "Summarize the most contended call sites in pachd.",
+		Long:  "Summarize the most contended call sites in pachd. The block or mutex profile is enabled for the duration, then the call sites are ranked by their cumulative delay in that window.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := client.NewOnUserMachine("debug-contention")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return withFile(args[0], func(f *os.File) error {
+				return client.Contention(contentionProfile, duration, contentionLimit, f)
+			})
+		}),
+	}
+	contention.Flags().StringVar(&contentionProfile, "profile", "block", "Contention profile to summarize, either \"block\" or \"mutex\".")
+	contention.Flags().DurationVarP(&duration, "duration", "d", time.Minute, "Duration to enable the profile for.")
+	contention.Flags().Int64VarP(&contentionLimit, "limit", "l", 20, "Number of call sites in the summary.")
+	commands = append(commands, cmdutil.CreateAlias(contention, "debug contention"))
+
+	setProfileRate := &cobra.Command{
+		Use:   "{{alias}} <profile> <rate>",
+		Short: "Enable pachd's block or mutex profile until it's reset.",
+		Long:  "Enable pachd's block or mutex profile with the given sampling rate until it's reset, so that the profile accumulates and can be collected later with 'pachctl debug profile'. The rate is the block profile rate (one blocking event is sampled per rate nanoseconds spent blocked) or the mutex profile fraction (1/rate of the mutex contention events are sampled). A rate of zero disables the profile.",
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			rate, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid profile rate %q", args[1])
+			}
+			client, err := client.NewOnUserMachine("debug-set-profile-rate")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			prev, err := client.SetProfileRate(args[0], rate)
+			if err != nil {
+				return err
+			}
+			if rate > 0 {
+				fmt.Fprintf(os.Stderr, "WARNING: profiling adds overhead to pachd, reset the profile rate with 'pachctl debug reset-profile-rates' when you're done\n")
+			}
+			fmt.Printf("%v profile rate set to %v (was %v)\n", args[0], rate, prev)
+			return nil
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(setProfileRate, "debug set-profile-rate"))
+
+	resetProfileRates := &cobra.Command{
+		Short: "Disable pachd's block and mutex profiles.",
+		Long:  "Disable pachd's block and mutex profiles, undoing 'pachctl debug set-profile-rate'.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := client.NewOnUserMachine("debug-reset-profile-rates")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.ResetProfileRates()
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(resetProfileRates, "debug reset-profile-rates"))
+
+	goroutine := &cobra.Command{
+		Use:   "{{alias}} <id>",
+		Short: "Print the stack of a single pachd goroutine.",
+		Long:  "Print the stack of a single pachd goroutine, given its ID in a goroutine dump.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid goroutine ID %q", args[0])
+			}
+			client, err := client.NewOnUserMachine("debug-goroutine")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			stack, err := client.Goroutine(id)
+			if err != nil {
+				return err
+			}
+			fmt.Print(stack)
+			return nil
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(goroutine, "debug goroutine"))
+
+	var interval time.Duration
+	goroutineCount := &cobra.Command{
+		Short: "Sample the number of pachd goroutines over time.",
+		Long:  "Sample the number of pachd goroutines at an interval for the duration, and print the time and count of each sample, for catching goroutine leaks.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := client.NewOnUserMachine("debug-goroutine-count")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.GoroutineCount(interval, duration, func(t time.Time, count int64) error {
+				fmt.Printf("%v %v\n", t.Format(time.RFC3339Nano), count)
+				return nil
+			})
+		}),
+	}
+	goroutineCount.Flags().DurationVarP(&interval, "interval", "i", time.Second, "Interval to sample the goroutine count at.")
+	goroutineCount.Flags().DurationVarP(&duration, "duration", "d", time.Minute, "Duration to sample the goroutine count for.")
+	commands = append(commands, cmdutil.CreateAlias(goroutineCount, "debug goroutine-count"))
+
+	var reset bool
+	counters := &cobra.Command{
+		Use:   "{{alias}} [<name>...]",
+		Short: "Print the values of pachd's internal counters.",
+		Long:  "Print the values of pachd's internal counters with the given names, or all of the counters if no names are given. The counters can be reset, so that later calls print their counts since the reset (the counters exported to Prometheus are not reset).",
+		Run: cmdutil.Run(func(args []string) error {
+			client, err := client.NewOnUserMachine("debug-counters")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			counters, err := client.Counters(args, reset)
+			if err != nil {
+				return err
+			}
+			for _, counter := range counters {
+				var labels []string
+				for name, value := range counter.Labels {
+					labels = append(labels, fmt.Sprintf("%v=%q", name, value))
+				}
+				sort.Strings(labels)
+				fmt.Printf("%v{%v} %v\n", counter.Name, strings.Join(labels, ","), counter.Value)
+			}
+			return nil
+		}),
+	}
+	counters.Flags().BoolVar(&reset, "reset", false, "Reset the counters after printing them.")
+	commands = append(commands, cmdutil.CreateAlias(counters, "debug counters"))
+
 	debug := &cobra.Command{
 		Short: "Debug commands for analyzing a running cluster.",
 		Long:  "Debug commands for analyzing a running cluster.",
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/pprof/profile"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// writeCollapsed converts a gzipped pprof protobuf profile into the collapsed
+// stack format, which has a line for each distinct stack containing its
+// frames (from the root to the leaf) separated by semicolons, followed by a
+// space and the stack's count. The count is the profile's default sample
+// value (the last sample value if there's no default, like pprof), and the
+// stacks with a count of zero are omitted.
+func writeCollapsed(w io.Writer, r io.Reader) error {
+	p, err := profile.Parse(r)
+	if err != nil {
+		return err
+	}
+	if len(p.SampleType) == 0 {
+		return errors.Errorf("profile has no sample types")
+	}
+	valueIndex := len(p.SampleType) - 1
+	if p.DefaultSampleType != "" {
+		for i, sampleType := range p.SampleType {
+			if sampleType.Type == p.DefaultSampleType {
+				valueIndex = i
+			}
+		}
+	}
+	counts := make(map[string]int64)
+	for _, sample := range p.Sample {
+		count := sample.Value[valueIndex]
+		if count == 0 {
+			continue
+		}
+		// The first location is the leaf, so the locations (and the inlined
+		// functions in each location) are visited in reverse.
+		var frames []string
+		for i := len(sample.Location) - 1; i >= 0; i-- {
+			loc := sample.Location[i]
+			if len(loc.Line) == 0 {
+				frames = append(frames, fmt.Sprintf("0x%x", loc.Address))
+				continue
+			}
+			for j := len(loc.Line) - 1; j >= 0; j-- {
+				var name string
+				if loc.Line[j].Function != nil {
+					name = loc.Line[j].Function.Name
+				}
+				frames = append(frames, collapsedFrame(name))
+			}
+		}
+		if len(frames) == 0 {
+			continue
+		}
+		counts[strings.Join(frames, ";")] += count
+	}
+	stacks := make([]string, 0, len(counts))
+	for stack := range counts {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+	for _, stack := range stacks {
+		if _, err := fmt.Fprintf(w, "%s %d\n", stack, counts[stack]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setDefaultSampleType rewrites a gzipped pprof protobuf profile with
+// sampleType (one of the profile's sample types) as its default sample type.
+func setDefaultSampleType(w io.Writer, r io.Reader, sampleType string) error {
+	p, err := profile.Parse(r)
+	if err != nil {
+		return err
+	}
+	var found bool
+	for _, st := range p.SampleType {
+		if st.Type == sampleType {
+			found = true
+		}
+	}
+	if !found {
+		return errors.Errorf("profile has no sample type %q", sampleType)
+	}
+	p.DefaultSampleType = sampleType
+	return p.Write(w)
+}
+
+// collapsedFrame returns the frame for a function in a collapsed stack, which
+// can't contain the frame separator or whitespace.
+func collapsedFrame(name string) string {
+	if name == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ';', ' ', '\t', '\n':
+			return '_'
+		}
+		return r
+	}, name)
+}
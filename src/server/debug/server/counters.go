@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client/debug"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// counterBaselines are the values of the counters when they were last reset
+// by a Counters request, keyed by counterKey. The values returned by Counters
+// are relative to the baselines, since the counters themselves are exported
+// to Prometheus and must not decrease.
+type counterBaselines map[string]float64
+
+func (s *debugServer) Counters(ctx context.Context, request *debug.CountersRequest) (*debug.CountersResponse, error) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, name := range request.Names {
+		names[name] = true
+	}
+	s.countersMu.Lock()
+	defer s.countersMu.Unlock()
+	if s.counterBaselines == nil {
+		s.counterBaselines = make(counterBaselines)
+	}
+	resp := &debug.CountersResponse{}
+	for _, family := range families {
+		if family.GetType() != dto.MetricType_COUNTER {
+			continue
+		}
+		if len(names) > 0 && !names[family.GetName()] {
+			continue
+		}
+		for _, m := range family.Metric {
+			counter := &debug.Counter{
+				Name:   family.GetName(),
+				Labels: make(map[string]string),
+			}
+			for _, label := range m.Label {
+				counter.Labels[label.GetName()] = label.GetValue()
+			}
+			key := counterKey(counter)
+			value := m.GetCounter().GetValue()
+			counter.Value = value - s.counterBaselines[key]
+			if request.Reset_ {
+				s.counterBaselines[key] = value
+			}
+			resp.Counters = append(resp.Counters, counter)
+		}
+	}
+	return resp, nil
+}
+
+// counterKey identifies a counter by its name and labels.
+func counterKey(counter *debug.Counter) string {
+	var labels []string
+	for name, value := range counter.Labels {
+		labels = append(labels, name+"="+value)
+	}
+	sort.Strings(labels)
+	return counter.Name + "{" + strings.Join(labels, ",") + "}"
+}
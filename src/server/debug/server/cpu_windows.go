@@ -0,0 +1,14 @@
+// +build windows
+
+package server
+
+import (
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// processCPUTime returns the user and system CPU time used by the process.
+func processCPUTime() (time.Duration, error) {
+	return 0, errors.Errorf("process CPU time is not supported on windows")
+}
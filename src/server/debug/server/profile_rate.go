@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/pachyderm/pachyderm/src/client/debug"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// blockProfileRate is the block profile rate set by SetProfileRate, which
+// contention captures restore when they finish (the rate can't be read from
+// the runtime). It's guarded by contentionMu.
+var blockProfileRate int
+
+func (s *debugServer) SetProfileRate(ctx context.Context, request *debug.SetProfileRateRequest) (*debug.SetProfileRateResponse, error) {
+	if request.Rate < 0 {
+		return nil, errors.Errorf("invalid profile rate %v, must be non-negative", request.Rate)
+	}
+	profile := request.Profile
+	if profile == "" {
+		profile = "block"
+	}
+	contentionMu.Lock()
+	defer contentionMu.Unlock()
+	prev, err := setProfileRate(profile, int(request.Rate))
+	if err != nil {
+		return nil, err
+	}
+	if request.Rate > 0 {
+		logrus.Warnf("%v profile enabled with rate %v until it's reset, sampling %v events adds overhead to them", profile, request.Rate, profile)
+	}
+	return &debug.SetProfileRateResponse{PreviousRate: int64(prev)}, nil
+}
+
+func (s *debugServer) ResetProfileRates(ctx context.Context, request *debug.ResetProfileRatesRequest) (*debug.ResetProfileRatesResponse, error) {
+	contentionMu.Lock()
+	defer contentionMu.Unlock()
+	for _, profile := range []string{"block", "mutex"} {
+		if _, err := setProfileRate(profile, 0); err != nil {
+			return nil, err
+		}
+	}
+	return &debug.ResetProfileRatesResponse{}, nil
+}
+
+// setProfileRate sets the rate of the block or mutex profile, and returns its
+// previous rate. contentionMu must be held.
+func setProfileRate(profile string, rate int) (int, error) {
+	switch profile {
+	case "block":
+		prev := blockProfileRate
+		blockProfileRate = rate
+		runtime.SetBlockProfileRate(rate)
+		return prev, nil
+	case "mutex":
+		return runtime.SetMutexProfileFraction(rate), nil
+	default:
+		return 0, errors.Errorf("unknown contention profile %q, must be \"block\" or \"mutex\"", profile)
+	}
+}
@@ -2,15 +2,27 @@ package server
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"math"
 	"os"
+	"path"
+	"runtime"
+	rdebug "runtime/debug"
 	"runtime/pprof"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"text/tabwriter"
 	"time"
 
+	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/debug"
@@ -18,10 +30,15 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/client/version"
 	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
+	"github.com/pachyderm/pachyderm/src/server/pkg/work"
 	workerserver "github.com/pachyderm/pachyderm/src/server/worker/server"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -34,6 +51,9 @@ const (
 	pachdPrefix     = "pachd"
 	pipelinePrefix  = "pipelines"
 	podPrefix       = "pods"
+	// profileMetadataSuffix is the suffix of the file that precedes each
+	// profile returned by Profile, which describes the profile.
+	profileMetadataSuffix = ".metadata"
 )
 
 type debugServer struct {
@@ -41,22 +61,48 @@ type debugServer struct {
 	name          string
 	sidecarClient *client.APIClient
 	marshaller    *jsonpb.Marshaler
+
+	// maxProfileDuration is the max duration of a CPU profile, longer
+	// requests are clamped to it (zero means unlimited).
+	maxProfileDuration time.Duration
+	// maxTailDuration is the max duration of a log tail, longer requests are
+	// clamped to it (zero means unlimited).
+	maxTailDuration time.Duration
+
+	countersMu       sync.Mutex
+	counterBaselines counterBaselines
+
+	// newWorkerClient connects to the debug server of the worker at address.
+	newWorkerClient func(address string) (debug.DebugClient, error)
 }
 
 // NewDebugServer creates a new server that serves the debug api over GRPC
 func NewDebugServer(env *serviceenv.ServiceEnv, name string, sidecarClient *client.APIClient) debug.DebugServer {
-	return &debugServer{
+	s := &debugServer{
 		env:           env,
 		name:          name,
 		sidecarClient: sidecarClient,
 		marshaller:    &jsonpb.Marshaler{Indent: "  "},
+		newWorkerClient: func(address string) (debug.DebugClient, error) {
+			c, err := workerserver.NewClient(address)
+			if err != nil {
+				return nil, err
+			}
+			return c.DebugClient, nil
+		},
+	}
+	if env != nil {
+		s.maxProfileDuration = time.Duration(env.DebugMaxProfileSeconds) * time.Second
+		s.maxTailDuration = time.Duration(env.DebugMaxTailSeconds) * time.Second
 	}
+	return s
 }
 
 type collectPipelineFunc func(*tar.Writer, *pps.PipelineInfo, ...string) error
 type collectWorkerFunc func(*tar.Writer, *v1.Pod, ...string) error
 type redirectFunc func(debug.DebugClient, *debug.Filter) (io.Reader, error)
 type collectFunc func(*tar.Writer, ...string) error
+type progressFunc func(tw *tar.Writer, done, total int) error
 
 func (s *debugServer) handleRedirect(
 	pachClient *client.APIClient,
@@ -67,60 +113,103 @@ func (s *debugServer) handleRedirect(
 	collectWorker collectWorkerFunc,
 	redirect redirectFunc,
 	collect collectFunc,
+	progress progressFunc,
 ) error {
-	return withDebugWriter(w, func(tw *tar.Writer) error {
-		// Handle filter.
-		pachdContainerPrefix := join(pachdPrefix, s.name, "pachd")
-		if filter != nil {
-			switch f := filter.Filter.(type) {
-			case *debug.Filter_Pachd:
-				return collectPachd(tw, pachdContainerPrefix)
-			case *debug.Filter_Pipeline:
-				pipelineInfo, err := pachClient.InspectPipeline(f.Pipeline.Name)
-				if err != nil {
-					return err
-				}
-				return s.handlePipelineRedirect(tw, pipelineInfo, collectPipeline, collectWorker, redirect)
-			case *debug.Filter_Worker:
-				if f.Worker.Redirected {
-					// Collect the storage container.
-					if s.sidecarClient == nil {
-						return collect(tw, client.PPSWorkerSidecarContainerName)
-					}
-					// Collect the user container.
-					if err := collect(tw, client.PPSWorkerUserContainerName); err != nil {
-						return err
-					}
-					// Redirect to the storage container.
-					r, err := redirect(s.sidecarClient.DebugClient, filter)
-					if err != nil {
-						return err
-					}
-					return collectDebugStream(tw, r)
+	// Worker errors don't abort the collection, they're returned after the
+	// debug information that was collected has been written.
+	var errs workerErrors
+	if err := withDebugWriter(w, func(tw *tar.Writer) error {
+		return errs.add(s.collectRedirect(tw, pachClient, filter, collectPachd, collectPipeline, collectWorker, redirect, collect, progress))
+	}); err != nil {
+		return err
+	}
+	return errs.err()
+}
 
+func (s *debugServer) collectRedirect(
+	tw *tar.Writer,
+	pachClient *client.APIClient,
+	filter *debug.Filter,
+	collectPachd collectFunc,
+	collectPipeline collectPipelineFunc,
+	collectWorker collectWorkerFunc,
+	redirect redirectFunc,
+	collect collectFunc,
+	progress progressFunc,
+) error {
+	// Handle filter.
+	pachdContainerPrefix := join(pachdPrefix, s.name, "pachd")
+	if filter != nil {
+		switch f := filter.Filter.(type) {
+		case *debug.Filter_Pachd:
+			return collectPachd(tw, pachdContainerPrefix)
+		case *debug.Filter_Pipeline:
+			pipelineInfo, err := pachClient.InspectPipeline(f.Pipeline.Name)
+			if err != nil {
+				return err
+			}
+			return s.handlePipelineRedirect(tw, pipelineInfo, collectPipeline, collectWorker, redirect)
+		case *debug.Filter_Worker:
+			if f.Worker.Redirected {
+				// Collect the storage container.
+				if s.sidecarClient == nil {
+					return collect(tw, client.PPSWorkerSidecarContainerName)
+				}
+				// Collect the user container.
+				if err := collect(tw, client.PPSWorkerUserContainerName); err != nil {
+					return err
 				}
-				pod, err := s.env.GetKubeClient().CoreV1().Pods(s.env.Namespace).Get(f.Worker.Pod, metav1.GetOptions{})
+				// Redirect to the storage container.
+				r, err := redirect(s.sidecarClient.DebugClient, filter)
 				if err != nil {
 					return err
 				}
-				return s.handleWorkerRedirect(tw, pod, collectWorker, redirect)
+				return collectDebugStream(tw, r)
+
 			}
+			pod, err := s.env.GetKubeClient().CoreV1().Pods(s.env.Namespace).Get(f.Worker.Pod, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			return s.handleWorkerRedirect(tw, pod, collectWorker, redirect)
 		}
-		// No filter, collect everything.
-		if err := collectPachd(tw, pachdContainerPrefix); err != nil {
-			return err
-		}
-		pipelineInfos, err := pachClient.ListPipeline()
-		if err != nil {
+	}
+	// No filter, collect everything.
+	pipelineInfos, err := pachClient.ListPipeline()
+	if err != nil {
+		return err
+	}
+	sections := []func() error{
+		func() error {
+			return collectPachd(tw, pachdContainerPrefix)
+		},
+	}
+	for _, pipelineInfo := range pipelineInfos {
+		pipelineInfo := pipelineInfo
+		sections = append(sections, func() error {
+			return s.handlePipelineRedirect(tw, pipelineInfo, collectPipeline, collectWorker, redirect)
+		})
+	}
+	return collectSections(tw, sections, progress)
+}
+
+// collectSections collects each section in order. If progress is set, it's
+// called after each section is collected. Worker errors don't stop the
+// collection of the remaining sections, they're aggregated and returned after
+// all of the sections have been collected.
+func collectSections(tw *tar.Writer, sections []func() error, progress progressFunc) error {
+	var errs workerErrors
+	for i, section := range sections {
+		if err := errs.add(section()); err != nil {
 			return err
 		}
-		for _, pipelineInfo := range pipelineInfos {
-			if err := s.handlePipelineRedirect(tw, pipelineInfo, collectPipeline, collectWorker, redirect); err != nil {
+		if progress != nil {
+			if err := progress(tw, i+1, len(sections)); err != nil {
 				return err
 			}
 		}
-		return nil
-	})
+	}
+	return errs.err()
 }
 
 func (s *debugServer) handlePipelineRedirect(
@@ -131,22 +220,22 @@ func (s *debugServer) handlePipelineRedirect(
 	redirect redirectFunc,
 ) (retErr error) {
 	prefix := join(pipelinePrefix, pipelineInfo.Pipeline.Name)
+	// The worker errors have already been written to the workers' error
+	// files, so they're returned rather than written to the pipeline's.
+	var errs workerErrors
 	defer func() {
 		if retErr != nil {
 			retErr = writeErrorFile(tw, retErr, prefix)
 		}
+		if retErr == nil {
+			retErr = errs.err()
+		}
 	}()
 	if collectPipeline != nil {
 		if err := collectPipeline(tw, pipelineInfo, prefix); err != nil {
 			return err
 		}
 	}
-	return s.forEachWorker(tw, pipelineInfo, func(pod *v1.Pod) error {
-		return s.handleWorkerRedirect(tw, pod, collectWorker, redirect, prefix)
-	})
-}
-
-func (s *debugServer) forEachWorker(tw *tar.Writer, pipelineInfo *pps.PipelineInfo, cb func(*v1.Pod) error) error {
 	pods, err := s.getWorkerPods(pipelineInfo)
 	if err != nil {
 		return err
@@ -154,12 +243,23 @@ func (s *debugServer) forEachWorker(tw *tar.Writer, pipelineInfo *pps.PipelineIn
 	if len(pods) == 0 {
 		return errors.Errorf("no worker pods found for pipeline %v", pipelineInfo.Pipeline.Name)
 	}
+	return errs.add(forEachWorker(pods, func(pod *v1.Pod) error {
+		return s.handleWorkerRedirect(tw, pod, collectWorker, redirect, prefix)
+	}))
+}
+
+// forEachWorker calls cb for each worker pod. Worker errors don't stop the
+// remaining workers from being visited, they're aggregated and returned after
+// all of the workers have been visited.
+func forEachWorker(pods []v1.Pod, cb func(*v1.Pod) error) error {
+	var errs workerErrors
 	for _, pod := range pods {
-		if err := cb(&pod); err != nil {
+		pod := pod
+		if err := errs.add(cb(&pod)); err != nil {
 			return err
 		}
 	}
-	return nil
+	return errs.err()
 }
 
 func (s *debugServer) getWorkerPods(pipelineInfo *pps.PipelineInfo) ([]v1.Pod, error) {
@@ -191,7 +291,11 @@ func (s *debugServer) handleWorkerRedirect(tw *tar.Writer, pod *v1.Pod, collectW
 	}
 	defer func() {
 		if retErr != nil {
-			retErr = writeErrorFile(tw, retErr, workerPrefix)
+			if err := writeErrorFile(tw, retErr, workerPrefix); err != nil {
+				retErr = err
+				return
+			}
+			retErr = &workerError{pod: pod.Name, err: retErr}
 		}
 	}()
 	if collectWorker != nil {
@@ -202,11 +306,11 @@ func (s *debugServer) handleWorkerRedirect(tw *tar.Writer, pod *v1.Pod, collectW
 	if pod.Status.Phase != v1.PodRunning {
 		return errors.Errorf("pod in phase %v, must be in phase %v to collect debug information", pod.Status.Phase, v1.PodRunning)
 	}
-	c, err := workerserver.NewClient(pod.Status.PodIP)
+	c, err := s.newWorkerClient(pod.Status.PodIP)
 	if err != nil {
 		return err
 	}
-	r, err := cb(c.DebugClient, &debug.Filter{
+	r, err := cb(c, &debug.Filter{
 		Filter: &debug.Filter_Worker{
 			Worker: &debug.Worker{
 				Pod:        pod.Name,
@@ -220,26 +324,79 @@ func (s *debugServer) handleWorkerRedirect(tw *tar.Writer, pod *v1.Pod, collectW
 	return collectDebugStream(tw, r, workerPrefix)
 }
 
-func (s *debugServer) Profile(request *debug.ProfileRequest, server debug.Debug_ProfileServer) error {
+func (s *debugServer) Profile(request *debug.ProfileRequest, server debug.Debug_ProfileServer) (retErr error) {
 	pachClient := s.env.GetPachClient(server.Context())
+	w := grpcutil.NewChunkedStreamingBytesWriter(server, s.env.DebugChunkSize)
+	defer func() {
+		if err := w.Close(); retErr == nil {
+			retErr = err
+		}
+	}()
 	return s.handleRedirect(
 		pachClient,
-		grpcutil.NewStreamingBytesWriter(server),
+		w,
 		request.Filter,
-		collectProfileFunc(request.Profile),
+		s.collectProfileFunc(request.Profile),
 		nil,
 		nil,
 		redirectProfileFunc(pachClient.Ctx(), request.Profile),
-		collectProfileFunc(request.Profile),
+		s.collectProfileFunc(request.Profile),
+		nil,
 	)
 }
 
-func collectProfileFunc(profile *debug.Profile) collectFunc {
+func (s *debugServer) collectProfileFunc(profile *debug.Profile) collectFunc {
 	return func(tw *tar.Writer, prefix ...string) error {
+		profile, clamped, err := s.clampProfile(profile)
+		if err != nil {
+			return err
+		}
+		collected, err := types.TimestampProto(time.Now())
+		if err != nil {
+			return err
+		}
+		if err := collectDebugFile(tw, profile.Name+profileMetadataSuffix, func(w io.Writer) error {
+			return s.marshaller.Marshal(w, &debug.ProfileMetadata{
+				Name:            profile.Name,
+				Format:          profile.Format,
+				Collected:       collected,
+				Node:            s.name,
+				Duration:        profile.Duration,
+				DurationClamped: clamped,
+			})
+		}, prefix...); err != nil {
+			return err
+		}
 		return collectProfile(tw, profile, prefix...)
 	}
 }
 
+// clampProfile returns a copy of a CPU profile with its duration set
+// explicitly, and clamped to the max profile duration. It also returns
+// whether the duration was clamped. Other profiles are returned unchanged.
+func (s *debugServer) clampProfile(profile *debug.Profile) (*debug.Profile, bool, error) {
+	if profile.Name != "cpu" {
+		return profile, false, nil
+	}
+	duration := defaultDuration
+	if profile.Duration != nil {
+		var err error
+		duration, err = types.DurationFromProto(profile.Duration)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	var clamped bool
+	if s.maxProfileDuration > 0 && duration > s.maxProfileDuration {
+		logrus.Warnf("clamping the requested %v cpu profile duration to the max of %v", duration, s.maxProfileDuration)
+		duration = s.maxProfileDuration
+		clamped = true
+	}
+	profile = proto.Clone(profile).(*debug.Profile)
+	profile.Duration = types.DurationProto(duration)
+	return profile, clamped, nil
+}
+
 func collectProfile(tw *tar.Writer, profile *debug.Profile, prefix ...string) error {
 	return collectDebugFile(tw, profile.Name, func(w io.Writer) error {
 		return writeProfile(w, profile)
@@ -247,10 +404,17 @@ func collectProfile(tw *tar.Writer, profile *debug.Profile, prefix ...string) er
 }
 
 func writeProfile(w io.Writer, profile *debug.Profile) error {
-	if profile.Name == "cpu" {
-		if err := pprof.StartCPUProfile(w); err != nil {
+	// Collapsed profiles are converted from the protobuf format.
+	if profile.Format == debug.Profile_COLLAPSED {
+		profile = proto.Clone(profile).(*debug.Profile)
+		profile.Format = debug.Profile_PROTO
+		buf := &bytes.Buffer{}
+		if err := writeProfile(buf, profile); err != nil {
 			return err
 		}
+		return writeCollapsed(w, buf)
+	}
+	if profile.Name == "cpu" {
 		duration := defaultDuration
 		if profile.Duration != nil {
 			var err error
@@ -259,15 +423,102 @@ func writeProfile(w io.Writer, profile *debug.Profile) error {
 				return err
 			}
 		}
+		if err := pprof.StartCPUProfile(w); err != nil {
+			return err
+		}
+		defer pprof.StopCPUProfile()
+		if profile.Samples > 0 {
+			return waitForCPUSamples(profile.Samples, duration)
+		}
 		time.Sleep(duration)
-		pprof.StopCPUProfile()
 		return nil
 	}
+	if allocProfiles[profile.Name] {
+		return writeAllocProfile(w, profile)
+	}
 	p := pprof.Lookup(profile.Name)
 	if p == nil {
 		return errors.Errorf("unable to find profile %q", profile.Name)
 	}
-	return p.WriteTo(w, 2)
+	debugLevel, err := profileDebugLevel(profile.Format)
+	if err != nil {
+		return err
+	}
+	return p.WriteTo(w, debugLevel)
+}
+
+// allocProfiles are the allocation views of the heap profile, which are named
+// after the heap profile's sample types.
+var allocProfiles = map[string]bool{
+	"alloc_space":   true,
+	"alloc_objects": true,
+}
+
+// writeAllocProfile writes the heap profile with an allocation sample type
+// (the profile's name) as its default sample type, so that pprof shows the
+// allocations rather than the in use memory. The text formats contain all of
+// the sample types, so they're written as is.
+func writeAllocProfile(w io.Writer, profile *debug.Profile) error {
+	p := pprof.Lookup("heap")
+	if profile.Format != debug.Profile_PROTO {
+		debugLevel, err := profileDebugLevel(profile.Format)
+		if err != nil {
+			return err
+		}
+		return p.WriteTo(w, debugLevel)
+	}
+	buf := &bytes.Buffer{}
+	if err := p.WriteTo(buf, 0); err != nil {
+		return err
+	}
+	return setDefaultSampleType(w, buf, profile.Name)
+}
+
+// cpuProfileRate is the rate (in hertz) that the CPU profiler samples at.
+const cpuProfileRate = 100
+
+// waitForCPUSamples waits until the CPU profiler has collected (about) the
+// number of samples, or the max duration elapses. The profiler takes a sample
+// for each 1/cpuProfileRate seconds of CPU time used by the process, so the
+// number of samples is estimated from the CPU time of the process.
+func waitForCPUSamples(samples int64, maxDuration time.Duration) error {
+	start, err := processCPUTime()
+	if err != nil {
+		return err
+	}
+	target := time.Duration(samples) * time.Second / cpuProfileRate
+	timer := time.NewTimer(maxDuration)
+	defer timer.Stop()
+	ticker := time.NewTicker(time.Second / cpuProfileRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case <-ticker.C:
+			cpuTime, err := processCPUTime()
+			if err != nil {
+				return err
+			}
+			if cpuTime-start >= target {
+				return nil
+			}
+		}
+	}
+}
+
+// profileDebugLevel returns the pprof debug level for a profile format.
+func profileDebugLevel(format debug.Profile_Format) (int, error) {
+	switch format {
+	case debug.Profile_VERBOSE:
+		return 2, nil
+	case debug.Profile_TEXT:
+		return 1, nil
+	case debug.Profile_PROTO:
+		return 0, nil
+	default:
+		return 0, errors.Errorf("unrecognized profile format: %v", format)
+	}
 }
 
 func redirectProfileFunc(ctx context.Context, profile *debug.Profile) redirectFunc {
@@ -279,7 +530,7 @@ func redirectProfileFunc(ctx context.Context, profile *debug.Profile) redirectFu
 		if err != nil {
 			return nil, err
 		}
-		return grpcutil.NewStreamingBytesReader(profileC, nil), nil
+		return grpcutil.NewChunkedStreamingBytesReader(profileC, nil), nil
 	}
 }
 
@@ -294,6 +545,7 @@ func (s *debugServer) Binary(request *debug.BinaryRequest, server debug.Debug_Bi
 		nil,
 		redirectBinaryFunc(pachClient.Ctx()),
 		collectBinary,
+		nil,
 	)
 }
 
@@ -327,35 +579,123 @@ func (s *debugServer) Dump(request *debug.DumpRequest, server debug.Debug_DumpSe
 	if request.Limit == 0 {
 		request.Limit = math.MaxInt64
 	}
+	sections, err := newDumpSections(request.Include, request.Exclude)
+	if err != nil {
+		return err
+	}
 	pachClient := s.env.GetPachClient(server.Context())
 	return s.handleRedirect(
 		pachClient,
 		grpcutil.NewStreamingBytesWriter(server),
 		request.Filter,
-		s.collectPachdDumpFunc(pachClient, request.Limit),
-		s.collectPipelineDumpFunc(pachClient, request.Limit),
-		s.collectWorkerDump,
-		redirectDumpFunc(pachClient.Ctx()),
-		collectDump,
+		s.collectPachdDumpFunc(pachClient, request.Limit, sections),
+		s.collectPipelineDumpFunc(pachClient, request.Limit, sections),
+		s.collectWorkerDumpFunc(sections),
+		redirectDumpFunc(pachClient.Ctx(), request.Include, request.Exclude),
+		collectDumpFunc(sections),
+		writeDumpProgress,
 	)
 }
 
-func (s *debugServer) collectPachdDumpFunc(pachClient *client.APIClient, limit int64) collectFunc {
+// dumpSectionNames are the names of the sections of a dump, which can be
+// included in or excluded from a dump.
+var dumpSectionNames = []string{
+	"build-info",
+	"commits",
+	"etcd-keys",
+	"goroutine",
+	"heap",
+	"input-repos",
+	"jobs",
+	"logs",
+	"process",
+	"spec",
+	"version",
+	"work-tasks",
+	"workers",
+}
+
+// dumpSections is the set of sections that are collected in a dump.
+type dumpSections map[string]bool
+
+// newDumpSections returns the sections in include (all of the sections if
+// include is empty), without the sections in exclude.
+func newDumpSections(include, exclude []string) (dumpSections, error) {
+	known := make(map[string]bool)
+	for _, name := range dumpSectionNames {
+		known[name] = true
+	}
+	for _, names := range [][]string{include, exclude} {
+		for _, name := range names {
+			if !known[name] {
+				return nil, errors.Errorf("unknown dump section %q, the dump sections are: %v", name, strings.Join(dumpSectionNames, ", "))
+			}
+		}
+	}
+	sections := make(dumpSections)
+	if len(include) == 0 {
+		include = dumpSectionNames
+	}
+	for _, name := range include {
+		sections[name] = true
+	}
+	for _, name := range exclude {
+		delete(sections, name)
+	}
+	return sections, nil
+}
+
+// writeDumpProgress writes a "progress" marker file to a dump after each of
+// its sections (pachd and each pipeline) is collected, so clients can report
+// the dump's progress. The markers share a name, so the last marker is the
+// one that remains when the dump is extracted.
+func writeDumpProgress(tw *tar.Writer, done, total int) error {
+	return collectDebugFile(tw, "progress", func(w io.Writer) error {
+		_, err := fmt.Fprintf(w, "dumped %v of %v sections\n", done, total)
+		return err
+	})
+}
+
+func (s *debugServer) collectPachdDumpFunc(pachClient *client.APIClient, limit int64, sections dumpSections) collectFunc {
 	return func(tw *tar.Writer, prefix ...string) error {
 		// Collect input repos.
-		if err := s.collectInputRepos(tw, pachClient, limit); err != nil {
-			return err
+		if sections["input-repos"] {
+			if err := s.collectInputRepos(tw, pachClient, limit); err != nil {
+				return err
+			}
 		}
 		// Collect the pachd version.
-		if err := s.collectPachdVersion(tw, pachClient, prefix...); err != nil {
-			return err
+		if sections["version"] {
+			if err := s.collectPachdVersion(tw, pachClient, prefix...); err != nil {
+				return err
+			}
 		}
 		// Collect the pachd container logs.
-		if err := s.collectLogs(tw, s.name, "pachd", prefix...); err != nil {
-			return err
+		if sections["logs"] {
+			if err := s.collectLogs(tw, s.name, "pachd", prefix...); err != nil {
+				return err
+			}
+		}
+		// Collect the etcd key counts.
+		if sections["etcd-keys"] {
+			if err := s.collectEtcdKeyCounts(pachClient.Ctx(), tw, prefix...); err != nil {
+				return err
+			}
+		}
+		// Collect the pipeline workers.
+		if sections["workers"] {
+			if err := s.collectWorkers(tw, pachClient, prefix...); err != nil {
+				return err
+			}
+		}
+		// Collect the outstanding work tasks.
+		if sections["work-tasks"] {
+			if err := s.collectWorkTasks(pachClient.Ctx(), tw, prefix...); err != nil {
+				return err
+			}
 		}
 		// Collect the pachd container dump.
-		return collectDump(tw, prefix...)
+		return collectDumpFunc(sections)(tw, prefix...)
 	}
 }
 
@@ -408,31 +748,232 @@ func (s *debugServer) collectLogs(tw *tar.Writer, pod, container string, prefix
 	}, prefix...)
 }
 
-func collectDump(tw *tar.Writer, prefix ...string) error {
-	if err := collectProfile(tw, &debug.Profile{Name: "goroutine"}, prefix...); err != nil {
+// collectEtcdKeyCounts collects the number of etcd keys under the prefix of
+// each pachd service, to help diagnose etcd bloat.
+func (s *debugServer) collectEtcdKeyCounts(ctx context.Context, tw *tar.Writer, prefix ...string) error {
+	pfsPrefix := path.Join(s.env.EtcdPrefix, s.env.PFSEtcdPrefix)
+	keyPrefixes := []string{
+		pfsPrefix,
+		// The storage task queue is stored under the pfs prefix.
+		path.Join(pfsPrefix, "task"),
+		path.Join(pfsPrefix, "subtask"),
+		path.Join(pfsPrefix, "claim"),
+		path.Join(s.env.EtcdPrefix, s.env.PPSEtcdPrefix),
+		path.Join(s.env.EtcdPrefix, s.env.AuthEtcdPrefix),
+		path.Join(s.env.EtcdPrefix, s.env.IdentityEtcdPrefix),
+		path.Join(s.env.EtcdPrefix, s.env.EnterpriseEtcdPrefix),
+	}
+	return collectEtcdKeyCounts(ctx, tw, s.env.GetEtcdClient(), keyPrefixes, prefix...)
+}
+
+func collectEtcdKeyCounts(ctx context.Context, tw *tar.Writer, etcdClient *etcd.Client, keyPrefixes []string, prefix ...string) error {
+	return collectDebugFile(tw, "etcd-keys", func(w io.Writer) error {
+		for _, keyPrefix := range keyPrefixes {
+			// Only the count is requested, so the keys and values are not transferred.
+			resp, err := etcdClient.Get(ctx, keyPrefix, etcd.WithPrefix(), etcd.WithCountOnly())
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "%v: %d\n", keyPrefix, resp.Count); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, prefix...)
+}
+
+// workerDiscoveryFunc returns the addresses of the workers for a pipeline RC.
+type workerDiscoveryFunc func(ctx context.Context, pipelineRcName string) ([]string, error)
+
+// collectWorkers collects the addresses of the running workers for each
+// pipeline, to give an overview of the cluster topology.
+func (s *debugServer) collectWorkers(tw *tar.Writer, pachClient *client.APIClient, prefix ...string) error {
+	pipelineInfos, err := pachClient.ListPipeline()
+	if err != nil {
 		return err
 	}
-	return collectProfile(tw, &debug.Profile{Name: "heap"}, prefix...)
+	etcdPrefix := path.Join(s.env.EtcdPrefix, s.env.PPSEtcdPrefix)
+	discover := func(ctx context.Context, pipelineRcName string) ([]string, error) {
+		return workerserver.Addresses(ctx, pipelineRcName, s.env.GetEtcdClient(), etcdPrefix)
+	}
+	return collectWorkers(pachClient.Ctx(), tw, pipelineInfos, discover, prefix...)
 }
 
-func (s *debugServer) collectPipelineDumpFunc(pachClient *client.APIClient, limit int64) collectPipelineFunc {
-	return func(tw *tar.Writer, pipelineInfo *pps.PipelineInfo, prefix ...string) error {
-		if err := collectDebugFile(tw, "spec", func(w io.Writer) error {
-			fullPipelineInfo, err := pachClient.InspectPipeline(pipelineInfo.Pipeline.Name)
+func collectWorkers(ctx context.Context, tw *tar.Writer, pipelineInfos []*pps.PipelineInfo, discover workerDiscoveryFunc, prefix ...string) error {
+	return collectDebugFile(tw, "workers", func(w io.Writer) error {
+		for _, pipelineInfo := range pipelineInfos {
+			addresses, err := discover(ctx, ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version))
 			if err != nil {
 				return err
 			}
-			return s.marshaller.Marshal(w, fullPipelineInfo)
-		}, prefix...); err != nil {
+			if _, err := fmt.Fprintf(w, "%v: %d [%v]\n", pipelineInfo.Pipeline.Name, len(addresses), strings.Join(addresses, ", ")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, prefix...)
+}
+
+// collectWorkTasks collects the outstanding work tasks (such as the storage
+// compaction tasks) and their subtasks, with their ages, to help diagnose
+// stuck tasks.
+func (s *debugServer) collectWorkTasks(ctx context.Context, tw *tar.Writer, prefix ...string) error {
+	etcdPrefixes := []string{
+		// The storage task queue is stored under the pfs prefix.
+		path.Join(s.env.EtcdPrefix, s.env.PFSEtcdPrefix),
+		// The pipeline task queues are stored under the pps prefix.
+		path.Join(s.env.EtcdPrefix, s.env.PPSEtcdPrefix),
+	}
+	return collectWorkTasks(ctx, tw, s.env.GetEtcdClient(), etcdPrefixes, time.Now(), prefix...)
+}
+
+func collectWorkTasks(ctx context.Context, tw *tar.Writer, etcdClient *etcd.Client, etcdPrefixes []string, now time.Time, prefix ...string) error {
+	age := func(task *work.Task) string {
+		created, err := types.TimestampFromProto(task.Created)
+		if err != nil {
+			// Tasks created by older versions of pachd don't have a
+			// creation time.
+			return "unknown"
+		}
+		return now.Sub(created).Round(time.Second).String()
+	}
+	return collectDebugFile(tw, "work-tasks", func(w io.Writer) error {
+		for _, etcdPrefix := range etcdPrefixes {
+			if err := work.ListTasks(ctx, etcdClient, etcdPrefix, func(status *work.TaskStatus) error {
+				if _, err := fmt.Fprintf(w, "%v %v: age %v, %d subtasks\n", path.Join(etcdPrefix, status.Namespace), status.Task.ID, age(status.Task), len(status.Subtasks)); err != nil {
+					return err
+				}
+				for _, subtaskInfo := range status.Subtasks {
+					line := fmt.Sprintf("  %v: %v, age %v", subtaskInfo.Task.ID, subtaskInfo.State, age(subtaskInfo.Task))
+					if subtaskInfo.Reason != "" {
+						line += fmt.Sprintf(" (%v)", subtaskInfo.Reason)
+					}
+					if _, err := fmt.Fprintln(w, line); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, prefix...)
+}
+
+// collectDumpFunc returns a function that collects the profiles and process
+// stats in sections for a container.
+func collectDumpFunc(sections dumpSections) collectFunc {
+	return func(tw *tar.Writer, prefix ...string) error {
+		for _, name := range []string{"goroutine", "heap"} {
+			if !sections[name] {
+				continue
+			}
+			if err := collectProfile(tw, &debug.Profile{Name: name}, prefix...); err != nil {
+				return err
+			}
+		}
+		if sections["build-info"] {
+			if err := collectBuildInfo(tw, prefix...); err != nil {
+				return err
+			}
+		}
+		if !sections["process"] {
+			return nil
+		}
+		return collectProcessStats(tw, prefix...)
+	}
+}
+
+// collectBuildInfo collects the module versions that the binary was built
+// with, in the format of `go version -m`, so that a dump pins the exact
+// build. The toolchain doesn't record the VCS revision, so the binary's
+// pachyderm version (which includes the commit for custom releases) is
+// collected with it. This is a no-op for binaries built without module
+// support.
+func collectBuildInfo(tw *tar.Writer, prefix ...string) error {
+	info, ok := rdebug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	return collectDebugFile(tw, "build-info", func(w io.Writer) error {
+		if _, err := fmt.Fprintf(w, "version\t%v\n", version.PrettyVersion()); err != nil {
 			return err
 		}
-		if err := collectDebugFile(tw, "commits", func(w io.Writer) error {
-			return pachClient.ListCommitF(pipelineInfo.Pipeline.Name, "", "", uint64(limit), false, func(ci *pfs.CommitInfo) error {
-				return s.marshaller.Marshal(w, ci)
-			})
-		}, prefix...); err != nil {
+		return writeBuildInfo(w, info)
+	}, prefix...)
+}
+
+func writeBuildInfo(w io.Writer, info *rdebug.BuildInfo) error {
+	if _, err := fmt.Fprintf(w, "go\t%v\npath\t%v\n", runtime.Version(), info.Path); err != nil {
+		return err
+	}
+	writeModule := func(kind string, m *rdebug.Module) error {
+		if _, err := fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", kind, m.Path, m.Version, m.Sum); err != nil {
+			return err
+		}
+		if m.Replace == nil {
+			return nil
+		}
+		_, err := fmt.Fprintf(w, "\t=> %v\t%v\t%v\n", m.Replace.Path, m.Replace.Version, m.Replace.Sum)
+		return err
+	}
+	if err := writeModule("mod", &info.Main); err != nil {
+		return err
+	}
+	for _, dep := range info.Deps {
+		if err := writeModule("dep", dep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectProcessStats collects the open file descriptor and OS thread counts
+// for the process. This is a no-op on platforms without /proc.
+func collectProcessStats(tw *tar.Writer, prefix ...string) error {
+	if _, err := os.Stat("/proc/self"); err != nil {
+		return nil
+	}
+	return collectDebugFile(tw, "process", func(w io.Writer) error {
+		fds, err := ioutil.ReadDir("/proc/self/fd")
+		if err != nil {
+			return err
+		}
+		threads, err := ioutil.ReadDir("/proc/self/task")
+		if err != nil {
 			return err
 		}
+		_, err = fmt.Fprintf(w, "fds: %d\nthreads: %d\n", len(fds), len(threads))
+		return err
+	}, prefix...)
+}
+
+func (s *debugServer) collectPipelineDumpFunc(pachClient *client.APIClient, limit int64, sections dumpSections) collectPipelineFunc {
+	return func(tw *tar.Writer, pipelineInfo *pps.PipelineInfo, prefix ...string) error {
+		if sections["spec"] {
+			if err := collectDebugFile(tw, "spec", func(w io.Writer) error {
+				fullPipelineInfo, err := pachClient.InspectPipeline(pipelineInfo.Pipeline.Name)
+				if err != nil {
+					return err
+				}
+				return s.marshaller.Marshal(w, fullPipelineInfo)
+			}, prefix...); err != nil {
+				return err
+			}
+		}
+		if sections["commits"] {
+			if err := collectDebugFile(tw, "commits", func(w io.Writer) error {
+				return pachClient.ListCommitF(pipelineInfo.Pipeline.Name, "", "", uint64(limit), false, func(ci *pfs.CommitInfo) error {
+					return s.marshaller.Marshal(w, ci)
+				})
+			}, prefix...); err != nil {
+				return err
+			}
+		}
+		if !sections["jobs"] {
+			return nil
+		}
 		return collectDebugFile(tw, "jobs", func(w io.Writer) error {
 			// TODO: The limiting should eventually be a feature of list job.
 			var count int64
@@ -447,26 +988,549 @@ func (s *debugServer) collectPipelineDumpFunc(pachClient *client.APIClient, limi
 	}
 }
 
-func (s *debugServer) collectWorkerDump(tw *tar.Writer, pod *v1.Pod, prefix ...string) error {
-	// Collect the worker user and storage container logs.
-	userPrefix := client.PPSWorkerUserContainerName
-	sidecarPrefix := client.PPSWorkerSidecarContainerName
-	if len(prefix) > 0 {
-		userPrefix = join(prefix[0], userPrefix)
-		sidecarPrefix = join(prefix[0], sidecarPrefix)
-	}
-	if err := s.collectLogs(tw, pod.Name, client.PPSWorkerUserContainerName, userPrefix); err != nil {
-		return err
+func (s *debugServer) collectWorkerDumpFunc(sections dumpSections) collectWorkerFunc {
+	return func(tw *tar.Writer, pod *v1.Pod, prefix ...string) error {
+		if !sections["logs"] {
+			return nil
+		}
+		// Collect the worker user and storage container logs.
+		userPrefix := client.PPSWorkerUserContainerName
+		sidecarPrefix := client.PPSWorkerSidecarContainerName
+		if len(prefix) > 0 {
+			userPrefix = join(prefix[0], userPrefix)
+			sidecarPrefix = join(prefix[0], sidecarPrefix)
+		}
+		if err := s.collectLogs(tw, pod.Name, client.PPSWorkerUserContainerName, userPrefix); err != nil {
+			return err
+		}
+		return s.collectLogs(tw, pod.Name, client.PPSWorkerSidecarContainerName, sidecarPrefix)
 	}
-	return s.collectLogs(tw, pod.Name, client.PPSWorkerSidecarContainerName, sidecarPrefix)
 }
 
-func redirectDumpFunc(ctx context.Context) redirectFunc {
+func redirectDumpFunc(ctx context.Context, include, exclude []string) redirectFunc {
 	return func(c debug.DebugClient, filter *debug.Filter) (io.Reader, error) {
-		dumpC, err := c.Dump(ctx, &debug.DumpRequest{Filter: filter})
+		dumpC, err := c.Dump(ctx, &debug.DumpRequest{
+			Filter:  filter,
+			Include: include,
+			Exclude: exclude,
+		})
 		if err != nil {
 			return nil, err
 		}
 		return grpcutil.NewStreamingBytesReader(dumpC, nil), nil
 	}
 }
+
+// logLevelMu serializes log captures, so that a capture does not restore a log
+// level that was raised by another capture.
+var logLevelMu sync.Mutex
+
+func (s *debugServer) CaptureLogs(request *debug.CaptureLogsRequest, server debug.Debug_CaptureLogsServer) error {
+	level := logrus.DebugLevel
+	if request.Level != "" {
+		var err error
+		level, err = logrus.ParseLevel(request.Level)
+		if err != nil {
+			return err
+		}
+	}
+	duration := defaultDuration
+	if request.Duration != nil {
+		var err error
+		duration, err = types.DurationFromProto(request.Duration)
+		if err != nil {
+			return err
+		}
+	}
+	return withDebugWriter(grpcutil.NewStreamingBytesWriter(server), func(tw *tar.Writer) error {
+		return captureLogs(server.Context(), tw, logrus.StandardLogger(), level, duration, pachdPrefix)
+	})
+}
+
+// captureLogs raises the log level of logger to level for duration (or until
+// ctx is done), and writes the logs from that window to a "logs" file. The
+// prior log level is restored afterwards.
+func captureLogs(ctx context.Context, tw *tar.Writer, logger *logrus.Logger, level logrus.Level, duration time.Duration, prefix ...string) error {
+	logLevelMu.Lock()
+	defer logLevelMu.Unlock()
+	return collectDebugFile(tw, "logs", func(w io.Writer) error {
+		hook := &captureHook{w: w}
+		defer logFanOutFor(logger).subscribe(hook)()
+		prevLevel := logger.GetLevel()
+		if level > prevLevel {
+			logger.SetLevel(level)
+		}
+		defer logger.SetLevel(prevLevel)
+		select {
+		case <-time.After(duration):
+		case <-ctx.Done():
+		}
+		return hook.err()
+	}, prefix...)
+}
+
+// captureHook is a logrus hook that writes formatted log entries to w.
+type captureHook struct {
+	mu       sync.Mutex
+	w        io.Writer
+	writeErr error
+}
+
+func (h *captureHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *captureHook) Fire(entry *logrus.Entry) error {
+	line, err := entry.String()
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.writeErr != nil {
+		return nil
+	}
+	_, h.writeErr = io.WriteString(h.w, line)
+	return nil
+}
+
+func (h *captureHook) err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.writeErr
+}
+
+var (
+	logFanOutsMu sync.Mutex
+	logFanOuts   = make(map[*logrus.Logger]*logFanOut)
+)
+
+// logFanOutFor returns the fan-out hook of logger, adding it to logger the
+// first time it's requested. The hook stays installed for the life of the
+// process, so captures and tails never need to replace logger's hooks.
+func logFanOutFor(logger *logrus.Logger) *logFanOut {
+	logFanOutsMu.Lock()
+	defer logFanOutsMu.Unlock()
+	f, ok := logFanOuts[logger]
+	if !ok {
+		f = &logFanOut{subs: make(map[logrus.Hook]struct{})}
+		logger.AddHook(f)
+		logFanOuts[logger] = f
+	}
+	return f
+}
+
+// logFanOut is a logrus hook that passes each log entry on to its current
+// subscribers. A subscriber's error doesn't stop the entry from reaching the
+// others.
+type logFanOut struct {
+	mu   sync.RWMutex
+	subs map[logrus.Hook]struct{}
+}
+
+func (f *logFanOut) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (f *logFanOut) Fire(entry *logrus.Entry) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var firstErr error
+	for sub := range f.subs {
+		if err := sub.Fire(entry); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// subscribe passes the entries logged from now on to hook, until the returned
+// function is called.
+func (f *logFanOut) subscribe(hook logrus.Hook) func() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subs[hook] = struct{}{}
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subs, hook)
+	}
+}
+
+func (s *debugServer) TailLogs(request *debug.TailLogsRequest, server debug.Debug_TailLogsServer) error {
+	duration := defaultDuration
+	if request.Duration != nil {
+		var err error
+		duration, err = types.DurationFromProto(request.Duration)
+		if err != nil {
+			return err
+		}
+	}
+	if s.maxTailDuration > 0 && duration > s.maxTailDuration {
+		logrus.Warnf("clamping the requested %v log tail duration to the max of %v", duration, s.maxTailDuration)
+		duration = s.maxTailDuration
+	}
+	return tailLogs(server.Context(), logrus.StandardLogger(), duration, server.Send)
+}
+
+// maxTailLines is the number of log lines that are buffered while a tail is
+// sending earlier lines. Lines logged while the buffer is full are dropped
+// (rather than blocking the logger), and replaced with a line saying how many
+// were dropped.
+const maxTailLines = 10000
+
+// tailLogs calls cb with each line logged by logger, in order, until duration
+// has elapsed (or ctx is done).
+func tailLogs(ctx context.Context, logger *logrus.Logger, duration time.Duration, cb func(*debug.LogLine) error) error {
+	hook := &tailHook{ready: make(chan struct{}, 1)}
+	defer logFanOutFor(logger).subscribe(hook)()
+	deadline := time.NewTimer(duration)
+	defer deadline.Stop()
+	for {
+		select {
+		case <-hook.ready:
+		case <-deadline.C:
+			return hook.flush(cb)
+		case <-ctx.Done():
+			return nil
+		}
+		if err := hook.flush(cb); err != nil {
+			return err
+		}
+	}
+}
+
+// tailHook is a logrus hook that buffers formatted log lines until they're
+// flushed.
+type tailHook struct {
+	mu      sync.Mutex
+	lines   []*debug.LogLine
+	dropped int
+	ready   chan struct{}
+}
+
+func (h *tailHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *tailHook) Fire(entry *logrus.Entry) error {
+	line, err := entry.String()
+	if err != nil {
+		return err
+	}
+	t, err := types.TimestampProto(entry.Time)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.lines) >= maxTailLines {
+		h.dropped++
+		return nil
+	}
+	h.lines = append(h.lines, &debug.LogLine{Time: t, Line: line})
+	select {
+	case h.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// flush calls cb with each buffered line, in order, and empties the buffer.
+func (h *tailHook) flush(cb func(*debug.LogLine) error) error {
+	h.mu.Lock()
+	lines, dropped := h.lines, h.dropped
+	h.lines, h.dropped = nil, 0
+	h.mu.Unlock()
+	if dropped > 0 {
+		t, err := types.TimestampProto(time.Now())
+		if err != nil {
+			return err
+		}
+		lines = append(lines, &debug.LogLine{
+			Time: t,
+			Line: fmt.Sprintf("(%v log lines dropped)\n", dropped),
+		})
+	}
+	for _, line := range lines {
+		if err := cb(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultContentionLimit is the default number of call sites in a contention
+// summary.
+const defaultContentionLimit = 20
+
+// contentionMu serializes contention captures, so that a capture does not
+// disable the profile while another capture is using it.
+var contentionMu sync.Mutex
+
+func (s *debugServer) Contention(request *debug.ContentionRequest, server debug.Debug_ContentionServer) error {
+	duration := defaultDuration
+	if request.Duration != nil {
+		var err error
+		duration, err = types.DurationFromProto(request.Duration)
+		if err != nil {
+			return err
+		}
+	}
+	limit := defaultContentionLimit
+	if request.Limit > 0 {
+		limit = int(request.Limit)
+	}
+	return withDebugWriter(grpcutil.NewStreamingBytesWriter(server), func(tw *tar.Writer) error {
+		return collectContention(server.Context(), tw, request.Profile, duration, limit, pachdPrefix)
+	})
+}
+
+// collectContention enables the block or mutex profile for duration (or
+// until ctx is done), and writes a summary of the most contended call sites
+// in that window to a "contention" file. The call sites are ranked by their
+// cumulative delay.
+func collectContention(ctx context.Context, tw *tar.Writer, profile string, duration time.Duration, limit int, prefix ...string) error {
+	if profile == "" {
+		profile = "block"
+	}
+	// The lock is held from before the profile rates are changed until
+	// they're restored, so that SetProfileRate can't change them in between.
+	contentionMu.Lock()
+	defer contentionMu.Unlock()
+	var records func() []runtime.BlockProfileRecord
+	var enable, disable func()
+	switch profile {
+	case "block":
+		// The block profile rate can't be read, so it's restored to the
+		// rate set by SetProfileRate (which is zero by default).
+		records = blockProfileRecords
+		enable = func() { runtime.SetBlockProfileRate(1) }
+		disable = func() { runtime.SetBlockProfileRate(blockProfileRate) }
+	case "mutex":
+		records = mutexProfileRecords
+		var prevFraction int
+		enable = func() { prevFraction = runtime.SetMutexProfileFraction(1) }
+		disable = func() { runtime.SetMutexProfileFraction(prevFraction) }
+	default:
+		return errors.Errorf("unknown contention profile %q, must be \"block\" or \"mutex\"", profile)
+	}
+	return collectDebugFile(tw, "contention", func(w io.Writer) error {
+		cyclesPerSecond, err := profileCyclesPerSecond()
+		if err != nil {
+			return err
+		}
+		// The profiles are cumulative, so the records from before the window
+		// are subtracted.
+		before := records()
+		enable()
+		select {
+		case <-time.After(duration):
+		case <-ctx.Done():
+		}
+		disable()
+		sites := contentionSites(before, records(), cyclesPerSecond)
+		if len(sites) > limit {
+			sites = sites[:limit]
+		}
+		if _, err := fmt.Fprintf(w, "%v contention over %v, top %v call sites by delay:\n", profile, duration, len(sites)); err != nil {
+			return err
+		}
+		table := tabwriter.NewWriter(w, 0, 1, 2, ' ', 0)
+		if _, err := fmt.Fprintln(table, "DELAY\tCOUNT\tFUNCTION\tLOCATION"); err != nil {
+			return err
+		}
+		for _, site := range sites {
+			if _, err := fmt.Fprintf(table, "%v\t%v\t%v\t%v:%v\n", site.delay, site.count, site.function, site.file, site.line); err != nil {
+				return err
+			}
+		}
+		return table.Flush()
+	}, prefix...)
+}
+
+func blockProfileRecords() []runtime.BlockProfileRecord {
+	return profileRecords(runtime.BlockProfile)
+}
+
+func mutexProfileRecords() []runtime.BlockProfileRecord {
+	return profileRecords(runtime.MutexProfile)
+}
+
+func profileRecords(profile func([]runtime.BlockProfileRecord) (int, bool)) []runtime.BlockProfileRecord {
+	n, _ := profile(nil)
+	for {
+		// Leave room for records added since the last call.
+		records := make([]runtime.BlockProfileRecord, n+50)
+		var ok bool
+		n, ok = profile(records)
+		if ok {
+			return records[:n]
+		}
+	}
+}
+
+// profileCyclesPerSecond returns the rate of the clock that the block and
+// mutex profiles measure delays with, which is only exposed by the legacy
+// text format of the profiles.
+func profileCyclesPerSecond() (float64, error) {
+	buf := &bytes.Buffer{}
+	if err := pprof.Lookup("block").WriteTo(buf, 1); err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "cycles/second=") {
+			return strconv.ParseFloat(strings.TrimPrefix(line, "cycles/second="), 64)
+		}
+	}
+	return 0, errors.Errorf("could not find the cycles per second of the block profile")
+}
+
+// contentionSite is a call site that was delayed by contention.
+type contentionSite struct {
+	function string
+	file     string
+	line     int
+	count    int64
+	delay    time.Duration
+}
+
+// contentionSites aggregates the profile records added between before and
+// after by call site, ranked by delay. The call site of a record is the first
+// frame in its stack outside of the runtime and sync packages.
+func contentionSites(before, after []runtime.BlockProfileRecord, cyclesPerSecond float64) []*contentionSite {
+	type total struct {
+		count  int64
+		cycles int64
+	}
+	prev := make(map[[32]uintptr]total)
+	for _, r := range before {
+		prev[r.Stack0] = total{r.Count, r.Cycles}
+	}
+	sites := make(map[string]*contentionSite)
+	var result []*contentionSite
+	for _, r := range after {
+		count, cycles := r.Count-prev[r.Stack0].count, r.Cycles-prev[r.Stack0].cycles
+		if count <= 0 {
+			continue
+		}
+		frame := callSite(r.Stack())
+		key := fmt.Sprintf("%v %v:%v", frame.Function, frame.File, frame.Line)
+		site, ok := sites[key]
+		if !ok {
+			site = &contentionSite{
+				function: frame.Function,
+				file:     frame.File,
+				line:     frame.Line,
+			}
+			sites[key] = site
+			result = append(result, site)
+		}
+		site.count += count
+		site.delay += time.Duration(float64(cycles) / cyclesPerSecond * float64(time.Second))
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].delay > result[j].delay
+	})
+	return result
+}
+
+func callSite(stack []uintptr) runtime.Frame {
+	frames := runtime.CallersFrames(stack)
+	var first runtime.Frame
+	for {
+		frame, more := frames.Next()
+		if first.Function == "" {
+			first = frame
+		}
+		if !strings.HasPrefix(frame.Function, "runtime.") && !strings.HasPrefix(frame.Function, "sync.") {
+			return frame
+		}
+		if !more {
+			return first
+		}
+	}
+}
+
+func (s *debugServer) Goroutine(ctx context.Context, request *debug.GoroutineRequest) (*debug.GoroutineResponse, error) {
+	stack, ok, err := goroutineStack(request.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "goroutine %v not found", request.Id)
+	}
+	return &debug.GoroutineResponse{Stack: stack}, nil
+}
+
+// goroutineStack captures the full goroutine profile, and returns the stack
+// of the goroutine with the given ID, if it exists.
+func goroutineStack(id int64) (string, bool, error) {
+	buf := &bytes.Buffer{}
+	if err := pprof.Lookup("goroutine").WriteTo(buf, 2); err != nil {
+		return "", false, err
+	}
+	// The stacks in the profile are separated by blank lines, and each stack
+	// starts with a "goroutine <id> [<state>]:" line.
+	header := fmt.Sprintf("goroutine %v [", id)
+	for _, stack := range strings.Split(buf.String(), "\n\n") {
+		if strings.HasPrefix(stack, header) {
+			return strings.TrimSuffix(stack, "\n") + "\n", true, nil
+		}
+	}
+	return "", false, nil
+}
+
+const defaultGoroutineCountInterval = time.Second
+
+func (s *debugServer) GoroutineCount(request *debug.GoroutineCountRequest, server debug.Debug_GoroutineCountServer) error {
+	interval := defaultGoroutineCountInterval
+	if request.Interval != nil {
+		var err error
+		interval, err = types.DurationFromProto(request.Interval)
+		if err != nil {
+			return err
+		}
+	}
+	duration := defaultDuration
+	if request.Duration != nil {
+		var err error
+		duration, err = types.DurationFromProto(request.Duration)
+		if err != nil {
+			return err
+		}
+	}
+	if interval <= 0 {
+		return errors.Errorf("invalid goroutine count interval %v, must be positive", interval)
+	}
+	return sampleGoroutineCount(server.Context(), interval, duration, server.Send)
+}
+
+// sampleGoroutineCount samples the number of goroutines immediately, and then
+// every interval until duration has elapsed (or ctx is done), and calls cb
+// with each sample.
+func sampleGoroutineCount(ctx context.Context, interval, duration time.Duration, cb func(*debug.GoroutineCountSample) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(duration)
+	defer deadline.Stop()
+	for {
+		now, err := types.TimestampProto(time.Now())
+		if err != nil {
+			return err
+		}
+		if err := cb(&debug.GoroutineCountSample{
+			Time:  now,
+			Count: int64(runtime.NumGoroutine()),
+		}); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
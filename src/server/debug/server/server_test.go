@@ -0,0 +1,1018 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+	"github.com/google/pprof/profile"
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/debug"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testetcd"
+	"github.com/pachyderm/pachyderm/src/server/pkg/work"
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriteProfileFormat(t *testing.T) {
+	writeGoroutineProfile := func(format debug.Profile_Format) []byte {
+		buf := &bytes.Buffer{}
+		require.NoError(t, writeProfile(buf, &debug.Profile{
+			Name:   "goroutine",
+			Format: format,
+		}))
+		return buf.Bytes()
+	}
+	// The verbose format is a full stack dump of each goroutine.
+	verbose := string(writeGoroutineProfile(debug.Profile_VERBOSE))
+	require.True(t, strings.HasPrefix(verbose, "goroutine "), "unexpected verbose profile: %v", verbose)
+	require.True(t, strings.Contains(verbose, "[running]:"), "unexpected verbose profile: %v", verbose)
+	// The text format aggregates goroutines by stack.
+	text := string(writeGoroutineProfile(debug.Profile_TEXT))
+	require.True(t, strings.HasPrefix(text, "goroutine profile: total "), "unexpected text profile: %v", text)
+	require.False(t, strings.Contains(text, "[running]:"), "unexpected text profile: %v", text)
+	// The proto format is a gzipped protobuf.
+	gz, err := gzip.NewReader(bytes.NewReader(writeGoroutineProfile(debug.Profile_PROTO)))
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(gz)
+	require.NoError(t, err)
+	require.True(t, len(data) > 0)
+	require.True(t, strings.Contains(string(data), "runtime/pprof"))
+	require.YesError(t, writeProfile(&bytes.Buffer{}, &debug.Profile{
+		Name:   "goroutine",
+		Format: debug.Profile_Format(-1),
+	}))
+}
+
+func TestWriteCollapsedProfile(t *testing.T) {
+	collapsedStack := regexp.MustCompile(`^[^ ;]+(;[^ ;]+)* [1-9][0-9]*$`)
+	checkCollapsed := func(data []byte) []string {
+		require.True(t, len(data) > 0)
+		require.True(t, bytes.HasSuffix(data, []byte("\n")))
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		for _, line := range lines {
+			require.True(t, collapsedStack.MatchString(line), "malformed collapsed stack: %q", line)
+		}
+		return lines
+	}
+	// The stacks go from the root to the leaf, so the test's goroutine is in
+	// a stack that starts at testing.tRunner and includes this test.
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeProfile(buf, &debug.Profile{
+		Name:   "goroutine",
+		Format: debug.Profile_COLLAPSED,
+	}))
+	var found bool
+	for _, line := range checkCollapsed(buf.Bytes()) {
+		if strings.HasPrefix(line, "testing.tRunner;") && strings.Contains(line, ".TestWriteCollapsedProfile;") {
+			found = true
+		}
+	}
+	require.True(t, found, "test goroutine not found in collapsed profile: %v", buf.String())
+	// CPU profiles can be collapsed too.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+		}
+	}()
+	buf.Reset()
+	require.NoError(t, writeProfile(buf, &debug.Profile{
+		Name:     "cpu",
+		Duration: types.DurationProto(500 * time.Millisecond),
+		Format:   debug.Profile_COLLAPSED,
+	}))
+	checkCollapsed(buf.Bytes())
+	// A profile that isn't a gzipped protobuf can't be collapsed.
+	require.YesError(t, writeCollapsed(&bytes.Buffer{}, strings.NewReader("not a profile")))
+}
+
+func TestWriteAllocProfile(t *testing.T) {
+	// defaultSampleType returns the name of the default sample type of a
+	// gzipped protobuf profile.
+	defaultSampleType := func(data []byte) string {
+		p, err := profile.Parse(bytes.NewReader(data))
+		require.NoError(t, err)
+		return p.DefaultSampleType
+	}
+	for _, name := range []string{"alloc_space", "alloc_objects"} {
+		buf := &bytes.Buffer{}
+		require.NoError(t, writeProfile(buf, &debug.Profile{
+			Name:   name,
+			Format: debug.Profile_PROTO,
+		}))
+		require.Equal(t, name, defaultSampleType(buf.Bytes()))
+	}
+	// The allocation views can be collapsed and written as text too.
+	require.NoError(t, writeProfile(&bytes.Buffer{}, &debug.Profile{
+		Name:   "alloc_space",
+		Format: debug.Profile_COLLAPSED,
+	}))
+	buf := &bytes.Buffer{}
+	require.NoError(t, writeProfile(buf, &debug.Profile{
+		Name:   "alloc_objects",
+		Format: debug.Profile_TEXT,
+	}))
+	require.True(t, strings.HasPrefix(buf.String(), "heap profile: "), "unexpected text profile: %v", buf.String())
+}
+
+func TestProfileMetadata(t *testing.T) {
+	s := NewDebugServer(nil, "pachd-0", nil).(*debugServer)
+	start := time.Now()
+	buf := &bytes.Buffer{}
+	require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+		return s.collectProfileFunc(&debug.Profile{
+			Name:   "goroutine",
+			Format: debug.Profile_TEXT,
+		})(tw, "pachd")
+	}))
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	// The metadata precedes the profile.
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "pachd/goroutine.metadata", hdr.Name)
+	metadata := &debug.ProfileMetadata{}
+	require.NoError(t, jsonpb.Unmarshal(tr, metadata))
+	require.Equal(t, "goroutine", metadata.Name)
+	require.Equal(t, debug.Profile_TEXT, metadata.Format)
+	require.Equal(t, "pachd-0", metadata.Node)
+	collected, err := types.TimestampFromProto(metadata.Collected)
+	require.NoError(t, err)
+	require.True(t, !collected.Before(start.Truncate(time.Second)) && !collected.After(time.Now()), "unexpected collection time: %v", collected)
+	hdr, err = tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "pachd/goroutine", hdr.Name)
+	data, err := ioutil.ReadAll(tr)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(data), "goroutine profile: total "), "unexpected profile: %v", string(data))
+	_, err = tr.Next()
+	require.True(t, errors.Is(err, io.EOF))
+}
+
+func TestCPUProfileSamples(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process CPU time is not supported on windows")
+	}
+	// Generate synthetic load, so that samples are collected.
+	done := make(chan struct{})
+	defer close(done)
+	for i := 0; i < 2; i++ {
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}()
+	}
+	samples := int64(100)
+	target := time.Duration(samples) * time.Second / cpuProfileRate
+	startCPU, err := processCPUTime()
+	require.NoError(t, err)
+	// The profile stops once the CPU time for the samples is used, rather
+	// than after the max duration.
+	buf := &bytes.Buffer{}
+	require.NoErrorWithinT(t, 30*time.Second, func() error {
+		return writeProfile(buf, &debug.Profile{
+			Name:     "cpu",
+			Duration: types.DurationProto(time.Minute),
+			Samples:  samples,
+		})
+	})
+	endCPU, err := processCPUTime()
+	require.NoError(t, err)
+	cpuTime := endCPU - startCPU
+	require.True(t, cpuTime >= target, "cpu time: %v", cpuTime)
+	require.True(t, cpuTime < 2*target, "cpu time: %v", cpuTime)
+	_, err = gzip.NewReader(buf)
+	require.NoError(t, err)
+	// The max duration bounds the profile when the samples are not collected.
+	require.NoErrorWithinT(t, 10*time.Second, func() error {
+		return writeProfile(&bytes.Buffer{}, &debug.Profile{
+			Name:     "cpu",
+			Duration: types.DurationProto(100 * time.Millisecond),
+			Samples:  1000000,
+		})
+	})
+}
+
+func TestProfileDurationCap(t *testing.T) {
+	s := NewDebugServer(nil, "pachd-0", nil).(*debugServer)
+	s.maxProfileDuration = 200 * time.Millisecond
+	collectCPUProfile := func(duration time.Duration) *debug.ProfileMetadata {
+		buf := &bytes.Buffer{}
+		require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+			return s.collectProfileFunc(&debug.Profile{
+				Name:     "cpu",
+				Duration: types.DurationProto(duration),
+			})(tw, "pachd")
+		}))
+		gr, err := gzip.NewReader(buf)
+		require.NoError(t, err)
+		tr := tar.NewReader(gr)
+		hdr, err := tr.Next()
+		require.NoError(t, err)
+		require.Equal(t, "pachd/cpu.metadata", hdr.Name)
+		metadata := &debug.ProfileMetadata{}
+		require.NoError(t, jsonpb.Unmarshal(tr, metadata))
+		return metadata
+	}
+	// An over-long profile is clamped to the max duration.
+	start := time.Now()
+	metadata := collectCPUProfile(10 * time.Minute)
+	require.True(t, time.Since(start) < 10*time.Second, "profile took %v", time.Since(start))
+	require.True(t, metadata.DurationClamped)
+	duration, err := types.DurationFromProto(metadata.Duration)
+	require.NoError(t, err)
+	require.Equal(t, s.maxProfileDuration, duration)
+	// A profile within the max duration isn't clamped.
+	metadata = collectCPUProfile(100 * time.Millisecond)
+	require.False(t, metadata.DurationClamped)
+	duration, err = types.DurationFromProto(metadata.Duration)
+	require.NoError(t, err)
+	require.Equal(t, 100*time.Millisecond, duration)
+}
+
+func TestCollectProcessStats(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("process stats are only collected on linux")
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+		return collectProcessStats(tw, "pachd")
+	}))
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	stats := make(map[string]int)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, "pachd/process", hdr.Name)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			fields := strings.SplitN(line, ": ", 2)
+			require.Equal(t, 2, len(fields), "malformed line: %v", line)
+			n, err := strconv.Atoi(fields[1])
+			require.NoError(t, err)
+			stats[fields[0]] = n
+		}
+	}
+	require.True(t, stats["fds"] > 0, "fds: %v", stats["fds"])
+	require.True(t, stats["threads"] > 0, "threads: %v", stats["threads"])
+}
+
+func TestCollectEtcdKeyCounts(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		seeded := map[string]int{
+			"pfs":      5,
+			"pfs/task": 2,
+			"pps":      3,
+		}
+		for keyPrefix, n := range seeded {
+			for i := 0; i < n; i++ {
+				_, err := env.EtcdClient.Put(env.Context, fmt.Sprintf("%v/%v", keyPrefix, i), "value")
+				require.NoError(t, err)
+			}
+		}
+		buf := &bytes.Buffer{}
+		require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+			return collectEtcdKeyCounts(env.Context, tw, env.EtcdClient, []string{"pfs", "pfs/task", "pps", "auth"}, "pachd")
+		}))
+		gr, err := gzip.NewReader(buf)
+		require.NoError(t, err)
+		tr := tar.NewReader(gr)
+		hdr, err := tr.Next()
+		require.NoError(t, err)
+		require.Equal(t, "pachd/etcd-keys", hdr.Name)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		// The pfs prefix includes the keys under pfs/task.
+		require.Equal(t, "pfs: 7\npfs/task: 2\npps: 3\nauth: 0\n", string(data))
+		_, err = tr.Next()
+		require.True(t, errors.Is(err, io.EOF))
+		return nil
+	}))
+}
+
+func TestCollectWorkers(t *testing.T) {
+	pipelineInfos := []*pps.PipelineInfo{
+		{Pipeline: client.NewPipeline("edges"), Version: 1},
+		{Pipeline: client.NewPipeline("montage"), Version: 3},
+		{Pipeline: client.NewPipeline("stopped"), Version: 1},
+	}
+	workers := map[string][]string{
+		ppsutil.PipelineRcName("edges", 1):   {"10.0.0.1", "10.0.0.2"},
+		ppsutil.PipelineRcName("montage", 3): {"10.0.0.3"},
+	}
+	discover := func(_ context.Context, pipelineRcName string) ([]string, error) {
+		return workers[pipelineRcName], nil
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+		return collectWorkers(context.Background(), tw, pipelineInfos, discover, "pachd")
+	}))
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "pachd/workers", hdr.Name)
+	data, err := ioutil.ReadAll(tr)
+	require.NoError(t, err)
+	require.Equal(t, "edges: 2 [10.0.0.1, 10.0.0.2]\nmontage: 1 [10.0.0.3]\nstopped: 0 []\n", string(data))
+	// Discovery errors are written to an error file.
+	buf = &bytes.Buffer{}
+	require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+		return collectWorkers(context.Background(), tw, pipelineInfos, func(_ context.Context, _ string) ([]string, error) {
+			return nil, errors.Errorf("etcd unavailable")
+		}, "pachd")
+	}))
+	gr, err = gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr = tar.NewReader(gr)
+	hdr, err = tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "pachd/workers/error", hdr.Name)
+	data, err = ioutil.ReadAll(tr)
+	require.NoError(t, err)
+	require.Equal(t, "etcd unavailable\n", string(data))
+}
+
+func TestCaptureLogs(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	buf := &bytes.Buffer{}
+	done := make(chan error)
+	go func() {
+		done <- withDebugWriter(buf, func(tw *tar.Writer) error {
+			return captureLogs(context.Background(), tw, logger, logrus.DebugLevel, 2*time.Second, "pachd")
+		})
+	}()
+	// The log level is raised while the logs are captured.
+	require.NoError(t, backoff.Retry(func() error {
+		if logger.GetLevel() != logrus.DebugLevel {
+			return errors.Errorf("log level not raised: %v", logger.GetLevel())
+		}
+		return nil
+	}, backoff.RetryEvery(10*time.Millisecond).For(time.Second)))
+	logger.Debug("captured debug message")
+	require.NoError(t, <-done)
+	// The prior log level is restored afterwards.
+	require.Equal(t, logrus.InfoLevel, logger.GetLevel())
+	logger.Debug("uncaptured debug message")
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "pachd/logs", hdr.Name)
+	data, err := ioutil.ReadAll(tr)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(data), "captured debug message"), "unexpected logs: %v", string(data))
+	require.False(t, strings.Contains(string(data), "uncaptured"), "unexpected logs: %v", string(data))
+	// The log level is not lowered.
+	logger.SetLevel(logrus.TraceLevel)
+	require.NoError(t, withDebugWriter(&bytes.Buffer{}, func(tw *tar.Writer) error {
+		return captureLogs(context.Background(), tw, logger, logrus.DebugLevel, 10*time.Millisecond, "pachd")
+	}))
+	require.Equal(t, logrus.TraceLevel, logger.GetLevel())
+}
+
+func TestTailLogs(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+	lines := make(chan string, 100)
+	done := make(chan error)
+	go func() {
+		done <- tailLogs(context.Background(), logger, 2*time.Second, func(line *debug.LogLine) error {
+			lines <- line.Line
+			return nil
+		})
+	}()
+	// Wait for the tail to start.
+	require.NoError(t, backoff.Retry(func() error {
+		logger.Info("waiting for tail")
+		select {
+		case <-lines:
+			return nil
+		case <-time.After(10 * time.Millisecond):
+			return errors.Errorf("tail not started")
+		}
+	}, backoff.RetryEvery(10*time.Millisecond).For(time.Second)))
+	for i := 0; i < 10; i++ {
+		logger.Infof("message %v", i)
+	}
+	// The log level is not raised.
+	logger.Debug("untailed debug message")
+	var i int
+	for i < 10 {
+		line := <-lines
+		if strings.Contains(line, "waiting for tail") {
+			continue
+		}
+		require.True(t, strings.Contains(line, fmt.Sprintf("message %v\"", i)), "unexpected line: %v", line)
+		i++
+	}
+	require.NoError(t, <-done)
+	close(lines)
+	for line := range lines {
+		require.False(t, strings.Contains(line, "message"), "unexpected line: %v", line)
+	}
+	// The tail stops when the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, tailLogs(ctx, logger, time.Hour, func(line *debug.LogLine) error {
+		return errors.Errorf("unexpected line: %v", line.Line)
+	}))
+}
+
+// countHook is a logrus hook that counts the entries it's fired with.
+type countHook struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (h *countHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *countHook) Fire(*logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.count++
+	return nil
+}
+
+func TestConcurrentTailLogs(t *testing.T) {
+	logger := logrus.New()
+	var lines [2]chan string
+	var done [2]chan error
+	for i := range lines {
+		lines[i] = make(chan string, 100)
+		done[i] = make(chan error, 1)
+		i := i
+		go func() {
+			done[i] <- tailLogs(context.Background(), logger, time.Second, func(line *debug.LogLine) error {
+				lines[i] <- line.Line
+				return nil
+			})
+		}()
+	}
+	// Both tails run at once, rather than one waiting for the other.
+	require.NoError(t, backoff.Retry(func() error {
+		logger.Info("waiting for tails")
+		for i := range lines {
+			select {
+			case <-lines[i]:
+			case <-time.After(10 * time.Millisecond):
+				return errors.Errorf("tail %v not started", i)
+			}
+		}
+		return nil
+	}, backoff.RetryEvery(10*time.Millisecond).For(time.Second)))
+	// A hook added during the tails is kept after they end.
+	hook := &countHook{}
+	logger.AddHook(hook)
+	for i := range done {
+		require.NoError(t, <-done[i])
+	}
+	logger.Info("after tails")
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	require.Equal(t, 1, hook.count)
+	// The tails share a single fan-out hook.
+	require.Equal(t, 2, len(logger.Hooks[logrus.InfoLevel]))
+}
+
+// tailLogsServer is a debug.Debug_TailLogsServer that discards the lines it's
+// sent.
+type tailLogsServer struct {
+	debug.Debug_TailLogsServer
+	ctx context.Context
+}
+
+func (s *tailLogsServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tailLogsServer) Send(*debug.LogLine) error {
+	return nil
+}
+
+func TestTailLogsDurationCap(t *testing.T) {
+	s := NewDebugServer(nil, "pachd-0", nil).(*debugServer)
+	s.maxTailDuration = 200 * time.Millisecond
+	// An over-long tail is clamped to the max duration.
+	start := time.Now()
+	require.NoError(t, s.TailLogs(&debug.TailLogsRequest{
+		Duration: types.DurationProto(10 * time.Minute),
+	}, &tailLogsServer{ctx: context.Background()}))
+	require.True(t, time.Since(start) < 10*time.Second, "tail took %v", time.Since(start))
+}
+
+func TestCollectWorkTasks(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx, cancel := context.WithCancel(env.Context)
+		defer cancel()
+		// Seed tasks with subtasks that are never processed, because there
+		// are no workers.
+		seeded := map[string]string{
+			"pfs": "storage",
+			"pps": "/pipeline-edges/v1",
+		}
+		for etcdPrefix, namespace := range seeded {
+			tq, err := work.NewTaskQueue(ctx, env.EtcdClient, etcdPrefix, namespace)
+			require.NoError(t, err)
+			require.NoError(t, tq.RunTask(ctx, func(master *work.Master) {
+				master.RunSubtasks([]*work.Task{{ID: "subtask-1"}, {ID: "subtask-2"}}, nil)
+			}))
+		}
+		var numTasks int
+		require.NoError(t, backoff.Retry(func() error {
+			numTasks = 0
+			for etcdPrefix := range seeded {
+				if err := work.ListTasks(ctx, env.EtcdClient, etcdPrefix, func(status *work.TaskStatus) error {
+					if len(status.Subtasks) != 2 {
+						return errors.Errorf("expected 2 subtasks, got %v", len(status.Subtasks))
+					}
+					numTasks++
+					return nil
+				}); err != nil {
+					return err
+				}
+			}
+			if numTasks != len(seeded) {
+				return errors.Errorf("expected %v tasks, got %v", len(seeded), numTasks)
+			}
+			return nil
+		}, backoff.RetryEvery(10*time.Millisecond).For(10*time.Second)))
+		buf := &bytes.Buffer{}
+		require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+			return collectWorkTasks(env.Context, tw, env.EtcdClient, []string{"pfs", "pps"}, time.Now().Add(time.Hour), "pachd")
+		}))
+		gr, err := gzip.NewReader(buf)
+		require.NoError(t, err)
+		tr := tar.NewReader(gr)
+		hdr, err := tr.Next()
+		require.NoError(t, err)
+		require.Equal(t, "pachd/work-tasks", hdr.Name)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		// Each task is listed with its age, followed by its subtasks.
+		taskLine := regexp.MustCompile(`^(\S+) \S+: age (\S+), 2 subtasks$`)
+		subtaskLine := regexp.MustCompile(`^  subtask-[12]: RUNNING, age (\S+)$`)
+		checkAge := func(age string) {
+			d, err := time.ParseDuration(age)
+			require.NoError(t, err)
+			require.True(t, d >= time.Hour && d < 2*time.Hour, "unexpected age: %v", d)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Equal(t, 6, len(lines), "unexpected tasks: %v", string(data))
+		var namespaces []string
+		for i, line := range lines {
+			if i%3 == 0 {
+				match := taskLine.FindStringSubmatch(line)
+				require.True(t, match != nil, "unexpected task line: %v", line)
+				namespaces = append(namespaces, match[1])
+				checkAge(match[2])
+				continue
+			}
+			match := subtaskLine.FindStringSubmatch(line)
+			require.True(t, match != nil, "unexpected subtask line: %v", line)
+			checkAge(match[1])
+		}
+		require.Equal(t, []string{"pfs/storage", "pps/pipeline-edges/v1"}, namespaces)
+		return nil
+	}))
+}
+
+// contend repeatedly holds mu until stop is closed, which blocks the other
+// goroutines calling contend.
+func contend(mu *sync.Mutex, stop chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		mu.Lock()
+		time.Sleep(time.Millisecond)
+		mu.Unlock()
+	}
+}
+
+func TestCollectContention(t *testing.T) {
+	for _, profile := range []string{"block", "mutex"} {
+		t.Run(profile, func(t *testing.T) {
+			mu := &sync.Mutex{}
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					contend(mu, stop)
+				}()
+			}
+			buf := &bytes.Buffer{}
+			require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+				return collectContention(context.Background(), tw, profile, time.Second, 5, "pachd")
+			}))
+			close(stop)
+			wg.Wait()
+			gr, err := gzip.NewReader(buf)
+			require.NoError(t, err)
+			tr := tar.NewReader(gr)
+			hdr, err := tr.Next()
+			require.NoError(t, err)
+			require.Equal(t, "pachd/contention", hdr.Name)
+			data, err := ioutil.ReadAll(tr)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			require.True(t, strings.HasPrefix(lines[0], profile+" contention over 1s"), "unexpected summary: %v", string(data))
+			require.True(t, len(lines) > 2 && len(lines) <= 7, "unexpected summary: %v", string(data))
+			// The most contended call site is first.
+			fields := strings.Fields(lines[2])
+			require.Equal(t, 4, len(fields), "unexpected summary: %v", string(data))
+			require.True(t, strings.HasSuffix(fields[2], "server.contend"), "unexpected summary: %v", string(data))
+			count, err := strconv.Atoi(fields[1])
+			require.NoError(t, err)
+			require.True(t, count > 0)
+		})
+	}
+	require.YesError(t, withDebugWriter(&bytes.Buffer{}, func(tw *tar.Writer) error {
+		return collectContention(context.Background(), tw, "unknown", time.Second, 5)
+	}))
+}
+
+func TestSetProfileRate(t *testing.T) {
+	ctx := context.Background()
+	s := &debugServer{}
+	_, err := s.SetProfileRate(ctx, &debug.SetProfileRateRequest{Profile: "block", Rate: 1})
+	require.NoError(t, err)
+	defer func() {
+		_, err := s.ResetProfileRates(ctx, &debug.ResetProfileRatesRequest{})
+		require.NoError(t, err)
+	}()
+	// A contention capture restores the rate rather than disabling the
+	// profile.
+	require.NoError(t, withDebugWriter(&bytes.Buffer{}, func(tw *tar.Writer) error {
+		return collectContention(ctx, tw, "block", 10*time.Millisecond, 5)
+	}))
+	_, err = s.SetProfileRate(ctx, &debug.SetProfileRateRequest{Profile: "mutex", Rate: 5})
+	require.NoError(t, err)
+	require.NoError(t, withDebugWriter(&bytes.Buffer{}, func(tw *tar.Writer) error {
+		return collectContention(ctx, tw, "mutex", 10*time.Millisecond, 5)
+	}))
+	require.Equal(t, 5, runtime.SetMutexProfileFraction(-1))
+	// The blocking events after the rate was set are in the block profile.
+	before := blockProfileRecords()
+	mu := &sync.Mutex{}
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			contend(mu, stop)
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+	sites := contentionSites(before, blockProfileRecords(), 1)
+	require.True(t, len(sites) > 0)
+	var contended bool
+	for _, site := range sites {
+		if strings.HasSuffix(site.function, "server.contend") {
+			contended = true
+		}
+	}
+	require.True(t, contended)
+	resp, err := s.SetProfileRate(ctx, &debug.SetProfileRateRequest{Profile: "block", Rate: 100})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.PreviousRate)
+	_, err = s.ResetProfileRates(ctx, &debug.ResetProfileRatesRequest{})
+	require.NoError(t, err)
+	resp, err = s.SetProfileRate(ctx, &debug.SetProfileRateRequest{Profile: "mutex", Rate: 0})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), resp.PreviousRate)
+	_, err = s.SetProfileRate(ctx, &debug.SetProfileRateRequest{Profile: "block", Rate: -1})
+	require.YesError(t, err)
+	_, err = s.SetProfileRate(ctx, &debug.SetProfileRateRequest{Profile: "unknown", Rate: 1})
+	require.YesError(t, err)
+}
+
+func TestDumpProgress(t *testing.T) {
+	buf := &bytes.Buffer{}
+	names := []string{"pachd", "pipelines/a", "pipelines/b"}
+	require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+		var sections []func() error
+		for _, name := range names {
+			name := name
+			sections = append(sections, func() error {
+				return collectDebugFile(tw, "version", func(w io.Writer) error {
+					_, err := io.WriteString(w, name)
+					return err
+				}, name)
+			})
+		}
+		return collectSections(tw, sections, writeDumpProgress)
+	}))
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	var files []string
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		files = append(files, hdr.Name+": "+strings.TrimSpace(string(data)))
+	}
+	// A progress marker follows each section.
+	require.Equal(t, []string{
+		"pachd/version: pachd",
+		"progress: dumped 1 of 3 sections",
+		"pipelines/a/version: pipelines/a",
+		"progress: dumped 2 of 3 sections",
+		"pipelines/b/version: pipelines/b",
+		"progress: dumped 3 of 3 sections",
+	}, files)
+}
+
+func TestWorkerErrorAggregation(t *testing.T) {
+	s := &debugServer{
+		newWorkerClient: func(_ string) (debug.DebugClient, error) {
+			return nil, nil
+		},
+	}
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}, Status: v1.PodStatus{Phase: v1.PodPending}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-3"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+	}
+	// The worker-3 redirect fails, and the other workers return a dump
+	// containing their pod name.
+	redirect := func(_ debug.DebugClient, filter *debug.Filter) (io.Reader, error) {
+		pod := filter.Filter.(*debug.Filter_Worker).Worker.Pod
+		if pod == "worker-3" {
+			return nil, errors.Errorf("connection refused")
+		}
+		buf := &bytes.Buffer{}
+		if err := withDebugWriter(buf, func(tw *tar.Writer) error {
+			return collectDebugFile(tw, "version", func(w io.Writer) error {
+				_, err := io.WriteString(w, pod)
+				return err
+			})
+		}); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+	prefix := join(pipelinePrefix, "edges")
+	buf := &bytes.Buffer{}
+	var errs workerErrors
+	require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+		return errs.add(forEachWorker(pods, func(pod *v1.Pod) error {
+			return s.handleWorkerRedirect(tw, pod, nil, redirect, prefix)
+		}))
+	}))
+	// Both worker errors are aggregated.
+	require.Equal(t, 2, len(errs))
+	require.Equal(t, "worker-1", errs[0].pod)
+	require.Equal(t, "worker-3", errs[1].pod)
+	require.True(t, strings.Contains(errs.Error(), "worker worker-1: pod in phase Pending"))
+	require.True(t, strings.Contains(errs.Error(), "worker worker-3: connection refused"))
+	// The healthy workers were dumped, and the errors were written to the
+	// failing workers' error files.
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	var files []string
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		files = append(files, hdr.Name+": "+strings.TrimSpace(strings.SplitN(string(data), ",", 2)[0]))
+	}
+	require.Equal(t, []string{
+		"pipelines/edges/pods/worker-0/version: worker-0",
+		"pipelines/edges/pods/worker-1/error: pod in phase Pending",
+		"pipelines/edges/pods/worker-2/version: worker-2",
+		"pipelines/edges/pods/worker-3/error: connection refused",
+	}, files)
+}
+
+// parkGoroutine parks until stop is closed, and sends its goroutine ID to
+// ids.
+func parkGoroutine(ids chan<- int64, stop chan struct{}) {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	id, err := strconv.ParseInt(strings.Fields(string(buf))[1], 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	ids <- id
+	<-stop
+}
+
+func TestGoroutine(t *testing.T) {
+	ids := make(chan int64)
+	stop := make(chan struct{})
+	defer close(stop)
+	go parkGoroutine(ids, stop)
+	id := <-ids
+	s := &debugServer{}
+	resp, err := s.Goroutine(context.Background(), &debug.GoroutineRequest{Id: id})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(resp.Stack, fmt.Sprintf("goroutine %v [", id)), resp.Stack)
+	require.True(t, strings.Contains(resp.Stack, "parkGoroutine"), resp.Stack)
+	// Only the requested goroutine's stack is returned.
+	require.Equal(t, 1, strings.Count("\n"+resp.Stack, "\ngoroutine "), resp.Stack)
+	// A goroutine that doesn't exist is not found.
+	_, err = s.Goroutine(context.Background(), &debug.GoroutineRequest{Id: math.MaxInt64})
+	require.YesError(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSampleGoroutineCount(t *testing.T) {
+	// Leak a goroutine every few milliseconds while the count is sampled.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(5 * time.Millisecond):
+				go func() { <-stop }()
+			}
+		}
+	}()
+	var samples []*debug.GoroutineCountSample
+	require.NoError(t, sampleGoroutineCount(context.Background(), 50*time.Millisecond, 500*time.Millisecond, func(sample *debug.GoroutineCountSample) error {
+		samples = append(samples, sample)
+		return nil
+	}))
+	require.True(t, len(samples) >= 5, "too few samples: %v", len(samples))
+	first, last := samples[0], samples[len(samples)-1]
+	require.True(t, last.Count > first.Count+20, "goroutine count didn't trend upward: %v", samples)
+	firstTime, err := types.TimestampFromProto(first.Time)
+	require.NoError(t, err)
+	lastTime, err := types.TimestampFromProto(last.Time)
+	require.NoError(t, err)
+	require.True(t, lastTime.After(firstTime))
+	// Sampling stops when the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var n int
+	require.NoError(t, sampleGoroutineCount(ctx, time.Second, time.Hour, func(*debug.GoroutineCountSample) error {
+		n++
+		return nil
+	}))
+	require.Equal(t, 1, n)
+}
+
+func TestDumpSections(t *testing.T) {
+	collectFiles := func(sections dumpSections) map[string]bool {
+		buf := &bytes.Buffer{}
+		require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+			return collectDumpFunc(sections)(tw, pachdPrefix)
+		}))
+		gr, err := gzip.NewReader(buf)
+		require.NoError(t, err)
+		tr := tar.NewReader(gr)
+		files := make(map[string]bool)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			files[hdr.Name] = true
+		}
+		return files
+	}
+	// The heap profile is absent when it's excluded, while the other
+	// sections remain.
+	sections, err := newDumpSections(nil, []string{"heap"})
+	require.NoError(t, err)
+	files := collectFiles(sections)
+	require.True(t, files[join(pachdPrefix, "goroutine")], "files: %v", files)
+	require.False(t, files[join(pachdPrefix, "heap")], "files: %v", files)
+	// Only the included sections are collected.
+	sections, err = newDumpSections([]string{"heap"}, nil)
+	require.NoError(t, err)
+	files = collectFiles(sections)
+	require.Equal(t, map[string]bool{join(pachdPrefix, "heap"): true}, files)
+	// Unknown sections are rejected.
+	_, err = newDumpSections(nil, []string{"unknown"})
+	require.YesError(t, err)
+}
+
+func TestDumpBuildInfo(t *testing.T) {
+	sections, err := newDumpSections([]string{"build-info"}, nil)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, withDebugWriter(buf, func(tw *tar.Writer) error {
+		return collectDumpFunc(sections)(tw, pachdPrefix)
+	}))
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, join(pachdPrefix, "build-info"), hdr.Name)
+	data, err := ioutil.ReadAll(tr)
+	require.NoError(t, err)
+	var deps int
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "dep\t") {
+			deps++
+		}
+	}
+	require.True(t, strings.Contains(string(data), "\nmod\tgithub.com/pachyderm/pachyderm\t"), string(data))
+	require.True(t, deps > 0, string(data))
+}
+
+func TestCounters(t *testing.T) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "debug_test",
+		Name:      "counters_test_count",
+		Help:      "Counter for TestCounters",
+	}, []string{"outcome"})
+	require.NoError(t, prometheus.Register(counter))
+	defer prometheus.Unregister(counter)
+	name := "pachyderm_debug_test_counters_test_count"
+	s := &debugServer{}
+	counters := func(reset bool) map[string]float64 {
+		resp, err := s.Counters(context.Background(), &debug.CountersRequest{
+			Names:  []string{name},
+			Reset_: reset,
+		})
+		require.NoError(t, err)
+		values := make(map[string]float64)
+		for _, c := range resp.Counters {
+			require.Equal(t, name, c.Name)
+			values[c.Labels["outcome"]] = c.Value
+		}
+		return values
+	}
+	counter.WithLabelValues("succeeded").Add(3)
+	counter.WithLabelValues("failed").Inc()
+	require.Equal(t, map[string]float64{"succeeded": 3, "failed": 1}, counters(true))
+	// The counters are zero after they're reset.
+	require.Equal(t, map[string]float64{"succeeded": 0, "failed": 0}, counters(false))
+	counter.WithLabelValues("succeeded").Add(2)
+	require.Equal(t, map[string]float64{"succeeded": 2, "failed": 0}, counters(false))
+	// The counter exported to Prometheus is not reset.
+	require.Equal(t, float64(5), promtestutil.ToFloat64(counter.WithLabelValues("succeeded")))
+}
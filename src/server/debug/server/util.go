@@ -3,11 +3,14 @@ package server
 import (
 	"archive/tar"
 	"compress/gzip"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
 	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 )
 
 func join(names ...string) string {
@@ -136,3 +139,57 @@ func copyTar(tw *tar.Writer, tr *tar.Reader, prefix ...string) error {
 		}
 	}
 }
+
+// workerError is an error collecting the debug information of a worker, which
+// has already been written to the worker's error file.
+type workerError struct {
+	pod string
+	err error
+}
+
+func (e *workerError) Error() string {
+	return fmt.Sprintf("worker %v: %v", e.pod, e.err)
+}
+
+func (e *workerError) Unwrap() error {
+	return e.err
+}
+
+// workerErrors is the worker errors of a recursive collection, they're
+// aggregated so that the collection can continue with the reachable workers.
+type workerErrors []*workerError
+
+func (errs workerErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("error collecting debug information from %d worker(s): %v", len(errs), strings.Join(msgs, "; "))
+}
+
+// add adds err to errs if it's a worker error (or an aggregate of them), and
+// returns any other error.
+func (errs *workerErrors) add(err error) error {
+	if err == nil {
+		return nil
+	}
+	var aggregate workerErrors
+	if errors.As(err, &aggregate) {
+		*errs = append(*errs, aggregate...)
+		return nil
+	}
+	var workerErr *workerError
+	if errors.As(err, &workerErr) {
+		*errs = append(*errs, workerErr)
+		return nil
+	}
+	return err
+}
+
+// err returns errs as an error, or nil if there are no worker errors.
+func (errs workerErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -2,6 +2,8 @@ package cmds
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/pachyderm/pachyderm/src/client"
@@ -35,17 +37,24 @@ func parseISO8601(s string) (time.Time, error) {
 // users
 func ActivateCmd() *cobra.Command {
 	var expires string
+	var add bool
+	var codeRef string
 	activate := &cobra.Command{
 		Use: "{{alias}}",
 		Short: "Activate the enterprise features of Pachyderm with an activation " +
 			"code",
 		Long: "Activate the enterprise features of Pachyderm with an activation " +
-			"code",
+			"code. The code is read from the terminal, unless a reference to " +
+			"it is given with --code-ref, which pachd resolves.",
 		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
-			// request the enterprise key
-			key, err := cmdutil.ReadPassword("Enterprise key: ")
-			if err != nil {
-				return errors.Wrapf(err, "could not read enterprise key")
+			var key string
+			if codeRef == "" {
+				// request the enterprise key
+				var err error
+				key, err = cmdutil.ReadPassword("Enterprise key: ")
+				if err != nil {
+					return errors.Wrapf(err, "could not read enterprise key")
+				}
 			}
 
 			c, err := client.NewOnUserMachine("user")
@@ -55,6 +64,8 @@ func ActivateCmd() *cobra.Command {
 			defer c.Close()
 			req := &enterprise.ActivateRequest{}
 			req.ActivationCode = key
+			req.ActivationCodeRef = codeRef
+			req.Add = add
 			if expires != "" {
 				t, err := parseISO8601(expires)
 				if err != nil {
@@ -84,10 +95,139 @@ func ActivateCmd() *cobra.Command {
 		"RFC 3339/ISO 8601 datetime). This is only applied if it's earlier than "+
 		"the signed expiration time encoded in 'activation-code', and therefore "+
 		"is only useful for testing.")
+	activate.PersistentFlags().BoolVar(&add, "add", false, "Add the activation "+
+		"code to the cluster's active activation codes, rather than replacing "+
+		"them. The earliest expiration of the active codes applies.")
+	activate.PersistentFlags().StringVar(&codeRef, "code-ref", "", "A "+
+		"reference to the activation code that pachd resolves, rather than "+
+		"sending the code (by default, the name of an environment variable in "+
+		"pachd starting with ENTERPRISE_ACTIVATION_CODE).")
 
 	return cmdutil.CreateAlias(activate, "enterprise activate")
 }
 
+// StageCmd returns a cobra.Command to stage an activation code that replaces
+// the cluster's active activation codes when they expire
+func StageCmd() *cobra.Command {
+	var startAfter string
+	stage := &cobra.Command{
+		Use: "{{alias}}",
+		Short: "Stage an activation code that replaces the active activation " +
+			"codes when they expire",
+		Long: "Stage an activation code that replaces the active activation " +
+			"codes when they expire, so that a renewed license can be provided " +
+			"before the current one expires",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			// request the enterprise key
+			key, err := cmdutil.ReadPassword("Enterprise key: ")
+			if err != nil {
+				return errors.Wrapf(err, "could not read enterprise key")
+			}
+
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			req := &enterprise.StageActivationCodeRequest{}
+			req.ActivationCode = key
+			if startAfter != "" {
+				t, err := parseISO8601(startAfter)
+				if err != nil {
+					return errors.Wrapf(err, "could not parse the timestamp \"%s\"", startAfter)
+				}
+				req.StartAfter, err = types.TimestampProto(t)
+				if err != nil {
+					return errors.Wrapf(err, "error converting start time \"%s\"", t.String())
+				}
+			}
+			resp, err := c.Enterprise.StageActivationCode(c.Ctx(), req)
+			if err != nil {
+				return err
+			}
+			ts, err := types.TimestampFromProto(resp.StartAfter)
+			if err != nil {
+				return errors.Wrapf(err, "staging request succeeded, but could not "+
+					"convert the start time to a timestamp")
+			}
+			fmt.Printf("Staging succeeded. The activation code replaces the active "+
+				"activation codes when they expire after %s\n", ts.String())
+			return nil
+		}),
+	}
+	stage.PersistentFlags().StringVar(&startAfter, "start-after", "", "A "+
+		"timestamp (formatted as an RFC 3339/ISO 8601 datetime) before which the "+
+		"staged activation code does not replace the active activation codes. "+
+		"Defaults to the expiration of the active activation codes.")
+
+	return cmdutil.CreateAlias(stage, "enterprise stage")
+}
+
+// ExportStateCmd returns a cobra.Command to export the cluster's enterprise
+// state as JSON
+func ExportStateCmd() *cobra.Command {
+	exportState := &cobra.Command{
+		Short: "Export the cluster's enterprise state and configuration as JSON",
+		Long: "Export the cluster's enterprise state and configuration as JSON, " +
+			"for backups and support. The output contains the cluster's " +
+			"activation codes, and can be restored with 'import-state'",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			resp, err := c.Enterprise.ExportState(c.Ctx(), &enterprise.ExportStateRequest{})
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(resp.State))
+			return nil
+		}),
+	}
+	return cmdutil.CreateAlias(exportState, "enterprise export-state")
+}
+
+// ImportStateCmd returns a cobra.Command to restore enterprise state exported
+// by ExportStateCmd onto a cluster that hasn't been activated
+func ImportStateCmd() *cobra.Command {
+	var file string
+	importState := &cobra.Command{
+		Short: "Import enterprise state exported by 'export-state'",
+		Long: "Import enterprise state exported by 'export-state'. The cluster " +
+			"must not have an active activation code",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			var state []byte
+			if file == "-" {
+				var err error
+				state, err = ioutil.ReadAll(os.Stdin)
+				if err != nil {
+					return errors.Wrapf(err, "could not read state from stdin")
+				}
+			} else if file != "" {
+				var err error
+				state, err = ioutil.ReadFile(file)
+				if err != nil {
+					return errors.Wrapf(err, "could not read state from %q", file)
+				}
+			} else {
+				return errors.New("must set input file (use \"-\" to read from stdin)")
+			}
+
+			c, err := client.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "could not connect")
+			}
+			defer c.Close()
+			_, err = c.Enterprise.ImportState(c.Ctx(), &enterprise.ImportStateRequest{State: state})
+			return err
+		}),
+	}
+	importState.Flags().StringVarP(&file, "file", "f", "-", "input file (use "+
+		"\"-\" to read from stdin)")
+	return cmdutil.CreateAlias(importState, "enterprise import-state")
+}
+
 // GetStateCmd returns a cobra.Command to activate the enterprise features of
 // Pachyderm within a Pachyderm cluster. All repos will go from
 // publicly-accessible to accessible only by the owner, who can subsequently add
@@ -109,6 +249,10 @@ func GetStateCmd() *cobra.Command {
 				return err
 			}
 			if resp.State == enterprise.State_NONE {
+				if resp.Deactivated {
+					fmt.Println("Pachyderm Enterprise was deactivated")
+					return nil
+				}
 				fmt.Println("No Pachyderm Enterprise token was found")
 				return nil
 			}
@@ -136,7 +280,10 @@ func Cmds() []*cobra.Command {
 	commands = append(commands, cmdutil.CreateAlias(enterprise, "enterprise"))
 
 	commands = append(commands, ActivateCmd())
+	commands = append(commands, StageCmd())
 	commands = append(commands, GetStateCmd())
+	commands = append(commands, ExportStateCmd())
+	commands = append(commands, ImportStateCmd())
 
 	return commands
 }
@@ -3,11 +3,22 @@ package server
 import (
 	"encoding/base64"
 	"encoding/json"
+	"net/http"
+	"path"
+	"sync"
 	"time"
 
+	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/pachyderm/pachyderm/src/client/auth"
 	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
@@ -16,6 +27,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/license"
 	"github.com/pachyderm/pachyderm/src/server/pkg/log"
 	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 )
 
 const (
@@ -23,6 +35,18 @@ const (
 	// token that a user has given us. This is what we check to know if a
 	// Pachyderm cluster supports enterprise features
 	enterpriseTokenKey = "token"
+
+	// deactivationTokensPrefix is the prefix (under the enterprise prefix) of
+	// the collection of pending deactivation confirmation tokens.
+	deactivationTokensPrefix = "deactivation-tokens"
+
+	// deactivationTokenTTLSecs is the number of seconds that a deactivation
+	// confirmation token is valid for.
+	deactivationTokenTTLSecs = 60
+
+	// promotionInterval is how often the server checks whether a staged
+	// activation code should replace the expired activation codes.
+	promotionInterval = time.Minute
 )
 
 type apiServer struct {
@@ -34,14 +58,135 @@ type apiServer struct {
 	// enterpriseToken is a collection containing at most one Pachyderm enterprise
 	// token
 	enterpriseToken col.Collection
+
+	// deactivationTokens is a collection of the confirmation tokens returned
+	// by Deactivate, which expire after deactivationTokenTTLSecs
+	deactivationTokens col.Collection
+
+	// warmupTimeout is the amount of time to wait for the enterprise token
+	// cache to be loaded on startup (zero means don't wait).
+	warmupTimeout time.Duration
+
+	// newSTM runs the STMs that write the enterprise token (overridden in
+	// tests to inject etcd errors).
+	newSTM func(context.Context, *etcd.Client, func(col.STM) error) (*etcd.TxnResponse, error)
+
+	// validate validates an activation code and returns its expiration
+	// (overridden in tests to use unsigned activation codes).
+	validate func(string) (time.Time, error)
+
+	// decode validates an activation code and returns its claims and
+	// expiration (overridden in tests to use unsigned activation codes).
+	decode func(string) (*license.Token, time.Time, error)
+
+	// deleteAll deletes all of the cluster's data when it is deactivated
+	// (overridden in tests, which don't run the other pachd services).
+	deleteAll func(context.Context) error
+
+	// now returns the current time (overridden in tests to advance the clock
+	// past the expiration of an activation code).
+	now func() time.Time
+
+	// subject returns the auth subject of the caller in ctx, which the rate
+	// limit on GetActivationCode is applied per (overridden in tests, which
+	// don't run the auth service).
+	subject func(context.Context) (string, error)
+
+	// activationCodeLimit and activationCodeBurst configure the per-subject
+	// rate limit on GetActivationCode (a zero limit means unlimited).
+	activationCodeLimit rate.Limit
+	activationCodeBurst int
+
+	limitersMu             sync.Mutex
+	activationCodeLimiters map[string]*rate.Limiter
+
+	// webhookURL is the URL that license state changes are posted to (empty
+	// means the webhook is disabled).
+	webhookURL    string
+	webhookClient *http.Client
+
+	// webhookChanged is signaled each time the enterprise token cache is
+	// updated.
+	webhookChanged chan struct{}
+
+	// webhookState is the license state that was last posted to the webhook.
+	webhookMu    sync.Mutex
+	webhookState string
+
+	// readOnly makes the RPCs that write the enterprise state return an
+	// error, for standby servers that only serve reads.
+	readOnly bool
+
+	// resolveCode resolves the activation code references in Activate
+	// requests.
+	resolveCode CodeResolver
+
+	// ctx is the context of the server's background work (e.g. promoting
+	// staged activation codes), which stops when it's done.
+	ctx context.Context
+}
+
+// Option configures the enterprise server.
+type Option func(*apiServer)
+
+// WithWarmup makes NewEnterpriseServer block until the enterprise token cache
+// has been loaded from etcd, so that the server is consistent with etcd as
+// soon as it is returned. NewEnterpriseServer returns an error if the cache is
+// not loaded within the timeout.
+func WithWarmup(timeout time.Duration) Option {
+	return func(a *apiServer) {
+		a.warmupTimeout = timeout
+	}
+}
+
+// WithActivationCodeRateLimit limits each auth subject to limit calls to
+// GetActivationCode per second, with bursts of up to burst calls. Calls over
+// the limit return a ResourceExhausted error. A zero limit means unlimited.
+func WithActivationCodeRateLimit(limit float64, burst int) Option {
+	return func(a *apiServer) {
+		a.activationCodeLimit = rate.Limit(limit)
+		a.activationCodeBurst = burst
+	}
+}
+
+// WithContext stops the server's background work (e.g. promoting staged
+// activation codes) when ctx is done. By default the background work runs
+// until the process exits.
+func WithContext(ctx context.Context) Option {
+	return func(a *apiServer) {
+		a.ctx = ctx
+	}
+}
+
+// WithReadOnly makes the server read-only if readOnly is set. A read-only
+// server serves the enterprise state from its cache of etcd, but returns a
+// FailedPrecondition error from the RPCs that write the state (e.g. Activate
+// and Deactivate), and doesn't promote staged activation codes.
+func WithReadOnly(readOnly bool) Option {
+	return func(a *apiServer) {
+		a.readOnly = readOnly
+	}
+}
+
+// checkWritable returns a FailedPrecondition error if the server is
+// read-only.
+func (a *apiServer) checkWritable() error {
+	if a.readOnly {
+		return status.Error(codes.FailedPrecondition, "server is read-only")
+	}
+	return nil
 }
 
-func (a *apiServer) LogReq(request interface{}) {
-	a.pachLogger.Log(request, nil, nil, 0)
+// logReq logs a request with the request ID from ctx, and returns a logger
+// with the same request ID for logging the rest of the request.
+func (a *apiServer) logReq(ctx context.Context, request interface{}) log.Logger {
+	logger := a.pachLogger.WithRequestID(log.RequestID(ctx))
+	logger.Log(request, nil, nil, 0)
+	return logger
 }
 
 // NewEnterpriseServer returns an implementation of ec.APIServer.
-func NewEnterpriseServer(env *serviceenv.ServiceEnv, etcdPrefix string) (ec.APIServer, error) {
+func NewEnterpriseServer(env *serviceenv.ServiceEnv, etcdPrefix string, opts ...Option) (ec.APIServer, error) {
 	defaultExpires, err := types.TimestampProto(time.Time{})
 	if err != nil {
 		return nil, err
@@ -57,22 +202,87 @@ func NewEnterpriseServer(env *serviceenv.ServiceEnv, etcdPrefix string) (ec.APIS
 	)
 
 	s := &apiServer{
-		pachLogger:           log.NewLogger("enterprise.API"),
-		env:                  env,
-		enterpriseTokenCache: keycache.NewCache(enterpriseToken, enterpriseTokenKey, defaultEnterpriseRecord),
-		enterpriseToken:      enterpriseToken,
+		pachLogger:      log.NewLogger("enterprise.API"),
+		env:             env,
+		enterpriseToken: enterpriseToken,
+		deactivationTokens: col.NewCollection(
+			env.GetEtcdClient(),
+			path.Join(etcdPrefix, deactivationTokensPrefix),
+			nil,
+			&types.Empty{},
+			nil,
+			nil,
+		),
+		newSTM:                 col.NewSTM,
+		validate:               license.Validate,
+		decode:                 license.Decode,
+		resolveCode:            envCodeResolver,
+		now:                    time.Now,
+		activationCodeLimiters: make(map[string]*rate.Limiter),
+		webhookClient:          &http.Client{Timeout: 30 * time.Second},
+		webhookChanged:         make(chan struct{}, 1),
+		ctx:                    context.Background(),
+	}
+	s.enterpriseTokenCache = keycache.NewCache(enterpriseToken, enterpriseTokenKey, defaultEnterpriseRecord,
+		keycache.WithOnChange(s.licenseStateChanged))
+	s.deleteAll = func(ctx context.Context) error {
+		return s.env.GetPachClient(ctx).DeleteAll()
 	}
+	s.subject = func(ctx context.Context) (string, error) {
+		pachClient := s.env.GetPachClient(ctx)
+		whoAmI, err := pachClient.WhoAmI(pachClient.Ctx(), &auth.WhoAmIRequest{})
+		if err != nil {
+			// All callers share a rate limit when auth is not activated.
+			if auth.IsErrNotActivated(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		return whoAmI.Username, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.warmupTimeout > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), s.warmupTimeout)
+		defer cancel()
+		if err := s.enterpriseTokenCache.Init(ctx); err != nil {
+			return nil, errors.Wrapf(err, "could not load the enterprise token cache")
+		}
+	}
+	if s.webhookURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), webhookInitTimeout)
+		defer cancel()
+		if err := s.initWebhook(ctx); err != nil {
+			return nil, errors.Wrapf(err, "could not read the initial license state")
+		}
+		go s.notifyLicenseStateChanges()
+	}
+	registerMetrics()
 	go s.enterpriseTokenCache.Watch()
+	if !s.readOnly {
+		go s.promoteStagedActivationCodes(s.ctx)
+	}
 	return s, nil
 }
 
 // Activate implements the Activate RPC
 func (a *apiServer) Activate(ctx context.Context, req *ec.ActivateRequest) (resp *ec.ActivateResponse, retErr error) {
-	a.LogReq(req)
-	defer func(start time.Time) { a.pachLogger.Log(req, resp, retErr, time.Since(start)) }(time.Now())
+	logger := a.logReq(ctx, req)
+	defer func(start time.Time) {
+		logger.Log(req, resp, retErr, time.Since(start))
+		countActivationRequest("activate", retErr)
+	}(time.Now())
+	if err := a.checkWritable(); err != nil {
+		return nil, err
+	}
 
+	code, err := a.activationCode(req)
+	if err != nil {
+		return nil, err
+	}
 	// Validate the activation code
-	expiration, err := license.Validate(req.ActivationCode)
+	expiration, err := a.validate(code)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error validating activation code")
 	}
@@ -87,43 +297,244 @@ func (a *apiServer) Activate(ctx context.Context, req *ec.ActivateRequest) (resp
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not convert expiration time \"%s\" to proto", expiration.String())
 	}
-	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+	var record *ec.EnterpriseRecord
+	if err := a.runSTM(ctx, func(stm col.STM) error {
 		e := a.enterpriseToken.ReadWrite(stm)
-		// blind write
-		return e.Put(enterpriseTokenKey, &ec.EnterpriseRecord{
-			ActivationCode: req.ActivationCode,
+		existing := &ec.EnterpriseRecord{}
+		if err := e.Get(enterpriseTokenKey, existing); err != nil && !col.IsErrNotFound(err) {
+			return err
+		}
+		existing = promoteStagedActivationCode(existing, a.now())
+		record = &ec.EnterpriseRecord{
+			ActivationCode: code,
 			Expires:        expirationProto,
-		})
+		}
+		if req.Add {
+			var err error
+			record, err = addActivationCode(existing, code, expiration, a.now())
+			if err != nil {
+				return err
+			}
+		}
+		// The staged activation code (if any) still replaces the activation
+		// codes once they expire.
+		record.StagedActivationCode = existing.StagedActivationCode
+		record.StagedExpires = existing.StagedExpires
+		record.StagedStartAfter = existing.StagedStartAfter
+		return e.Put(enterpriseTokenKey, record)
 	}); err != nil {
 		return nil, err
 	}
+	if err := a.waitForRecord(logger, req, record); err != nil {
+		return nil, err
+	}
 
-	// Wait until watcher observes the write
-	if err := backoff.Retry(func() error {
-		record, ok := a.enterpriseTokenCache.Load().(*ec.EnterpriseRecord)
+	return &ec.ActivateResponse{
+		Info: &ec.TokenInfo{
+			Expires: record.Expires,
+		},
+	}, nil
+}
+
+// waitForRecord waits until the enterprise token cache observes record, and
+// then gives the other pachd nodes time to observe it.
+func (a *apiServer) waitForRecord(logger log.Logger, req interface{}, record *ec.EnterpriseRecord) error {
+	if err := backoff.RetryNotify(func() error {
+		cached, ok := a.enterpriseTokenCache.Load().(*ec.EnterpriseRecord)
 		if !ok {
 			return errors.Errorf("could not retrieve enterprise expiration time")
 		}
-		expiration, err := types.TimestampFromProto(record.Expires)
+		if !proto.Equal(cached, record) {
+			return errors.Errorf("enterprise record not updated")
+		}
+		return nil
+	}, backoff.RetryEvery(time.Second), func(err error, _ time.Duration) error {
+		logger.LogAtLevelFromDepth(req, nil, errors.Wrapf(err, "waiting for the enterprise token cache"), 0, logrus.DebugLevel, 5)
+		return nil
+	}); err != nil {
+		return err
+	}
+	time.Sleep(time.Second) // give other pachd nodes time to observe the write
+	return nil
+}
+
+// StageActivationCode stages an activation code that replaces the active
+// activation codes once they expire, so that a renewed license can be
+// provided before the current one expires.
+func (a *apiServer) StageActivationCode(ctx context.Context, req *ec.StageActivationCodeRequest) (resp *ec.StageActivationCodeResponse, retErr error) {
+	logger := a.logReq(ctx, req)
+	defer func(start time.Time) { logger.Log(req, resp, retErr, time.Since(start)) }(time.Now())
+	if err := a.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	expiration, err := a.validate(req.ActivationCode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error validating activation code")
+	}
+	expirationProto, err := types.TimestampProto(expiration)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not convert expiration time \"%s\" to proto", expiration.String())
+	}
+	var record *ec.EnterpriseRecord
+	if err := a.runSTM(ctx, func(stm col.STM) error {
+		e := a.enterpriseToken.ReadWrite(stm)
+		record = &ec.EnterpriseRecord{}
+		if err := e.Get(enterpriseTokenKey, record); err != nil && !col.IsErrNotFound(err) {
+			return err
+		}
+		record = promoteStagedActivationCode(record, a.now())
+		if len(activationCodes(record)) == 0 {
+			return errors.Errorf("enterprise has not been activated, the activation code should be activated rather than staged")
+		}
+		startAfter := req.StartAfter
+		if startAfter == nil {
+			startAfter = record.Expires
+		}
+		startAfterTime, err := types.TimestampFromProto(startAfter)
 		if err != nil {
-			return errors.Wrapf(err, "could not parse expiration timestamp")
+			return errors.Wrapf(err, "could not parse start after timestamp")
 		}
-		if expiration.IsZero() {
-			return errors.Errorf("enterprise not activated")
+		if expiration.Before(startAfterTime) {
+			return errors.Errorf("activation code expires (%v) before it would replace the active activation codes (%v)", expiration, startAfterTime)
 		}
-		return nil
-	}, backoff.RetryEvery(time.Second)); err != nil {
+		record.StagedActivationCode = req.ActivationCode
+		record.StagedExpires = expirationProto
+		record.StagedStartAfter = startAfter
+		return e.Put(enterpriseTokenKey, record)
+	}); err != nil {
+		return nil, err
+	}
+	if err := a.waitForRecord(logger, req, record); err != nil {
 		return nil, err
 	}
-	time.Sleep(time.Second) // give other pachd nodes time to observe the write
 
-	return &ec.ActivateResponse{
+	return &ec.StageActivationCodeResponse{
 		Info: &ec.TokenInfo{
 			Expires: expirationProto,
 		},
+		StartAfter: record.StagedStartAfter,
+	}, nil
+}
+
+// promoteStagedActivationCode returns a record with the staged activation
+// code in record replacing the active activation codes, if they have expired
+// and the staged activation code's start time has passed. Otherwise, record
+// is returned.
+func promoteStagedActivationCode(record *ec.EnterpriseRecord, now time.Time) *ec.EnterpriseRecord {
+	if record.StagedActivationCode == "" {
+		return record
+	}
+	expiration, err := types.TimestampFromProto(record.Expires)
+	if err != nil || !now.After(expiration) {
+		return record
+	}
+	startAfter, err := types.TimestampFromProto(record.StagedStartAfter)
+	if err != nil || now.Before(startAfter) {
+		return record
+	}
+	return &ec.EnterpriseRecord{
+		ActivationCode: record.StagedActivationCode,
+		Expires:        record.StagedExpires,
+	}
+}
+
+// promoteStagedActivationCodes periodically replaces the expired activation
+// codes with the staged activation code, until ctx is done.
+func (a *apiServer) promoteStagedActivationCodes(ctx context.Context) {
+	ticker := time.NewTicker(promotionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if err := a.promote(ctx); err != nil && ctx.Err() == nil {
+			logrus.Errorf("error promoting the staged activation code: %v", err)
+		}
+	}
+}
+
+// promote replaces the expired activation codes with the staged activation
+// code, if it is due to replace them.
+func (a *apiServer) promote(ctx context.Context) error {
+	return a.runSTM(ctx, func(stm col.STM) error {
+		e := a.enterpriseToken.ReadWrite(stm)
+		record := &ec.EnterpriseRecord{}
+		if err := e.Get(enterpriseTokenKey, record); err != nil {
+			if col.IsErrNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		promoted := promoteStagedActivationCode(record, a.now())
+		if promoted == record {
+			return nil
+		}
+		return e.Put(enterpriseTokenKey, promoted)
+	})
+}
+
+// addActivationCode returns a record with the activation code added to the
+// active activation codes in record. Expired codes are replaced rather than
+// added to, and the earliest expiration of the codes is the record's
+// expiration.
+func addActivationCode(record *ec.EnterpriseRecord, code string, expiration, now time.Time) (*ec.EnterpriseRecord, error) {
+	expirationProto, err := types.TimestampProto(expiration)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not convert expiration time \"%s\" to proto", expiration.String())
+	}
+	codes := activationCodes(record)
+	if len(codes) == 0 {
+		return &ec.EnterpriseRecord{
+			ActivationCode: code,
+			Expires:        expirationProto,
+		}, nil
+	}
+	existingExpiration, err := types.TimestampFromProto(record.Expires)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse expiration timestamp")
+	}
+	if now.After(existingExpiration) {
+		return &ec.EnterpriseRecord{
+			ActivationCode: code,
+			Expires:        expirationProto,
+		}, nil
+	}
+	if !hasActivationCode(record, code) {
+		codes = append(codes, code)
+	}
+	if existingExpiration.Before(expiration) {
+		expirationProto = record.Expires
+	}
+	return &ec.EnterpriseRecord{
+		ActivationCode:  codes[0],
+		Expires:         expirationProto,
+		ActivationCodes: codes,
 	}, nil
 }
 
+// activationCodes returns the active activation codes in record.
+func activationCodes(record *ec.EnterpriseRecord) []string {
+	if len(record.ActivationCodes) > 0 {
+		return append([]string{}, record.ActivationCodes...)
+	}
+	if record.ActivationCode != "" {
+		return []string{record.ActivationCode}
+	}
+	return nil
+}
+
+func hasActivationCode(record *ec.EnterpriseRecord, code string) bool {
+	for _, c := range activationCodes(record) {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
 // GetState returns the current state of the cluster's Pachyderm Enterprise key (ACTIVE, EXPIRED, or NONE), without the activation code
 func (a *apiServer) GetState(ctx context.Context, req *ec.GetStateRequest) (resp *ec.GetStateResponse, retErr error) {
 	record, err := a.getEnterpriseRecord()
@@ -132,8 +543,9 @@ func (a *apiServer) GetState(ctx context.Context, req *ec.GetStateRequest) (resp
 	}
 
 	resp = &ec.GetStateResponse{
-		Info:  record.Info,
-		State: record.State,
+		Info:        record.Info,
+		State:       record.State,
+		Deactivated: record.Deactivated,
 	}
 
 	if record.ActivationCode != "" {
@@ -156,9 +568,51 @@ func (a *apiServer) GetState(ctx context.Context, req *ec.GetStateRequest) (resp
 
 // GetActivationCode returns the current state of the cluster's Pachyderm Enterprise key (ACTIVE, EXPIRED, or NONE), including the enterprise activation code
 func (a *apiServer) GetActivationCode(ctx context.Context, req *ec.GetActivationCodeRequest) (resp *ec.GetActivationCodeResponse, retErr error) {
-	a.LogReq(req)
-	defer func(start time.Time) { a.pachLogger.Log(req, resp, retErr, time.Since(start)) }(time.Now())
-	return a.getEnterpriseRecord()
+	logger := a.logReq(ctx, req)
+	defer func(start time.Time) { logger.Log(req, resp, retErr, time.Since(start)) }(time.Now())
+	if err := a.limitActivationCode(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := a.getEnterpriseRecord()
+	if err != nil {
+		return nil, err
+	}
+	if resp.State == ec.State_ACTIVE {
+		if resp.Entitlement, err = a.entitlement(resp.ActivationCodes, resp.Info.Expires); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// limitActivationCode applies the rate limit on GetActivationCode to the
+// caller in ctx, and returns a ResourceExhausted error if the caller is over
+// the limit.
+func (a *apiServer) limitActivationCode(ctx context.Context) error {
+	if a.activationCodeLimit <= 0 {
+		return nil
+	}
+	subject, err := a.subject(ctx)
+	if err != nil {
+		return err
+	}
+	if !a.activationCodeLimiter(subject).Allow() {
+		return status.Errorf(codes.ResourceExhausted, "too many GetActivationCode calls from %v, the limit is %v per second", subject, float64(a.activationCodeLimit))
+	}
+	return nil
+}
+
+// activationCodeLimiter returns the GetActivationCode rate limiter for
+// subject, creating it if this is the subject's first call.
+func (a *apiServer) activationCodeLimiter(subject string) *rate.Limiter {
+	a.limitersMu.Lock()
+	defer a.limitersMu.Unlock()
+	limiter, ok := a.activationCodeLimiters[subject]
+	if !ok {
+		limiter = rate.NewLimiter(a.activationCodeLimit, a.activationCodeBurst)
+		a.activationCodeLimiters[subject] = limiter
+	}
+	return limiter
 }
 
 func (a *apiServer) getEnterpriseRecord() (*ec.GetActivationCodeResponse, error) {
@@ -166,20 +620,33 @@ func (a *apiServer) getEnterpriseRecord() (*ec.GetActivationCodeResponse, error)
 	if !ok {
 		return nil, errors.Errorf("could not retrieve enterprise expiration time")
 	}
+	// The staged activation code replaces the expired activation codes
+	// before it is promoted in etcd.
+	now := a.now()
+	record = promoteStagedActivationCode(record, now)
 	expiration, err := types.TimestampFromProto(record.Expires)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not parse expiration timestamp")
 	}
 	if expiration.IsZero() {
-		return &ec.GetActivationCodeResponse{State: ec.State_NONE}, nil
+		return &ec.GetActivationCodeResponse{
+			State:       ec.State_NONE,
+			Deactivated: record.Deactivated,
+		}, nil
 	}
 	resp := &ec.GetActivationCodeResponse{
 		Info: &ec.TokenInfo{
 			Expires: record.Expires,
 		},
-		ActivationCode: record.ActivationCode,
+		ActivationCode:  record.ActivationCode,
+		ActivationCodes: activationCodes(record),
+	}
+	if record.StagedActivationCode != "" {
+		resp.StagedInfo = &ec.TokenInfo{
+			Expires: record.StagedExpires,
+		}
 	}
-	if time.Now().After(expiration) {
+	if now.After(expiration) {
 		resp.State = ec.State_EXPIRED
 	} else {
 		resp.State = ec.State_ACTIVE
@@ -187,47 +654,116 @@ func (a *apiServer) getEnterpriseRecord() (*ec.GetActivationCodeResponse, error)
 	return resp, nil
 }
 
-// Deactivate deletes the current cluster's enterprise token, and puts the
-// cluster in the "NONE" enterprise state. It also deletes all data in the
-// cluster, to avoid invalid cluster states. This call only makes sense for
-// testing
+// Deactivate replaces the current cluster's enterprise token with a
+// tombstone, and puts the cluster in the "NONE" enterprise state. It also
+// deletes all data in the cluster, to avoid invalid cluster states. This call
+// only makes sense for testing
+//
+// A request without a confirmation token does not deactivate the cluster, it
+// only returns a confirmation token that must be passed to a second request.
 func (a *apiServer) Deactivate(ctx context.Context, req *ec.DeactivateRequest) (resp *ec.DeactivateResponse, retErr error) {
-	a.LogReq(req)
-	defer func(start time.Time) { a.pachLogger.Log(req, resp, retErr, time.Since(start)) }(time.Now())
-
-	pachClient := a.env.GetPachClient(ctx)
-	if err := pachClient.DeleteAll(); err != nil {
-		return nil, errors.Wrapf(err, "could not delete all pachyderm data")
+	logger := a.logReq(ctx, req)
+	defer func(start time.Time) {
+		logger.Log(req, resp, retErr, time.Since(start))
+		countActivationRequest("deactivate", retErr)
+	}(time.Now())
+	if err := a.checkWritable(); err != nil {
+		return nil, err
 	}
 
-	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
-		err := a.enterpriseToken.ReadWrite(stm).Delete(enterpriseTokenKey)
-		if err != nil && !col.IsErrNotFound(err) {
+	if req.ConfirmationToken == "" {
+		token := uuid.NewWithoutDashes()
+		if err := a.runSTM(ctx, func(stm col.STM) error {
+			return a.deactivationTokens.ReadWrite(stm).PutTTL(token, &types.Empty{}, deactivationTokenTTLSecs)
+		}); err != nil {
+			return nil, errors.Wrapf(err, "could not create deactivation confirmation token")
+		}
+		return &ec.DeactivateResponse{ConfirmationToken: token}, nil
+	}
+	// Tokens can only be used once.
+	if err := a.runSTM(ctx, func(stm col.STM) error {
+		deactivationTokens := a.deactivationTokens.ReadWrite(stm)
+		if err := deactivationTokens.Get(req.ConfirmationToken, &types.Empty{}); err != nil {
+			if col.IsErrNotFound(err) {
+				return errors.Errorf("invalid or expired deactivation confirmation token")
+			}
 			return err
 		}
-		return nil
+		return deactivationTokens.Delete(req.ConfirmationToken)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := a.deleteAll(ctx); err != nil {
+		return nil, errors.Wrapf(err, "could not delete all pachyderm data")
+	}
+
+	// Replace the activation codes with a tombstone, rather than deleting
+	// them, so that the cluster is reported as deactivated rather than never
+	// activated.
+	tombstoneExpires, err := types.TimestampProto(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	tombstone := &ec.EnterpriseRecord{
+		Expires:     tombstoneExpires,
+		Deactivated: true,
+	}
+	if err := a.runSTM(ctx, func(stm col.STM) error {
+		return a.enterpriseToken.ReadWrite(stm).Put(enterpriseTokenKey, tombstone)
 	}); err != nil {
 		return nil, err
 	}
 
 	// Wait until watcher observes the write
-	if err := backoff.Retry(func() error {
+	if err := backoff.RetryNotify(func() error {
 		record, ok := a.enterpriseTokenCache.Load().(*ec.EnterpriseRecord)
 		if !ok {
 			return errors.Errorf("could not retrieve enterprise expiration time")
 		}
-		expiration, err := types.TimestampFromProto(record.Expires)
-		if err != nil {
-			return errors.Wrapf(err, "could not parse expiration timestamp")
-		}
-		if !expiration.IsZero() {
+		if !proto.Equal(record, tombstone) {
 			return errors.Errorf("enterprise still activated")
 		}
 		return nil
-	}, backoff.RetryEvery(time.Second)); err != nil {
+	}, backoff.RetryEvery(time.Second), func(err error, _ time.Duration) error {
+		logger.LogAtLevelFromDepth(req, nil, errors.Wrapf(err, "waiting for the enterprise token cache"), 0, logrus.DebugLevel, 5)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 	time.Sleep(time.Second) // give other pachd nodes time to observe the write
 
 	return &ec.DeactivateResponse{}, nil
 }
+
+// runSTM runs apply in an STM, retrying with backoff if etcd fails with a
+// transient error. Other errors (including errors returned by apply) are
+// returned immediately.
+func (a *apiServer) runSTM(ctx context.Context, apply func(col.STM) error) error {
+	var stmErr error
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		_, stmErr = a.newSTM(ctx, a.env.GetEtcdClient(), apply)
+		if stmErr != nil && isTransientEtcdError(stmErr) {
+			return stmErr
+		}
+		return nil
+	}, backoff.New10sBackOff(), backoff.NotifyCtx(ctx, "enterprise token write")); err != nil {
+		return err
+	}
+	return stmErr
+}
+
+// isTransientEtcdError returns true if err indicates that etcd was
+// temporarily unable to serve a request (e.g. no leader or a leader change),
+// so the request may succeed if it is retried.
+func isTransientEtcdError(err error) bool {
+	var etcdErr rpctypes.EtcdError
+	if errors.As(err, &etcdErr) {
+		return isTransientCode(etcdErr.Code())
+	}
+	return isTransientCode(status.Code(err))
+}
+
+func isTransientCode(code codes.Code) bool {
+	return code == codes.Unavailable || code == codes.ResourceExhausted
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// DecodeActivationCode validates an activation code and returns what it
+// grants. It's stateless: the code isn't activated or stored, and the
+// cluster's enterprise state isn't read or written.
+func (a *apiServer) DecodeActivationCode(ctx context.Context, req *ec.DecodeActivationCodeRequest) (resp *ec.DecodeActivationCodeResponse, retErr error) {
+	// The request isn't logged, as it contains the activation code.
+	logger := a.logReq(ctx, nil)
+	defer func(start time.Time) { logger.Log(nil, resp, retErr, time.Since(start)) }(time.Now())
+
+	token, expiration, err := a.decode(req.ActivationCode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error validating activation code")
+	}
+	expires, err := types.TimestampProto(expiration)
+	if err != nil {
+		return nil, err
+	}
+	return &ec.DecodeActivationCodeResponse{
+		Entitlement: &ec.Entitlement{
+			Expires:  expires,
+			Customer: token.Customer,
+			Features: token.Features,
+			Seats:    token.Seats,
+		},
+	}, nil
+}
+
+// entitlement decodes the active activation codes and combines what they
+// grant. The customer is taken from the first code, and the expiration is the
+// record's, which is already the earliest of the codes' expirations.
+func (a *apiServer) entitlement(codes []string, expires *types.Timestamp) (*ec.Entitlement, error) {
+	entitlement := &ec.Entitlement{Expires: expires}
+	features := make(map[string]bool)
+	for _, code := range codes {
+		token, _, err := a.decode(code)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding active activation code")
+		}
+		if entitlement.Customer == "" {
+			entitlement.Customer = token.Customer
+		}
+		for _, feature := range token.Features {
+			if !features[feature] {
+				features[feature] = true
+				entitlement.Features = append(entitlement.Features, feature)
+			}
+		}
+		entitlement.Seats += token.Seats
+	}
+	return entitlement, nil
+}
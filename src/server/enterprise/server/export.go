@@ -0,0 +1,151 @@
+package server
+
+import (
+	"bytes"
+	"net/url"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// redactedURL is the webhook URL that is exported if the configured URL can't
+// be parsed (and so its secrets can't be removed).
+const redactedURL = "<redacted>"
+
+// ExportState returns the cluster's enterprise record and the server's
+// configuration as JSON. Deactivation confirmation tokens are not exported
+// (new ones can be requested from the importing server), and the webhook
+// URL's credentials are redacted.
+func (a *apiServer) ExportState(ctx context.Context, req *ec.ExportStateRequest) (resp *ec.ExportStateResponse, retErr error) {
+	logger := a.logReq(ctx, req)
+	// The response isn't logged, as it contains the activation codes.
+	defer func(start time.Time) { logger.Log(req, nil, retErr, time.Since(start)) }(time.Now())
+
+	record, ok := a.enterpriseTokenCache.Load().(*ec.EnterpriseRecord)
+	if !ok {
+		return nil, errors.Errorf("could not retrieve enterprise record")
+	}
+	state := &ec.ExportedState{
+		Record: record,
+		Config: &ec.ExportedConfig{
+			ActivationCodeRateLimit: float64(a.activationCodeLimit),
+			ActivationCodeRateBurst: int64(a.activationCodeBurst),
+			WebhookUrl:              redactURL(a.webhookURL),
+			ExpiringSoonThreshold:   types.DurationProto(expiringSoonThreshold),
+		},
+	}
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{Indent: "  "}).Marshal(&buf, state); err != nil {
+		return nil, errors.Wrapf(err, "could not marshal enterprise state")
+	}
+	return &ec.ExportStateResponse{State: buf.Bytes()}, nil
+}
+
+// ImportState restores an enterprise record exported by ExportState. The
+// cluster must not have any active activation codes, and the imported
+// activation codes must still be valid. The exported configuration is not
+// restored.
+func (a *apiServer) ImportState(ctx context.Context, req *ec.ImportStateRequest) (resp *ec.ImportStateResponse, retErr error) {
+	// The request isn't logged, as it contains the activation codes.
+	logger := a.logReq(ctx, nil)
+	defer func(start time.Time) { logger.Log(nil, resp, retErr, time.Since(start)) }(time.Now())
+	if err := a.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	state := &ec.ExportedState{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(req.State), state); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal enterprise state")
+	}
+	record := state.Record
+	if record == nil {
+		return nil, errors.Errorf("enterprise state has no enterprise record")
+	}
+	if err := a.validateRecord(record); err != nil {
+		return nil, err
+	}
+	if err := a.runSTM(ctx, func(stm col.STM) error {
+		e := a.enterpriseToken.ReadWrite(stm)
+		existing := &ec.EnterpriseRecord{}
+		if err := e.Get(enterpriseTokenKey, existing); err != nil && !col.IsErrNotFound(err) {
+			return err
+		}
+		if len(activationCodes(existing)) > 0 {
+			return errors.Errorf("enterprise is already activated, it must be deactivated before enterprise state can be imported")
+		}
+		return e.Put(enterpriseTokenKey, record)
+	}); err != nil {
+		return nil, err
+	}
+	if err := a.waitForRecord(logger, nil, record); err != nil {
+		return nil, err
+	}
+	return &ec.ImportStateResponse{}, nil
+}
+
+// validateRecord validates the activation codes in an imported record, and
+// checks that the record's expirations aren't later than the codes' signed
+// expirations.
+func (a *apiServer) validateRecord(record *ec.EnterpriseRecord) error {
+	if record.Expires == nil {
+		return errors.Errorf("enterprise record has no expiration")
+	}
+	var earliest time.Time
+	for _, code := range activationCodes(record) {
+		expiration, err := a.validate(code)
+		if err != nil {
+			return errors.Wrapf(err, "error validating activation code")
+		}
+		if earliest.IsZero() || expiration.Before(earliest) {
+			earliest = expiration
+		}
+	}
+	if err := checkExpiration(record.Expires, earliest); err != nil {
+		return err
+	}
+	if record.StagedActivationCode != "" {
+		expiration, err := a.validate(record.StagedActivationCode)
+		if err != nil {
+			return errors.Wrapf(err, "error validating staged activation code")
+		}
+		if err := checkExpiration(record.StagedExpires, expiration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkExpiration returns an error if expires is later than the signed
+// expiration of its activation codes.
+func checkExpiration(expires *types.Timestamp, signed time.Time) error {
+	expiration, err := types.TimestampFromProto(expires)
+	if err != nil {
+		return errors.Wrapf(err, "could not parse expiration timestamp")
+	}
+	if expiration.After(signed) {
+		return errors.Errorf("enterprise record expires (%v) after its activation codes (%v)", expiration, signed)
+	}
+	return nil
+}
+
+// redactURL removes the user info, query and fragment from rawURL, which may
+// contain credentials.
+func redactURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redactedURL
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
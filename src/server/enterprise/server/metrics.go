@@ -0,0 +1,44 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+var (
+	// activationRequestCount is a counter tracking the number of Activate and
+	// Deactivate calls, by method and outcome (succeeded|failed)
+	activationRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pachd_enterprise",
+			Name:      "activation_request_count",
+			Help:      "Number of Activate and Deactivate calls by method and outcome (succeeded|failed)",
+		},
+		[]string{
+			"method",
+			"outcome",
+		},
+	)
+)
+
+func registerMetrics() {
+	if err := prometheus.Register(activationRequestCount); err != nil {
+		// metrics may be redundantly registered; ignore these errors
+		if !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+			logrus.Errorf("error registering prometheus metric: %v", err)
+		}
+	}
+}
+
+// countActivationRequest records the outcome of a call to method, which is
+// "activate" or "deactivate".
+func countActivationRequest(method string, err error) {
+	outcome := "succeeded"
+	if err != nil {
+		outcome = "failed"
+	}
+	activationRequestCount.WithLabelValues(method, outcome).Inc()
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// activationCodeEnvPrefix is the prefix of the environment variables that the
+// default CodeResolver reads. Activate is unauthenticated, so references to
+// the other environment variables are rejected.
+const activationCodeEnvPrefix = "ENTERPRISE_ACTIVATION_CODE"
+
+// CodeResolver resolves a reference to an activation code (see
+// ActivateRequest.ActivationCodeRef) to the code.
+type CodeResolver func(ref string) (string, error)
+
+// WithCodeResolver sets the resolver of the activation code references in
+// Activate requests. By default, a reference is the name of an environment
+// variable (which must start with ENTERPRISE_ACTIVATION_CODE) containing the
+// activation code, for example one populated from a Kubernetes secret.
+func WithCodeResolver(resolve CodeResolver) Option {
+	return func(a *apiServer) {
+		a.resolveCode = resolve
+	}
+}
+
+// envCodeResolver is the default CodeResolver, which resolves a reference to
+// the value of the environment variable it names.
+func envCodeResolver(ref string) (string, error) {
+	if !strings.HasPrefix(ref, activationCodeEnvPrefix) {
+		return "", errors.Errorf("activation code reference %q must be an environment variable starting with %v", ref, activationCodeEnvPrefix)
+	}
+	code, ok := os.LookupEnv(ref)
+	if !ok || code == "" {
+		return "", errors.Errorf("environment variable %v is not set", ref)
+	}
+	return code, nil
+}
+
+// activationCode returns the activation code in req, which is resolved if the
+// request has a reference to it.
+func (a *apiServer) activationCode(req *ec.ActivateRequest) (string, error) {
+	if req.ActivationCodeRef == "" {
+		return req.ActivationCode, nil
+	}
+	if req.ActivationCode != "" {
+		return "", status.Error(codes.InvalidArgument, "an activation code and an activation code reference can't both be set")
+	}
+	code, err := a.resolveCode(req.ActivationCodeRef)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not resolve activation code reference")
+	}
+	return code, nil
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	ec "github.com/pachyderm/pachyderm/src/client/enterprise"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+const (
+	// The license states that are posted to the webhook.
+	licenseStateNone         = "none"
+	licenseStateActivated    = "activated"
+	licenseStateExpiringSoon = "expiring-soon"
+	licenseStateExpired      = "expired"
+	licenseStateDeactivated  = "deactivated"
+
+	// expiringSoonThreshold is how long before its expiration an activation
+	// code is reported as expiring soon.
+	expiringSoonThreshold = 7 * 24 * time.Hour
+
+	// webhookCheckInterval is how often the license state is checked for
+	// changes that aren't caused by a write to etcd (i.e. the activation code
+	// expiring, or being about to expire).
+	webhookCheckInterval = time.Minute
+
+	// webhookInitTimeout is the amount of time to wait for the initial
+	// license state to be read from etcd.
+	webhookInitTimeout = time.Minute
+
+	// webhookRetryTimeout is how long a notification is retried for before
+	// it's given up on. A notification that is given up on is retried at the
+	// next check.
+	webhookRetryTimeout = 5 * time.Minute
+)
+
+// licenseStateChange is the payload that is posted to the webhook when the
+// license state changes.
+type licenseStateChange struct {
+	PreviousState string `json:"previous_state"`
+	State         string `json:"state"`
+	// Expires is the expiration of the activation code, in RFC 3339 format.
+	// It's empty if the cluster has no activation code.
+	Expires string `json:"expires,omitempty"`
+}
+
+// WithWebhook makes the server POST a JSON payload to url each time the
+// cluster's license state changes (i.e. it's activated, expiring soon,
+// expired or deactivated). Failed notifications are retried with backoff. An
+// empty url disables the notifications.
+func WithWebhook(url string) Option {
+	return func(a *apiServer) {
+		a.webhookURL = url
+	}
+}
+
+// licenseState returns the license state of record at time now, and the
+// expiration of its activation code (if it has one).
+func licenseState(record *ec.EnterpriseRecord, now time.Time) (string, time.Time, error) {
+	record = promoteStagedActivationCode(record, now)
+	expiration, err := types.TimestampFromProto(record.Expires)
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "could not parse expiration timestamp")
+	}
+	switch {
+	case expiration.IsZero() && record.Deactivated:
+		return licenseStateDeactivated, expiration, nil
+	case expiration.IsZero():
+		return licenseStateNone, expiration, nil
+	case now.After(expiration):
+		return licenseStateExpired, expiration, nil
+	case expiration.Sub(now) < expiringSoonThreshold:
+		return licenseStateExpiringSoon, expiration, nil
+	default:
+		return licenseStateActivated, expiration, nil
+	}
+}
+
+// initWebhook reads the current license state from etcd. The current state
+// isn't posted to the webhook, so that restarting pachd doesn't repeat the
+// last notification.
+func (a *apiServer) initWebhook(ctx context.Context) error {
+	record := &ec.EnterpriseRecord{}
+	if err := a.enterpriseToken.ReadOnly(ctx).Get(enterpriseTokenKey, record); err != nil {
+		if !col.IsErrNotFound(err) {
+			return err
+		}
+		record = a.enterpriseTokenCache.Load().(*ec.EnterpriseRecord)
+	}
+	state, _, err := licenseState(record, a.now())
+	if err != nil {
+		return err
+	}
+	a.webhookState = state
+	return nil
+}
+
+// licenseStateChanged is called by the enterprise token cache each time the
+// cached record changes. It doesn't block the cache's watcher.
+func (a *apiServer) licenseStateChanged(proto.Message) {
+	select {
+	case a.webhookChanged <- struct{}{}:
+	default:
+		// A check is already pending.
+	}
+}
+
+// notifyLicenseStateChanges should be called in a goroutine; it checks the
+// license state each time the enterprise token changes, and every
+// webhookCheckInterval.
+func (a *apiServer) notifyLicenseStateChanges() {
+	ticker := time.NewTicker(webhookCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.webhookChanged:
+		case <-ticker.C:
+		}
+		if err := a.checkLicenseState(context.Background()); err != nil {
+			logrus.Errorf("error notifying the enterprise webhook: %v", err)
+		}
+	}
+}
+
+// checkLicenseState posts the license state to the webhook if it has changed
+// since the last successful notification.
+func (a *apiServer) checkLicenseState(ctx context.Context) error {
+	a.webhookMu.Lock()
+	defer a.webhookMu.Unlock()
+	record, ok := a.enterpriseTokenCache.Load().(*ec.EnterpriseRecord)
+	if !ok {
+		return errors.Errorf("could not retrieve enterprise record")
+	}
+	state, expiration, err := licenseState(record, a.now())
+	if err != nil {
+		return err
+	}
+	if state == a.webhookState {
+		return nil
+	}
+	change := &licenseStateChange{
+		PreviousState: a.webhookState,
+		State:         state,
+	}
+	if !expiration.IsZero() {
+		change.Expires = expiration.Format(time.RFC3339)
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = webhookRetryTimeout
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		return a.postWebhook(ctx, change)
+	}, b, backoff.NotifyCtx(ctx, "enterprise webhook")); err != nil {
+		return err
+	}
+	a.webhookState = state
+	return nil
+}
+
+// postWebhook posts change to the webhook.
+func (a *apiServer) postWebhook(ctx context.Context, change *licenseStateChange) error {
+	body, err := json.Marshal(change)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal license state change")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "could not create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.webhookClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not post to webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned status %v", resp.Status)
+	}
+	return nil
+}
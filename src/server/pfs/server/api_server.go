@@ -57,6 +57,11 @@ func newAPIServer(env *serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv, etc
 	return s, nil
 }
 
+// Close implements the APIServer interface.
+func (a *apiServer) Close() {
+	a.driver.close()
+}
+
 // CreateRepoInTransaction is identical to CreateRepo except that it can run
 // inside an existing etcd STM transaction.  This is not an RPC.
 func (a *apiServer) CreateRepoInTransaction(txnCtx *txnenv.TransactionContext, request *pfs.CreateRepoRequest) error {
@@ -406,7 +411,10 @@ func appendFileTar(uw *fileset.UnorderedWriter, server modifyFileSource, req *pf
 			}
 			return tfsr.bytesRead, err
 		}
-		if hdr.Typeflag == tar.TypeDir {
+		if err := fileset.CheckTarEntry(hdr); err != nil {
+			return tfsr.bytesRead, err
+		}
+		if fileset.SkipTarEntry(hdr) {
 			continue
 		}
 		if err := uw.Append(hdr.Name, req.Overwrite, tr, req.Tag); err != nil {
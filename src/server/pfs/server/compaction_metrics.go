@@ -0,0 +1,39 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// compactionDepth is the distribution of the depths of the compactions' merge
+// trees. A compaction whose inputs fit in the max fan in has a depth of one,
+// and each additional level of merges adds one to the depth.
+var compactionDepth = prometheus.NewHistogram(
+	prometheus.HistogramOpts{
+		Namespace: "pachyderm",
+		Subsystem: "pachd_pfs",
+		Name:      "compaction_fan_in_depth",
+		Help:      "depth of the merge tree of pfs compactions, histogram by depth (levels)",
+		Buckets:   prometheus.LinearBuckets(1, 1, 8),
+	},
+)
+
+var registerCompactionDepthOnce sync.Once
+
+// observeCompactionDepth records the merge tree depth of a compaction,
+// registering the histogram with the default registry the first time.
+func observeCompactionDepth(depth int) {
+	registerCompactionDepthOnce.Do(func() {
+		if err := prometheus.Register(compactionDepth); err != nil {
+			// metrics may be redundantly registered; ignore these errors
+			if !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+				logrus.Infof("error registering prometheus metric: %v", err)
+			}
+		}
+	})
+	compactionDepth.Observe(float64(depth))
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"golang.org/x/net/context"
+	"golang.org/x/sync/semaphore"
+)
+
+// compactionCandidate describes a commit that needs to be compacted.
+type compactionCandidate struct {
+	// numFileSets is the number of file sets in the commit, which is a
+	// measure of its fragmentation.
+	numFileSets int
+	// started is when the commit was started.
+	started time.Time
+}
+
+func (d *driver) newCompactionCandidate(ctx context.Context, commitInfo *pfs.CommitInfo, commitPath string) (*compactionCandidate, error) {
+	c := &compactionCandidate{}
+	if err := d.storage.Store().Walk(ctx, commitPath, func(_ string) error {
+		c.numFileSets++
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if commitInfo.Started != nil {
+		started, err := types.TimestampFromProto(commitInfo.Started)
+		if err != nil {
+			return nil, err
+		}
+		c.started = started
+	}
+	return c, nil
+}
+
+// compactionScore scores a compaction candidate. Candidates with higher
+// scores are compacted first.
+type compactionScore func(*compactionCandidate) float64
+
+// compactionScores are the scores that can be configured for prioritizing
+// compactions.
+var compactionScores = map[string]compactionScore{
+	// fifo compacts commits in the order that they were finished.
+	"fifo": func(_ *compactionCandidate) float64 {
+		return 0
+	},
+	// fragmentation compacts the most fragmented commits first.
+	"fragmentation": func(c *compactionCandidate) float64 {
+		return float64(c.numFileSets)
+	},
+	// age compacts the oldest commits first.
+	"age": func(c *compactionCandidate) float64 {
+		return time.Since(c.started).Seconds()
+	},
+}
+
+// compactionScheduler limits the number of compactions that run at once, and
+// dispatches the pending compactions in priority order.
+type compactionScheduler struct {
+	limit   int
+	score   compactionScore
+	mu      sync.Mutex
+	running int
+	pending compactionHeap
+	seq     uint64
+}
+
+// newCompactionScheduler creates a compaction scheduler that runs at most
+// limit compactions at once (zero means no limit), prioritized by the
+// named score.
+func newCompactionScheduler(limit int, score string) (*compactionScheduler, error) {
+	if score == "" {
+		score = "fifo"
+	}
+	scoreFunc, ok := compactionScores[score]
+	if !ok {
+		return nil, errors.Errorf("unknown compaction priority %q", score)
+	}
+	return &compactionScheduler{
+		limit: limit,
+		score: scoreFunc,
+	}, nil
+}
+
+// schedule runs f once the compaction for the candidate is dispatched.
+// Compactions with equal scores are dispatched in the order they were
+// scheduled.
+func (cs *compactionScheduler) schedule(ctx context.Context, c *compactionCandidate, f func() error) error {
+	if cs.limit <= 0 {
+		return f()
+	}
+	item := &compactionItem{
+		score: cs.score(c),
+		ready: make(chan struct{}),
+	}
+	cs.mu.Lock()
+	item.seq = cs.seq
+	cs.seq++
+	heap.Push(&cs.pending, item)
+	cs.dispatch()
+	cs.mu.Unlock()
+	select {
+	case <-item.ready:
+	case <-ctx.Done():
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		if item.index >= 0 {
+			heap.Remove(&cs.pending, item.index)
+			return ctx.Err()
+		}
+		// The compaction was dispatched while the context was being canceled.
+		cs.running--
+		cs.dispatch()
+		return ctx.Err()
+	}
+	defer func() {
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		cs.running--
+		cs.dispatch()
+	}()
+	return f()
+}
+
+// dispatch dispatches pending compactions until the limit is reached.
+// The caller must hold cs.mu.
+func (cs *compactionScheduler) dispatch() {
+	for cs.running < cs.limit && cs.pending.Len() > 0 {
+		item := heap.Pop(&cs.pending).(*compactionItem)
+		cs.running++
+		close(item.ready)
+	}
+}
+
+// compactionBytesLimiter limits the content bytes of the compaction shards
+// that are in flight at once, which bounds the memory used by compaction.
+type compactionBytesLimiter struct {
+	max int64
+	sem *semaphore.Weighted
+}
+
+// newCompactionBytesLimiter creates a limiter that allows at most max bytes
+// in flight (zero means no limit). A nil limiter does not limit.
+func newCompactionBytesLimiter(max int64) *compactionBytesLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &compactionBytesLimiter{
+		max: max,
+		sem: semaphore.NewWeighted(max),
+	}
+}
+
+// acquire blocks until n bytes are available, and returns a function that
+// releases them. A shard larger than the limit acquires the whole limit, so
+// it runs on its own rather than blocking forever.
+func (l *compactionBytesLimiter) acquire(ctx context.Context, n int64) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if n > l.max {
+		n = l.max
+	}
+	if err := l.sem.Acquire(ctx, n); err != nil {
+		return nil, err
+	}
+	return func() { l.sem.Release(n) }, nil
+}
+
+type compactionItem struct {
+	score float64
+	seq   uint64
+	ready chan struct{}
+	index int
+}
+
+// compactionHeap is a max heap of compaction items ordered by score, then
+// by the order they were scheduled.
+type compactionHeap []*compactionItem
+
+func (h compactionHeap) Len() int {
+	return len(h)
+}
+
+func (h compactionHeap) Less(i, j int) bool {
+	if h[i].score != h[j].score {
+		return h[i].score > h[j].score
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h compactionHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *compactionHeap) Push(x interface{}) {
+	item := x.(*compactionItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *compactionHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	item.index = -1
+	*h = old[:len(old)-1]
+	return item
+}
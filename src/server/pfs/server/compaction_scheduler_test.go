@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+func TestCompactionSchedulerPriority(t *testing.T) {
+	cs, err := newCompactionScheduler(1, "fragmentation")
+	require.NoError(t, err)
+	ctx := context.Background()
+	// Block the scheduler with a running compaction, so the following
+	// compactions are queued.
+	running := make(chan struct{})
+	unblock := make(chan struct{})
+	go func() {
+		cs.schedule(ctx, &compactionCandidate{}, func() error {
+			close(running)
+			<-unblock
+			return nil
+		})
+	}()
+	<-running
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	numFileSets := []int{3, 10, 1, 7, 5}
+	for _, n := range numFileSets {
+		n := n
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, cs.schedule(ctx, &compactionCandidate{numFileSets: n}, func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, n)
+				return nil
+			}))
+		}()
+	}
+	require.NoError(t, backoff.Retry(func() error {
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		if cs.pending.Len() != len(numFileSets) {
+			return errors.Errorf("expected %v pending compactions, got %v", len(numFileSets), cs.pending.Len())
+		}
+		return nil
+	}, backoff.RetryEvery(10*time.Millisecond).For(10*time.Second)))
+	close(unblock)
+	wg.Wait()
+	require.Equal(t, []int{10, 7, 5, 3, 1}, order)
+}
+
+func TestCompactionSchedulerCancel(t *testing.T) {
+	cs, err := newCompactionScheduler(1, "fifo")
+	require.NoError(t, err)
+	running := make(chan struct{})
+	unblock := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cs.schedule(context.Background(), &compactionCandidate{}, func() error {
+			close(running)
+			<-unblock
+			return nil
+		})
+	}()
+	<-running
+	// A queued compaction is removed from the queue when its context is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = cs.schedule(ctx, &compactionCandidate{}, func() error {
+		return errors.Errorf("canceled compaction should not run")
+	})
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.Equal(t, 0, cs.pending.Len())
+	close(unblock)
+	<-done
+	// The slot is released after the running compaction completes.
+	require.NoError(t, cs.schedule(context.Background(), &compactionCandidate{}, func() error {
+		return nil
+	}))
+	_, err = newCompactionScheduler(1, "unknown")
+	require.YesError(t, err)
+}
+
+func TestCompactionBytesLimiter(t *testing.T) {
+	var maxInFlight int64 = 100 * units.MB
+	l := newCompactionBytesLimiter(maxInFlight)
+	ctx := context.Background()
+	var inFlight, peak int64
+	var wg sync.WaitGroup
+	// The sizes include a shard larger than the ceiling, which must still be
+	// compacted (on its own).
+	sizes := []int64{60 * units.MB, 30 * units.MB, 90 * units.MB, 250 * units.MB, 10 * units.MB, 45 * units.MB, 70 * units.MB, 0}
+	for i := 0; i < 4; i++ {
+		for _, size := range sizes {
+			size := size
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				release, err := l.acquire(ctx, size)
+				require.NoError(t, err)
+				defer release()
+				// Charge the clamped size, which is what the limiter holds.
+				charged := size
+				if charged > maxInFlight {
+					charged = maxInFlight
+				}
+				n := atomic.AddInt64(&inFlight, charged)
+				for {
+					p := atomic.LoadInt64(&peak)
+					if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt64(&inFlight, -charged)
+			}()
+		}
+	}
+	wg.Wait()
+	require.True(t, peak <= maxInFlight, "in flight bytes %v exceeded the ceiling %v", peak, maxInFlight)
+	require.True(t, peak > 0)
+	// Acquiring blocks when the ceiling is reached, until the context is done.
+	release, err := l.acquire(ctx, maxInFlight)
+	require.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(timeoutCtx, 1)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	release()
+	release, err = l.acquire(ctx, 1)
+	require.NoError(t, err)
+	release()
+	// A nil limiter does not limit.
+	release, err = newCompactionBytesLimiter(0).acquire(ctx, 1000*units.GB)
+	require.NoError(t, err)
+	release()
+}
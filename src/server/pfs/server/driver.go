@@ -82,8 +82,15 @@ type driver struct {
 	branches    collectionFactory
 	openCommits col.Collection
 
-	storage         *fileset.Storage
-	compactionQueue *work.TaskQueue
+	storage                *fileset.Storage
+	compactionQueue        *work.TaskQueue
+	compactionScheduler    *compactionScheduler
+	compactionBytesLimiter *compactionBytesLimiter
+	// cancel stops the driver's background work (e.g. the compaction
+	// worker), and workerDone is closed when the compaction worker has
+	// drained.
+	cancel     context.CancelFunc
+	workerDone chan struct{}
 
 	// TODO: remove this. It prevents flakiness when running on macOS (millisecond resolution timestamps)
 	nonce uint64
@@ -125,6 +132,11 @@ func newDriver(env *serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv, etcdPr
 	if err != nil {
 		return nil, err
 	}
+	d.compactionScheduler, err = newCompactionScheduler(env.StorageCompactionConcurrency, env.StorageCompactionPriority)
+	if err != nil {
+		return nil, err
+	}
+	d.compactionBytesLimiter = newCompactionBytesLimiter(env.StorageCompactionMaxInFlightBytes)
 	// Create spec repo (default repo)
 	repo := client.NewRepo(ppsconsts.SpecRepo)
 	repoInfo := &pfs.RepoInfo{
@@ -139,10 +151,29 @@ func newDriver(env *serviceenv.ServiceEnv, txnEnv *txnenv.TransactionEnv, etcdPr
 	}
 	// Setup PFS master
 	go d.master(env, db)
-	go d.compactionWorker()
+	d.startCompactionWorker()
 	return d, nil
 }
 
+// startCompactionWorker starts the compaction worker, which runs until the
+// driver is closed.
+func (d *driver) startCompactionWorker() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.workerDone = make(chan struct{})
+	go func() {
+		defer close(d.workerDone)
+		d.compactionWorker(ctx)
+	}()
+}
+
+// close stops the driver's background work, and waits for the compaction
+// worker to drain.
+func (d *driver) close() {
+	d.cancel()
+	<-d.workerDone
+}
+
 func (d *driver) createRepo(txnCtx *txnenv.TransactionContext, repo *pfs.Repo, description string, update bool) error {
 	// Validate arguments
 	if repo == nil {
@@ -801,79 +832,86 @@ func (d *driver) finishCommit(txnCtx *txnenv.TransactionContext, commit *pfs.Com
 		commitInfo.Description = description
 	}
 	commitPath := commitKey(commit)
-	// Run compaction task.
-	return d.compactionQueue.RunTaskBlock(txnCtx.Client.Ctx(), func(m *work.Master) error {
-		exists := func(p string) (bool, error) {
-			var exists bool
-			if err := d.storage.Store().Walk(m.Ctx(), p, func(_ string) error {
-				exists = true
-				return nil
-			}); err != nil {
-				return false, err
+	// Schedule and run compaction task.
+	ctx := txnCtx.Client.Ctx()
+	candidate, err := d.newCompactionCandidate(ctx, commitInfo, commitPath)
+	if err != nil {
+		return err
+	}
+	return d.compactionScheduler.schedule(ctx, candidate, func() error {
+		return d.compactionQueue.RunTaskBlock(ctx, func(m *work.Master) error {
+			exists := func(p string) (bool, error) {
+				var exists bool
+				if err := d.storage.Store().Walk(m.Ctx(), p, func(_ string) error {
+					exists = true
+					return nil
+				}); err != nil {
+					return false, err
+				}
+				return exists, nil
 			}
-			return exists, nil
-		}
-		diffExists, err := exists(path.Join(commitPath, fileset.Diff))
-		if err != nil {
-			return err
-		}
-		if !diffExists {
-			// Compact the commit changes into a diff file set.
-			if err := d.compact(m, path.Join(commitPath, fileset.Diff), []string{commitPath}); err != nil {
+			diffExists, err := exists(path.Join(commitPath, fileset.Diff))
+			if err != nil {
 				return err
 			}
-		}
-		compactedExists, err := exists(path.Join(commitPath, fileset.Compacted))
-		if err != nil {
-			return err
-		}
-		if !compactedExists {
-			// Compact the commit changes (diff file set) into the total changes in the commit's ancestry.
-			var compactSpec *fileset.CompactSpec
-			if commitInfo.ParentCommit == nil {
-				compactSpec, err = d.storage.CompactSpec(m.Ctx(), commitPath)
-			} else {
-				parentCommitPath := commitKey(commitInfo.ParentCommit)
-				compactSpec, err = d.storage.CompactSpec(m.Ctx(), commitPath, parentCommitPath)
+			if !diffExists {
+				// Compact the commit changes into a diff file set.
+				if err := d.compact(m, path.Join(commitPath, fileset.Diff), []string{commitPath}); err != nil {
+					return err
+				}
 			}
+			compactedExists, err := exists(path.Join(commitPath, fileset.Compacted))
 			if err != nil {
 				return err
 			}
-			if err := d.compact(m, compactSpec.Output, compactSpec.Input); err != nil {
+			if !compactedExists {
+				// Compact the commit changes (diff file set) into the total changes in the commit's ancestry.
+				var compactSpec *fileset.CompactSpec
+				if commitInfo.ParentCommit == nil {
+					compactSpec, err = d.storage.CompactSpec(m.Ctx(), commitPath)
+				} else {
+					parentCommitPath := commitKey(commitInfo.ParentCommit)
+					compactSpec, err = d.storage.CompactSpec(m.Ctx(), commitPath, parentCommitPath)
+				}
+				if err != nil {
+					return err
+				}
+				if err := d.compact(m, compactSpec.Output, compactSpec.Input); err != nil {
+					return err
+				}
+			}
+			// Collect the output size from the file set metadata.
+			var outputSize int64
+			if err := d.storage.Store().Walk(m.Ctx(), path.Join(commitPath, fileset.Compacted), func(p string) error {
+				md, err := d.storage.Store().Get(m.Ctx(), p)
+				if err != nil {
+					return err
+				}
+				outputSize += md.SizeBytes
+				return nil
+			}); err != nil {
 				return err
 			}
-		}
-		// Collect the output size from the file set metadata.
-		var outputSize int64
-		if err := d.storage.Store().Walk(m.Ctx(), path.Join(commitPath, fileset.Compacted), func(p string) error {
-			md, err := d.storage.Store().Get(m.Ctx(), p)
-			if err != nil {
+			commitInfo.SizeBytes = uint64(outputSize)
+			commitInfo.Finished = types.TimestampNow()
+			empty := strings.Contains(commitInfo.Description, pfs.EmptyStr)
+			if err := d.updateProvenanceProgress(txnCtx, !empty, commitInfo); err != nil {
 				return err
 			}
-			outputSize += md.SizeBytes
-			return nil
-		}); err != nil {
-			return err
-		}
-		commitInfo.SizeBytes = uint64(outputSize)
-		commitInfo.Finished = types.TimestampNow()
-		empty := strings.Contains(commitInfo.Description, pfs.EmptyStr)
-		if err := d.updateProvenanceProgress(txnCtx, !empty, commitInfo); err != nil {
-			return err
-		}
-		if err := d.writeFinishedCommit(txnCtx.Stm, commit, commitInfo); err != nil {
-			return err
-		}
-		triggeredBranches, err := d.triggerCommit(txnCtx, commitInfo.Commit)
-		if err != nil {
-			return err
-		}
-		for _, b := range triggeredBranches {
-			if err := txnCtx.PropagateCommit(b, false); err != nil {
+			if err := d.writeFinishedCommit(txnCtx.Stm, commit, commitInfo); err != nil {
 				return err
 			}
-		}
-		return nil
+			triggeredBranches, err := d.triggerCommit(txnCtx, commitInfo.Commit)
+			if err != nil {
+				return err
+			}
+			for _, b := range triggeredBranches {
+				if err := txnCtx.PropagateCommit(b, false); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	})
 }
 
@@ -19,8 +19,52 @@ import (
 	"golang.org/x/net/context"
 )
 
-func (d *driver) compact(master *work.Master, outputPath string, inputPrefixes []string) error {
+type compactConfig struct {
+	maxDuration time.Duration
+	outputTTL   time.Duration
+}
+
+// compactOption configures a compaction.
+type compactOption func(*compactConfig)
+
+// withMaxDuration bounds the duration of the whole compaction (all levels).
+// A compaction that exceeds the max duration cancels its subtasks, cleans
+// up its output, and returns an error wrapping context.DeadlineExceeded.
+func withMaxDuration(maxDuration time.Duration) compactOption {
+	return func(c *compactConfig) {
+		c.maxDuration = maxDuration
+	}
+}
+
+// withOutputTTL sets the ttl of the compaction output. The intermediate
+// outputs of the compaction still use the default ttl. An output ttl of zero
+// (the default) means the output does not expire.
+func withOutputTTL(ttl time.Duration) compactOption {
+	return func(c *compactConfig) {
+		c.outputTTL = ttl
+	}
+}
+
+func (d *driver) compact(master *work.Master, outputPath string, inputPrefixes []string, opts ...compactOption) (retErr error) {
+	config := &compactConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.maxDuration > 0 {
+		var cancel context.CancelFunc
+		master, cancel = master.WithTimeout(config.maxDuration)
+		defer cancel()
+	}
 	ctx := master.Ctx()
+	defer func() {
+		if config.maxDuration == 0 || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return
+		}
+		if err := d.storage.Delete(context.Background(), outputPath); err != nil {
+			log.WithError(err).WithField("output", outputPath).Error("error deleting compaction output after deadline")
+		}
+		retErr = errors.Wrapf(context.DeadlineExceeded, "compaction exceeded max duration of %v", config.maxDuration)
+	}()
 	// resolve prefixes into paths
 	inputPaths := []string{}
 	for _, inputPrefix := range inputPrefixes {
@@ -33,11 +77,26 @@ func (d *driver) compact(master *work.Master, outputPath string, inputPrefixes [
 	}
 	// TODO: There is probably a better way to handle empty filesets.
 	if len(inputPaths) == 0 {
-		w := d.storage.NewWriter(ctx, outputPath)
+		w := d.storage.NewWriter(ctx, outputPath, fileset.WithTTL(config.outputTTL))
 		return w.Close()
 	}
 	if len(inputPaths) == 1 {
-		return d.storage.Copy(ctx, inputPaths[0], outputPath, 0)
+		return d.storage.Copy(ctx, inputPaths[0], outputPath, config.outputTTL)
+	}
+	// Small inputs are compacted locally, since distributing the compaction
+	// would cost more than the compaction itself.
+	if threshold := d.env.StorageCompactionInlineThreshold; threshold > 0 {
+		size, err := d.fileSetsSize(ctx, inputPaths)
+		if err != nil {
+			return err
+		}
+		if size < threshold {
+			if _, err := d.storage.Compact(ctx, outputPath, inputPaths, config.outputTTL); err != nil {
+				return err
+			}
+			observeCompactionDepth(1)
+			return nil
+		}
 	}
 	return d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *renew.StringSet) error {
 		res, err := d.compactIter(ctx, compactSpec{
@@ -49,10 +108,82 @@ func (d *driver) compact(master *work.Master, outputPath string, inputPrefixes [
 			return err
 		}
 		renewer.Add(res.OutputPath)
-		return d.storage.Copy(ctx, res.OutputPath, outputPath, 0)
+		if err := d.storage.Copy(ctx, res.OutputPath, outputPath, config.outputTTL); err != nil {
+			return err
+		}
+		observeCompactionDepth(res.depth)
+		return nil
 	})
 }
 
+// compactCommitRange compacts the diffs of the commits in commitRange into a
+// single file set at outputPath, which contains the changes made by the
+// commits from commitRange.Lower to commitRange.Upper (inclusive). This speeds
+// up reads across the range for repos with many small commits. The commits'
+// own file sets are not modified.
+func (d *driver) compactCommitRange(master *work.Master, commitRange *pfs.CommitRange, outputPath string, opts ...compactOption) error {
+	commits, err := d.resolveCommitRange(master.Ctx(), commitRange)
+	if err != nil {
+		return err
+	}
+	var inputPrefixes []string
+	for _, commit := range commits {
+		inputPrefixes = append(inputPrefixes, path.Join(commitPath(commit), fileset.Diff))
+	}
+	return d.compact(master, outputPath, inputPrefixes, opts...)
+}
+
+// resolveCommitRange returns the commits in commitRange, ordered from the
+// oldest to the newest (which is the order the compaction inputs are
+// prioritized in). Each commit in the range must be finished, so that its
+// diff has been compacted.
+func (d *driver) resolveCommitRange(ctx context.Context, commitRange *pfs.CommitRange) ([]*pfs.Commit, error) {
+	lower, upper := commitRange.GetLower(), commitRange.GetUpper()
+	if lower == nil || upper == nil {
+		return nil, errors.Errorf("commit range must have a lower and upper commit")
+	}
+	if lower.Repo.Name != upper.Repo.Name {
+		return nil, errors.Errorf("commit range across repos %v and %v", lower.Repo.Name, upper.Repo.Name)
+	}
+	commits := d.commits(upper.Repo.Name).ReadOnly(ctx)
+	var result []*pfs.Commit
+	commit := upper
+	for {
+		commitInfo := &pfs.CommitInfo{}
+		if err := commits.Get(commit.ID, commitInfo); err != nil {
+			return nil, err
+		}
+		if commitInfo.Finished == nil {
+			return nil, errors.Errorf("commit %v is not finished", commit.ID)
+		}
+		result = append(result, commit)
+		if commit.ID == lower.ID {
+			break
+		}
+		if commitInfo.ParentCommit == nil {
+			return nil, errors.Errorf("commit %v is not an ancestor of commit %v", lower.ID, upper.ID)
+		}
+		commit = commitInfo.ParentCommit
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+// fileSetsSize returns the total size of the file sets in paths.
+func (d *driver) fileSetsSize(ctx context.Context, paths []string) (int64, error) {
+	var size int64
+	for _, p := range paths {
+		md, err := d.storage.Store().Get(ctx, p)
+		if err != nil {
+			return 0, err
+		}
+		size += md.SizeBytes
+	}
+	return size, nil
+}
+
 type compactSpec struct {
 	master     *work.Master
 	inputPaths []string
@@ -61,29 +192,29 @@ type compactSpec struct {
 
 type compactResult struct {
 	OutputPath string
+	// depth is the number of levels in the compaction's merge tree.
+	depth int
 }
 
 // compactIter is one level of compaction.  It will only perform compaction
 // if len(inputPaths) <= params.maxFanIn otherwise it will split inputPaths recursively.
 func (d *driver) compactIter(ctx context.Context, params compactSpec) (*compactResult, error) {
 	if len(params.inputPaths) <= params.maxFanIn {
-		return d.shardedCompact(ctx, params.master, params.inputPaths)
-	}
-	childSize := params.maxFanIn
-	for len(params.inputPaths)/childSize > params.maxFanIn {
-		childSize *= params.maxFanIn
+		res, err := d.shardedCompact(ctx, params.master, params.inputPaths)
+		if err != nil {
+			return nil, err
+		}
+		res.depth = 1
+		return res, nil
 	}
 	// TODO: use an errgroup to make the recursion concurrecnt.
 	// this requires changing the master to allow multiple calls to RunSubtasks
 	// don't forget to pass the errgroups childCtx to compactIter instead of ctx.
 	var res *compactResult
+	var childDepth int
 	if err := d.storage.WithRenewer(ctx, defaultTTL, func(ctx context.Context, renewer *renew.StringSet) error {
 		var childOutputPaths []string
-		for start := 0; start < len(params.inputPaths); start += childSize {
-			end := start + childSize
-			if end > len(params.inputPaths) {
-				end = len(params.inputPaths)
-			}
+		if err := fileset.SplitFanIn(len(params.inputPaths), params.maxFanIn, func(start, end int) error {
 			res, err := d.compactIter(ctx, compactSpec{
 				master:     params.master,
 				inputPaths: params.inputPaths[start:end],
@@ -94,6 +225,12 @@ func (d *driver) compactIter(ctx context.Context, params compactSpec) (*compactR
 			}
 			renewer.Add(res.OutputPath)
 			childOutputPaths = append(childOutputPaths, res.OutputPath)
+			if res.depth > childDepth {
+				childDepth = res.depth
+			}
+			return nil
+		}); err != nil {
+			return err
 		}
 		var err error
 		res, err = d.shardedCompact(ctx, params.master, childOutputPaths)
@@ -101,6 +238,7 @@ func (d *driver) compactIter(ctx context.Context, params compactSpec) (*compactR
 	}); err != nil {
 		return nil, err
 	}
+	res.depth = childDepth + 1
 	return res, nil
 }
 
@@ -110,6 +248,15 @@ func (d *driver) compactIter(ctx context.Context, params compactSpec) (*compactR
 // fan in of one because they are concatenated sequentially.
 func (d *driver) shardedCompact(ctx context.Context, master *work.Master, inputPaths []string) (*compactResult, error) {
 	scratch := path.Join(tmpRepo, uuid.NewWithoutDashes())
+	defer func() {
+		// The compaction was canceled, so clean up the shard outputs rather
+		// than waiting for them to expire.
+		if ctx.Err() != nil {
+			if err := d.storage.Delete(context.Background(), scratch); err != nil {
+				log.WithError(err).WithField("scratch", scratch).Error("error deleting canceled compaction shards")
+			}
+		}
+	}()
 	compaction := &pfs.Compaction{InputPrefixes: inputPaths}
 	var subtasks []*work.Task
 	var shardOutputs []string
@@ -117,7 +264,7 @@ func (d *driver) shardedCompact(ctx context.Context, master *work.Master, inputP
 	if err != nil {
 		return nil, err
 	}
-	if err := d.storage.Shard(ctx, fs, func(pathRange *index.PathRange) error {
+	if err := d.storage.Shard(ctx, fs, func(pathRange *index.PathRange, sizeBytes int64) error {
 		shardOutputPath := path.Join(scratch, strconv.Itoa(len(subtasks)))
 		shard, err := serializeShard(&pfs.Shard{
 			Compaction: compaction,
@@ -126,6 +273,7 @@ func (d *driver) shardedCompact(ctx context.Context, master *work.Master, inputP
 				Upper: pathRange.Upper,
 			},
 			OutputPath: shardOutputPath,
+			SizeBytes:  sizeBytes,
 		})
 		if err != nil {
 			return err
@@ -188,31 +336,73 @@ func (d *driver) concatFileSets(ctx context.Context, inputPaths []string) (*comp
 	return &compactResult{OutputPath: outputPath}, nil
 }
 
-func (d *driver) compactionWorker() {
-	ctx := context.Background()
+// compactionWorker processes compaction subtasks until ctx is canceled.
+// On cancellation, the subtask being processed is abandoned and its
+// partial output is deleted before the worker returns.
+func (d *driver) compactionWorker(ctx context.Context) {
 	w := work.NewWorker(d.etcdClient, d.prefix, storageTaskNamespace)
-	err := backoff.RetryNotify(func() error {
-		return w.Run(ctx, func(ctx context.Context, subtask *work.Task) error {
-			return d.compactShard(ctx, subtask)
-		})
-	}, backoff.NewInfiniteBackOff(), func(err error, _ time.Duration) error {
-		log.Printf("error in compaction worker: %v", err)
+	err := backoff.RetryUntilCancel(ctx, func() error {
+		return w.Run(ctx, d.processCompactionSubtask)
+	}, backoff.NewInfiniteBackOff(), func(err error, retryIn time.Duration) error {
+		log.WithError(err).WithField("retryIn", retryIn).Error("error in compaction worker")
 		return nil
 	})
+	if ctx.Err() != nil {
+		return
+	}
 	// Never ending backoff should prevent us from getting here.
 	panic(err)
 }
 
+// processCompactionSubtask compacts the shard in subtask, and logs the
+// subtask identity along with any error, so failures can be correlated.
+func (d *driver) processCompactionSubtask(ctx context.Context, subtask *work.Task) error {
+	if err := d.compactShard(ctx, subtask); err != nil {
+		compactionLogger(subtask).WithError(err).Error("error compacting shard")
+		return err
+	}
+	return nil
+}
+
+func compactionLogger(subtask *work.Task) *log.Entry {
+	logger := log.WithField("subtask", subtask.ID)
+	shard, err := deserializeShard(subtask.Data)
+	if err != nil {
+		return logger
+	}
+	return logger.WithFields(log.Fields{
+		"inputs": shard.Compaction.GetInputPrefixes(),
+		"lower":  shard.Range.GetLower(),
+		"upper":  shard.Range.GetUpper(),
+		"output": shard.OutputPath,
+	})
+}
+
 func (d *driver) compactShard(ctx context.Context, subtask *work.Task) error {
 	shard, err := deserializeShard(subtask.Data)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		// The subtask was abandoned, so clean up the output in case it was
+		// (partially) written. The subtask will be processed again by another
+		// worker.
+		if ctx.Err() != nil {
+			if err := d.storage.Delete(context.Background(), shard.OutputPath); err != nil {
+				compactionLogger(subtask).WithError(err).Error("error deleting abandoned compaction output")
+			}
+		}
+	}()
+	release, err := d.compactionBytesLimiter.acquire(ctx, shard.SizeBytes)
+	if err != nil {
+		return err
+	}
+	defer release()
 	pathRange := &index.PathRange{
 		Lower: shard.Range.Lower,
 		Upper: shard.Range.Upper,
 	}
-	_, err = d.storage.Compact(ctx, shard.OutputPath, shard.Compaction.InputPrefixes, defaultTTL, index.WithRange(pathRange))
+	_, err = d.storage.Compact(ctx, shard.OutputPath, shard.Compaction.InputPrefixes, defaultTTL, fileset.WithIndexOptions(index.WithRange(pathRange)))
 	return err
 }
 
@@ -0,0 +1,575 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/dbutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/pfsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/track"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testetcd"
+	"github.com/pachyderm/pachyderm/src/server/pkg/work"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// cancelStore cancels a context after a file set with a prefix is stored,
+// which simulates a shutdown between writing a compaction output and
+// completing the subtask.
+type cancelStore struct {
+	fileset.Store
+	prefix string
+	cancel context.CancelFunc
+}
+
+func (cs *cancelStore) Set(ctx context.Context, p string, md *fileset.Metadata) error {
+	if err := cs.Store.Set(ctx, p, md); err != nil {
+		return err
+	}
+	if strings.HasPrefix(p, cs.prefix) {
+		cs.cancel()
+	}
+	return nil
+}
+
+// failStore fails to store file sets with a prefix.
+type failStore struct {
+	fileset.Store
+	prefix string
+}
+
+func (fs *failStore) Set(ctx context.Context, p string, md *fileset.Metadata) error {
+	if strings.HasPrefix(p, fs.prefix) {
+		return errors.Errorf("injected error")
+	}
+	return fs.Store.Set(ctx, p, md)
+}
+
+// newTestStorage creates a test storage, with its store and tracker wrapped
+// by wrapStore and wrapTracker (if they are set).
+func newTestStorage(t *testing.T, wrapStore func(fileset.Store) fileset.Store, wrapTracker func(track.Tracker) track.Tracker, opts ...fileset.StorageOption) *fileset.Storage {
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	if wrapTracker != nil {
+		tr = wrapTracker(tr)
+	}
+	_, chunks := chunk.NewTestStorage(t, db, tr)
+	store := fileset.NewTestStore(t, db)
+	if wrapStore != nil {
+		store = wrapStore(store)
+	}
+	return fileset.NewStorage(store, tr, chunks, opts...)
+}
+
+// newTestDriver creates a driver for compaction tests, which compacts with a
+// max fan in of maxFanIn and runs its distributed compactions through the
+// task queue in env.
+func newTestDriver(env *testetcd.Env, storage *fileset.Storage, maxFanIn int) *driver {
+	return &driver{
+		env: &serviceenv.ServiceEnv{
+			Configuration: serviceenv.NewConfiguration(&serviceenv.PachdFullConfiguration{
+				PachdSpecificConfiguration: serviceenv.PachdSpecificConfiguration{
+					StorageConfiguration: serviceenv.StorageConfiguration{
+						StorageCompactionMaxFanIn: maxFanIn,
+					},
+				},
+			}),
+		},
+		etcdClient: env.EtcdClient,
+		storage:    storage,
+	}
+}
+
+// newTestCompactionStorage creates a test storage with the store wrapped by
+// wrap, and writes a couple of input file sets that can be compacted.
+func newTestCompactionStorage(t *testing.T, wrap func(fileset.Store) fileset.Store, opts ...fileset.StorageOption) (*fileset.Storage, []string) {
+	storage := newTestStorage(t, wrap, nil, opts...)
+	return storage, writeTestCompactionInputs(t, storage)
+}
+
+// writeTestCompactionInputs writes a couple of input file sets that can be
+// compacted.
+func writeTestCompactionInputs(t *testing.T, storage *fileset.Storage) []string {
+	var inputs []string
+	for i := 0; i < 2; i++ {
+		input := fmt.Sprintf("input-%v", i)
+		w := storage.NewWriter(context.Background(), input)
+		require.NoError(t, w.Append(fmt.Sprintf("/%v", input), func(fw *fileset.FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(chunk.RandSeq(100))
+			return err
+		}))
+		require.NoError(t, w.Close())
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+// ttlTracker records the ttl that each object was created with.
+type ttlTracker struct {
+	track.Tracker
+	mu   sync.Mutex
+	ttls map[string]time.Duration
+}
+
+func (tt *ttlTracker) CreateObject(ctx context.Context, id string, pointsTo []string, ttl time.Duration) error {
+	if err := tt.Tracker.CreateObject(ctx, id, pointsTo, ttl); err != nil {
+		return err
+	}
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	tt.ttls[id] = ttl
+	return nil
+}
+
+func (tt *ttlTracker) ttl(id string) (time.Duration, bool) {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	ttl, ok := tt.ttls[id]
+	return ttl, ok
+}
+
+func TestCompactionWorkerShutdown(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx := env.Context
+		d := &driver{etcdClient: env.EtcdClient}
+		// The driver is closed (as it is when pachd shuts down) after the
+		// compaction output is written.
+		closed := make(chan struct{})
+		var closeOnce sync.Once
+		storage, inputs := newTestCompactionStorage(t, func(store fileset.Store) fileset.Store {
+			return &cancelStore{
+				Store:  store,
+				prefix: tmpRepo,
+				cancel: func() {
+					closeOnce.Do(func() {
+						go func() {
+							defer close(closed)
+							d.close()
+						}()
+					})
+				},
+			}
+		})
+		d.storage = storage
+		d.startCompactionWorker()
+		shard, err := serializeShard(&pfs.Shard{
+			Compaction: &pfs.Compaction{InputPrefixes: inputs},
+			Range:      &pfs.PathRange{},
+			OutputPath: path.Join(tmpRepo, "output"),
+		})
+		require.NoError(t, err)
+		taskQueue, err := work.NewTaskQueue(ctx, env.EtcdClient, "", storageTaskNamespace)
+		require.NoError(t, err)
+		masterCtx, masterCancel := context.WithCancel(ctx)
+		defer masterCancel()
+		require.NoError(t, taskQueue.RunTask(masterCtx, func(master *work.Master) {
+			master.RunSubtasks([]*work.Task{{Data: shard}}, func(_ context.Context, _ *work.TaskInfo) error {
+				return nil
+			})
+		}))
+		// Closing the driver waits for the worker to clean up the output.
+		<-closed
+		var orphaned []string
+		require.NoError(t, d.storage.Store().Walk(ctx, tmpRepo, func(p string) error {
+			orphaned = append(orphaned, p)
+			return nil
+		}))
+		require.Equal(t, 0, len(orphaned), "orphaned file sets: %v", orphaned)
+		return nil
+	}))
+}
+
+func TestCompactionSubtaskLogging(t *testing.T) {
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+	storage, inputs := newTestCompactionStorage(t, func(store fileset.Store) fileset.Store {
+		return &failStore{
+			Store:  store,
+			prefix: tmpRepo,
+		}
+	})
+	d := &driver{storage: storage}
+	shard, err := serializeShard(&pfs.Shard{
+		Compaction: &pfs.Compaction{InputPrefixes: inputs},
+		Range: &pfs.PathRange{
+			Lower: "/a",
+			Upper: "/z",
+		},
+		OutputPath: path.Join(tmpRepo, "output"),
+	})
+	require.NoError(t, err)
+	require.YesError(t, d.processCompactionSubtask(context.Background(), &work.Task{ID: "subtask-id", Data: shard}))
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	require.Equal(t, logrus.ErrorLevel, entry.Level)
+	require.Equal(t, "subtask-id", entry.Data["subtask"])
+	require.Equal(t, inputs, entry.Data["inputs"])
+	require.Equal(t, "/a", entry.Data["lower"])
+	require.Equal(t, "/z", entry.Data["upper"])
+	require.Equal(t, path.Join(tmpRepo, "output"), entry.Data["output"])
+	require.True(t, strings.Contains(entry.Data[logrus.ErrorKey].(error).Error(), "injected error"))
+}
+
+func TestCompactMaxDuration(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx := env.Context
+		// Each input file is a separate shard, so the compaction is run
+		// through the workers.
+		storage, inputs := newTestCompactionStorage(t, func(store fileset.Store) fileset.Store {
+			return store
+		}, fileset.WithShardThreshold(1))
+		d := newTestDriver(env, storage, 10)
+		// The worker does not complete a subtask until it is canceled.
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		defer workerCancel()
+		go work.NewWorker(env.EtcdClient, "", storageTaskNamespace).Run(workerCtx, func(ctx context.Context, _ *work.Task) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		taskQueue, err := work.NewTaskQueue(ctx, env.EtcdClient, "", storageTaskNamespace)
+		require.NoError(t, err)
+		outputPath := "output"
+		err = taskQueue.RunTaskBlock(ctx, func(master *work.Master) error {
+			return d.compact(master, outputPath, inputs, withMaxDuration(time.Second))
+		})
+		require.YesError(t, err)
+		require.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+		// Neither the output nor the shard outputs should be left behind.
+		for _, prefix := range []string{outputPath, tmpRepo} {
+			var orphaned []string
+			require.NoError(t, d.storage.Store().Walk(ctx, prefix, func(p string) error {
+				orphaned = append(orphaned, p)
+				return nil
+			}))
+			require.Equal(t, 0, len(orphaned), "orphaned file sets: %v", orphaned)
+		}
+		return nil
+	}))
+}
+
+func TestCompactInline(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx := env.Context
+		// Each input file is a separate shard, so the distributed compaction
+		// is run through the workers.
+		storage, inputs := newTestCompactionStorage(t, func(store fileset.Store) fileset.Store {
+			return store
+		}, fileset.WithShardThreshold(1))
+		newDriver := func(inlineThreshold int64) *driver {
+			d := newTestDriver(env, storage, 10)
+			d.env.StorageCompactionInlineThreshold = inlineThreshold
+			return d
+		}
+		inlineDriver, distributedDriver := newDriver(units.MB), newDriver(0)
+		// The worker counts the subtasks that it processes.
+		var subtasks int64
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		defer workerCancel()
+		go work.NewWorker(env.EtcdClient, "", storageTaskNamespace).Run(workerCtx, func(ctx context.Context, subtask *work.Task) error {
+			atomic.AddInt64(&subtasks, 1)
+			return distributedDriver.processCompactionSubtask(ctx, subtask)
+		})
+		taskQueue, err := work.NewTaskQueue(ctx, env.EtcdClient, "", storageTaskNamespace)
+		require.NoError(t, err)
+		require.NoError(t, taskQueue.RunTaskBlock(ctx, func(master *work.Master) error {
+			return inlineDriver.compact(master, "inline", inputs)
+		}))
+		require.Equal(t, int64(0), atomic.LoadInt64(&subtasks))
+		require.NoError(t, taskQueue.RunTaskBlock(ctx, func(master *work.Master) error {
+			return distributedDriver.compact(master, "distributed", inputs)
+		}))
+		require.True(t, atomic.LoadInt64(&subtasks) > 0)
+		inlineFs, err := storage.Open(ctx, []string{"inline"})
+		require.NoError(t, err)
+		distributedFs, err := storage.Open(ctx, []string{"distributed"})
+		require.NoError(t, err)
+		equal, diff, err := fileset.Equal(ctx, inlineFs, distributedFs)
+		require.NoError(t, err)
+		require.True(t, equal, diff)
+		return nil
+	}))
+}
+
+func TestCompactOutputTTL(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx := env.Context
+		var tr *ttlTracker
+		// Each input file is a separate shard, so the compaction is run
+		// through the workers.
+		storage := newTestStorage(t, nil, func(x track.Tracker) track.Tracker {
+			tr = &ttlTracker{
+				Tracker: x,
+				ttls:    make(map[string]time.Duration),
+			}
+			return tr
+		}, fileset.WithShardThreshold(1))
+		inputs := writeTestCompactionInputs(t, storage)
+		d := newTestDriver(env, storage, 10)
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		defer workerCancel()
+		go work.NewWorker(env.EtcdClient, "", storageTaskNamespace).Run(workerCtx, d.processCompactionSubtask)
+		taskQueue, err := work.NewTaskQueue(ctx, env.EtcdClient, "", storageTaskNamespace)
+		require.NoError(t, err)
+		outputTTL := 30 * 24 * time.Hour
+		require.NoError(t, taskQueue.RunTaskBlock(ctx, func(master *work.Master) error {
+			return d.compact(master, "custom", inputs, withOutputTTL(outputTTL))
+		}))
+		require.NoError(t, taskQueue.RunTaskBlock(ctx, func(master *work.Master) error {
+			return d.compact(master, "default", inputs)
+		}))
+		// The output reflects the custom ttl, and does not expire by default.
+		ttl, ok := tr.ttl("fileset/custom")
+		require.True(t, ok)
+		require.Equal(t, outputTTL, ttl)
+		ttl, ok = tr.ttl("fileset/default")
+		require.True(t, ok)
+		require.Equal(t, time.Duration(0), ttl)
+		// The intermediate outputs keep the default ttl.
+		var scratch int
+		for id, ttl := range tr.ttls {
+			if strings.HasPrefix(id, path.Join("fileset", tmpRepo)) {
+				require.Equal(t, defaultTTL, ttl, "unexpected ttl for %v", id)
+				scratch++
+			}
+		}
+		require.True(t, scratch > 0)
+		return nil
+	}))
+}
+
+func TestCompactCommitRange(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx := env.Context
+		storage := newTestStorage(t, nil, nil)
+		d := newTestDriver(env, storage, 10)
+		d.commits = func(repo string) col.Collection {
+			return pfsdb.Commits(env.EtcdClient, "", repo)
+		}
+		// Write a chain of commits, each of which adds a file in its diff.
+		repo := client.NewRepo("repo")
+		var commits []*pfs.Commit
+		var parent *pfs.Commit
+		for i := 0; i < 4; i++ {
+			commit := client.NewCommit(repo.Name, fmt.Sprintf("commit-%v", i))
+			w := storage.NewWriter(ctx, path.Join(commitPath(commit), fileset.Diff))
+			require.NoError(t, w.Append(fmt.Sprintf("/file-%v", i), func(fw *fileset.FileWriter) error {
+				fw.Append("0")
+				_, err := fw.Write([]byte(commit.ID))
+				return err
+			}))
+			require.NoError(t, w.Close())
+			_, err := col.NewSTM(ctx, env.EtcdClient, func(stm col.STM) error {
+				return d.commits(repo.Name).ReadWrite(stm).Put(commit.ID, &pfs.CommitInfo{
+					Commit:       commit,
+					ParentCommit: parent,
+					Finished:     types.TimestampNow(),
+				})
+			})
+			require.NoError(t, err)
+			commits = append(commits, commit)
+			parent = commit
+		}
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		defer workerCancel()
+		go work.NewWorker(env.EtcdClient, "", storageTaskNamespace).Run(workerCtx, d.processCompactionSubtask)
+		taskQueue, err := work.NewTaskQueue(ctx, env.EtcdClient, "", storageTaskNamespace)
+		require.NoError(t, err)
+		compactRange := func(lower, upper *pfs.Commit, outputPath string) error {
+			return taskQueue.RunTaskBlock(ctx, func(master *work.Master) error {
+				return d.compactCommitRange(master, &pfs.CommitRange{Lower: lower, Upper: upper}, outputPath)
+			})
+		}
+		// The last three commits are compacted, which excludes the first
+		// commit's changes.
+		require.NoError(t, compactRange(commits[1], commits[3], "output"))
+		fs, err := storage.Open(ctx, []string{"output"})
+		require.NoError(t, err)
+		contents := make(map[string]string)
+		require.NoError(t, fs.Iterate(ctx, func(f fileset.File) error {
+			buf := &bytes.Buffer{}
+			if err := f.Content(buf); err != nil {
+				return err
+			}
+			contents[f.Index().Path] = buf.String()
+			return nil
+		}))
+		require.Equal(t, map[string]string{
+			"/file-1": "commit-1",
+			"/file-2": "commit-2",
+			"/file-3": "commit-3",
+		}, contents)
+		// The commits' own file sets are unchanged.
+		for _, commit := range commits {
+			var paths []string
+			require.NoError(t, storage.Store().Walk(ctx, commitPath(commit), func(p string) error {
+				paths = append(paths, p)
+				return nil
+			}))
+			require.Equal(t, []string{path.Join(commitPath(commit), fileset.Diff)}, paths)
+		}
+		// The lower commit must be an ancestor of the upper commit.
+		require.YesError(t, compactRange(commits[3], commits[1], "invalid"))
+		return nil
+	}))
+}
+
+func TestCompactionDepthMetric(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx := env.Context
+		storage := newTestStorage(t, nil, nil)
+		d := newTestDriver(env, storage, 2)
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		defer workerCancel()
+		go work.NewWorker(env.EtcdClient, "", storageTaskNamespace).Run(workerCtx, d.processCompactionSubtask)
+		taskQueue, err := work.NewTaskQueue(ctx, env.EtcdClient, "", storageTaskNamespace)
+		require.NoError(t, err)
+		// bucketCounts returns the (non-cumulative) count of each of the
+		// histogram's buckets, by the bucket's upper bound.
+		bucketCounts := func() map[float64]uint64 {
+			m := &dto.Metric{}
+			require.NoError(t, compactionDepth.Write(m))
+			counts := make(map[float64]uint64)
+			var prev uint64
+			for _, b := range m.Histogram.Bucket {
+				counts[b.GetUpperBound()] = b.GetCumulativeCount() - prev
+				prev = b.GetCumulativeCount()
+			}
+			return counts
+		}
+		before := bucketCounts()
+		// With a max fan in of two, 2, 4 and 8 inputs are compacted with
+		// merge trees of depth 1, 2 and 3.
+		for _, n := range []int{2, 4, 8} {
+			var inputs []string
+			for i := 0; i < n; i++ {
+				input := fmt.Sprintf("input-%v-%v", n, i)
+				w := storage.NewWriter(ctx, input)
+				require.NoError(t, w.Append(fmt.Sprintf("/%v", input), func(fw *fileset.FileWriter) error {
+					fw.Append("0")
+					_, err := fw.Write(chunk.RandSeq(100))
+					return err
+				}))
+				require.NoError(t, w.Close())
+				inputs = append(inputs, input)
+			}
+			require.NoError(t, taskQueue.RunTaskBlock(ctx, func(master *work.Master) error {
+				return d.compact(master, fmt.Sprintf("output-%v", n), inputs)
+			}))
+		}
+		after := bucketCounts()
+		for _, depth := range []float64{1, 2, 3} {
+			require.Equal(t, uint64(1), after[depth]-before[depth], "depth %v", depth)
+		}
+		return nil
+	}))
+}
+
+func TestCompactionPlanDispatch(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx := env.Context
+		storage := newTestStorage(t, nil, nil, fileset.WithMaxFanIn(3), fileset.WithShardThreshold(15*units.KB))
+		d := newTestDriver(env, storage, 3)
+		// The input file sets overlap, so the merges concatenate the content
+		// of the same files.
+		var inputs []string
+		for i := 0; i < 7; i++ {
+			input := fmt.Sprintf("input-%v", i)
+			w := storage.NewWriter(ctx, input)
+			for j := 0; j < 10; j++ {
+				require.NoError(t, w.Append(fmt.Sprintf("/%04d", j), func(fw *fileset.FileWriter) error {
+					fw.Append(fmt.Sprint(i))
+					_, err := fw.Write(chunk.RandSeq(units.KB))
+					return err
+				}))
+			}
+			require.NoError(t, w.Close())
+			inputs = append(inputs, input)
+		}
+		plan, err := storage.PlanCompaction(ctx, inputs)
+		require.NoError(t, err)
+		// Record the shards of each level that's dispatched to the workers,
+		// in the order that the levels are dispatched.
+		var mu sync.Mutex
+		var levels [][]*fileset.CompactionShard
+		var levelInputs [][]string
+		levelIndex := make(map[string]int)
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		defer workerCancel()
+		go work.NewWorker(env.EtcdClient, "", storageTaskNamespace).Run(workerCtx, func(ctx context.Context, subtask *work.Task) error {
+			shard, err := deserializeShard(subtask.Data)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			key := strings.Join(shard.Compaction.InputPrefixes, ",")
+			i, ok := levelIndex[key]
+			if !ok {
+				i = len(levels)
+				levelIndex[key] = i
+				levels = append(levels, nil)
+				levelInputs = append(levelInputs, shard.Compaction.InputPrefixes)
+			}
+			levels[i] = append(levels[i], &fileset.CompactionShard{
+				PathRange: &index.PathRange{
+					Lower: shard.Range.Lower,
+					Upper: shard.Range.Upper,
+				},
+				SizeBytes: shard.SizeBytes,
+			})
+			mu.Unlock()
+			return d.processCompactionSubtask(ctx, subtask)
+		})
+		taskQueue, err := work.NewTaskQueue(ctx, env.EtcdClient, "", storageTaskNamespace)
+		require.NoError(t, err)
+		require.NoError(t, taskQueue.RunTaskBlock(ctx, func(master *work.Master) error {
+			return d.compact(master, "output", inputs)
+		}))
+		// The levels are dispatched children first, and the levels with a
+		// single shard are compacted without a subtask.
+		var expected []*fileset.CompactionPlan
+		var visit func(plan *fileset.CompactionPlan)
+		visit = func(plan *fileset.CompactionPlan) {
+			for _, child := range plan.Children {
+				visit(child)
+			}
+			if len(plan.Shards) > 1 {
+				expected = append(expected, plan)
+			}
+		}
+		visit(plan)
+		require.True(t, len(expected) > 1)
+		require.Equal(t, len(expected), len(levels))
+		for i, level := range expected {
+			shards := levels[i]
+			sort.Slice(shards, func(a, b int) bool {
+				return shards[a].PathRange.Lower < shards[b].PathRange.Lower
+			})
+			require.Equal(t, level.Shards, shards, "level %v", i)
+			if len(level.Children) == 0 {
+				require.Equal(t, level.Inputs, levelInputs[i], "level %v", i)
+			}
+		}
+		return nil
+	}))
+}
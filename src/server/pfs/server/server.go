@@ -21,6 +21,9 @@ const (
 type APIServer interface {
 	pfsclient.APIServer
 	txnenv.PfsTransactionServer
+	// Close stops the server's background work, and waits for the compaction
+	// worker to drain (abandoning its current subtask).
+	Close()
 }
 
 // BlockAPIServer combines BlockAPIServer and ObjectAPIServer.
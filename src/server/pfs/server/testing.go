@@ -117,6 +117,7 @@ func GetPachClient(t testing.TB, config *serviceenv.Configuration) *client.APICl
 	db := dbutil.NewTestDB(t)
 	apiServer, err := newAPIServer(env, txnEnv, etcdPrefix, db)
 	require.NoError(t, err)
+	t.Cleanup(apiServer.Close)
 
 	txnEnv.Initialize(env, nil, &authtesting.InactiveAPIServer{}, apiServer, txnenv.NewMockPpsTransactionServer())
 
@@ -68,18 +68,30 @@ var authHandlers = map[string]authHandler{
 	// Debug API
 	//
 
-	"/debug.Debug/Profile": authDisabledOr(admin),
-	"/debug.Debug/Binary":  authDisabledOr(admin),
-	"/debug.Debug/Dump":    authDisabledOr(admin),
+	"/debug.Debug/Profile":           authDisabledOr(admin),
+	"/debug.Debug/Binary":            authDisabledOr(admin),
+	"/debug.Debug/Dump":              authDisabledOr(admin),
+	"/debug.Debug/CaptureLogs":       authDisabledOr(admin),
+	"/debug.Debug/TailLogs":          authDisabledOr(admin),
+	"/debug.Debug/Contention":        authDisabledOr(admin),
+	"/debug.Debug/Goroutine":         authDisabledOr(admin),
+	"/debug.Debug/GoroutineCount":    authDisabledOr(admin),
+	"/debug.Debug/Counters":          authDisabledOr(admin),
+	"/debug.Debug/SetProfileRate":    authDisabledOr(admin),
+	"/debug.Debug/ResetProfileRates": authDisabledOr(admin),
 
 	//
 	// Enterprise API
 	//
 
-	"/enterprise.API/Activate":          unauthenticated,
-	"/enterprise.API/GetState":          unauthenticated,
-	"/enterprise.API/GetActivationCode": authDisabledOr(admin),
-	"/enterprise.API/Deactivate":        authDisabledOr(admin),
+	"/enterprise.API/Activate":             unauthenticated,
+	"/enterprise.API/GetState":             unauthenticated,
+	"/enterprise.API/GetActivationCode":    authDisabledOr(admin),
+	"/enterprise.API/Deactivate":           authDisabledOr(admin),
+	"/enterprise.API/StageActivationCode":  authDisabledOr(admin),
+	"/enterprise.API/ExportState":          authDisabledOr(admin),
+	"/enterprise.API/ImportState":          authDisabledOr(admin),
+	"/enterprise.API/DecodeActivationCode": authDisabledOr(admin),
 
 	//
 	// Health API
@@ -21,18 +21,51 @@ type Cache struct {
 	defaultValue proto.Message
 	key          string
 	value        *atomic.Value
+	onChange     func(proto.Message)
+}
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithOnChange makes the watcher call onChange with the new value each time
+// it updates the cached value. onChange is called from the watcher's
+// goroutine, so it should not block.
+func WithOnChange(onChange func(proto.Message)) Option {
+	return func(c *Cache) {
+		c.onChange = onChange
+	}
 }
 
 // NewCache returns a cache for the given key in the etcd collection
-func NewCache(c col.Collection, key string, defaultValue proto.Message) *Cache {
+func NewCache(c col.Collection, key string, defaultValue proto.Message, opts ...Option) *Cache {
 	value := &atomic.Value{}
 	value.Store(defaultValue)
-	return &Cache{
+	cache := &Cache{
 		c:            c,
 		value:        value,
 		key:          key,
 		defaultValue: defaultValue,
 	}
+	for _, opt := range opts {
+		opt(cache)
+	}
+	return cache
+}
+
+// Init loads the current value of the key into the cache.
+// It can be called before Watch, so that the value is available as soon as
+// the cache is used, rather than once the watcher has caught up.
+func (c *Cache) Init(ctx context.Context) error {
+	val := proto.Clone(c.defaultValue)
+	if err := c.c.ReadOnly(ctx).Get(c.key, val); err != nil {
+		if col.IsErrNotFound(err) {
+			c.value.Store(c.defaultValue)
+			return nil
+		}
+		return err
+	}
+	c.value.Store(val)
+	return nil
 }
 
 // Watch should be called in a goroutine to start the watcher
@@ -60,9 +93,9 @@ func (c *Cache) Watch() {
 					if err := proto.Unmarshal(ev.Value, val); err != nil {
 						return err
 					}
-					c.value.Store(val)
+					c.store(val)
 				case watch.EventDelete:
-					c.value.Store(c.defaultValue)
+					c.store(c.defaultValue)
 				}
 			}
 		}
@@ -72,6 +105,14 @@ func (c *Cache) Watch() {
 	})
 }
 
+// store updates the cached value and notifies onChange, if it's set.
+func (c *Cache) store(val proto.Message) {
+	c.value.Store(val)
+	if c.onChange != nil {
+		c.onChange(val)
+	}
+}
+
 // Load retrieves the current cached value
 func (c *Cache) Load() interface{} {
 	return c.value.Load()
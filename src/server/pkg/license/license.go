@@ -39,36 +39,48 @@ type ActivationCode struct {
 
 // Token is the inner JSON structure of an enterprise license. These
 // claims are signed and must be kept in sync with the license generation tool.
+// Only Expiry is required, the other claims are empty if the code doesn't
+// include them.
 type Token struct {
-	Expiry string
+	Expiry   string
+	Customer string
+	Features []string
+	Seats    int64
 }
 
 // Validate checks the validity of an enterprise license code
 func Validate(code string) (expiration time.Time, err error) {
+	_, expiration, err = Decode(code)
+	return expiration, err
+}
+
+// Decode checks the validity of an enterprise license code, like Validate,
+// and returns its claims along with its expiration.
+func Decode(code string) (_ *Token, expiration time.Time, err error) {
 	// Parse the public key.  If these steps fail, something is seriously
 	// wrong and we should crash the service by panicking.
 	block, _ := pem.Decode([]byte(publicKey))
 	if block == nil {
-		return time.Time{}, errors.Errorf("failed to pem decode public key")
+		return nil, time.Time{}, errors.Errorf("failed to pem decode public key")
 	}
 	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
-		return time.Time{}, errors.Wrapf(err, "failed to parse DER encoded public key")
+		return nil, time.Time{}, errors.Wrapf(err, "failed to parse DER encoded public key")
 	}
 	rsaPub, ok := pub.(*rsa.PublicKey)
 	if !ok {
-		return time.Time{}, errors.Errorf("public key isn't an RSA key")
+		return nil, time.Time{}, errors.Errorf("public key isn't an RSA key")
 	}
 
 	activationCode, err := Unmarshal(code)
 	if err != nil {
-		return time.Time{}, err
+		return nil, time.Time{}, err
 	}
 
 	// Decode the signature
 	decodedSignature, err := base64.StdEncoding.DecodeString(activationCode.Signature)
 	if err != nil {
-		return time.Time{}, errors.Errorf("signature is not base64 encoded")
+		return nil, time.Time{}, errors.Errorf("signature is not base64 encoded")
 	}
 
 	// Compute the sha256 checksum of the token
@@ -76,13 +88,13 @@ func Validate(code string) (expiration time.Time, err error) {
 
 	// Verify that the signature is valid
 	if err := rsa.VerifyPKCS1v15(rsaPub, crypto.SHA256, hashedToken[:], decodedSignature); err != nil {
-		return time.Time{}, errors.Errorf("invalid signature in activation code")
+		return nil, time.Time{}, errors.Errorf("invalid signature in activation code")
 	}
 
 	// Unmarshal the token
-	token := Token{}
-	if err := json.Unmarshal([]byte(activationCode.Token), &token); err != nil {
-		return time.Time{}, errors.Errorf("token is not valid JSON")
+	token := &Token{}
+	if err := json.Unmarshal([]byte(activationCode.Token), token); err != nil {
+		return nil, time.Time{}, errors.Errorf("token is not valid JSON")
 	}
 
 	// Parse the expiration. Note that this string is generated by Date.toJSON()
@@ -91,13 +103,13 @@ func Validate(code string) (expiration time.Time, err error) {
 	// it seems to work.
 	expiration, err = time.Parse(time.RFC3339, token.Expiry)
 	if err != nil {
-		return time.Time{}, errors.Errorf("expiration is not valid ISO 8601 string")
+		return nil, time.Time{}, errors.Errorf("expiration is not valid ISO 8601 string")
 	}
 	// Check that the activation code has not expired
 	if time.Now().After(expiration) {
-		return time.Time{}, errors.Errorf("the activation code has expired")
+		return nil, time.Time{}, errors.Errorf("the activation code has expired")
 	}
-	return expiration, nil
+	return token, expiration, nil
 }
 
 // Unmarshal deserializes the outer base64-encoded JSON payload of an enterprise license
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"runtime"
@@ -10,13 +11,19 @@ import (
 
 	"github.com/fatih/camelcase"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
 	bucketFactor = 2.0
 	bucketCount  = 20 // Which makes the max bucket 2^20 seconds or ~12 days in size
+
+	// RequestIDKey is the gRPC metadata key for the request ID, and the log
+	// field that it is written to.
+	RequestIDKey = "request-id"
 )
 
 // This needs to be a global var, not a field on the logger, because multiple servers
@@ -28,6 +35,7 @@ var reportMetricsOnce sync.Once
 type Logger interface {
 	Log(request interface{}, response interface{}, err error, duration time.Duration)
 	LogAtLevelFromDepth(request interface{}, response interface{}, err error, duration time.Duration, level logrus.Level, depth int)
+	WithRequestID(requestID string) Logger
 }
 
 type logger struct {
@@ -84,6 +92,30 @@ func newLogger(service string, exportStats bool) Logger {
 	return newLogger
 }
 
+// WithRequestID returns a logger that includes requestID in each log line,
+// so that the log lines for a request can be correlated.
+func (l *logger) WithRequestID(requestID string) Logger {
+	return &logger{
+		Entry:       l.Entry.WithField(RequestIDKey, requestID),
+		histogram:   l.histogram,
+		counter:     l.counter,
+		mutex:       l.mutex,
+		exportStats: l.exportStats,
+		service:     l.service,
+	}
+}
+
+// RequestID returns the request ID that the client set in the gRPC metadata
+// of ctx, or generates a new request ID if the client did not set one.
+func RequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return uuid.NewWithoutDashes()
+}
+
 // Helper function used to log requests and responses from our GRPC method
 // implementations
 func (l *logger) Log(request interface{}, response interface{}, err error, duration time.Duration) {
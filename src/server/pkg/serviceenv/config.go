@@ -26,6 +26,16 @@ type GlobalConfiguration struct {
 	LokiPort      string `env:"LOKI_SERVICE_PORT"`
 	SamlPort      uint16 `env:"SAML_PORT,default=654"`
 	OidcPort      uint16 `env:"OIDC_PORT,default=657"`
+	// DebugChunkSize is the size (in bytes) of the chunks that the debug
+	// server streams profiles in.
+	DebugChunkSize int `env:"DEBUG_CHUNK_SIZE,default=1048576"`
+	// DebugMaxProfileSeconds is the max duration of the CPU profiles that the
+	// debug server collects, longer requests are clamped to it (zero means
+	// unlimited).
+	DebugMaxProfileSeconds int64 `env:"DEBUG_MAX_PROFILE_SECONDS,default=300"`
+	// DebugMaxTailSeconds is the max duration of the log tails that the debug
+	// server serves, longer requests are clamped to it (zero means unlimited).
+	DebugMaxTailSeconds int64 `env:"DEBUG_MAX_TAIL_SECONDS,default=300"`
 
 	// PPSSpecCommitID is only set for workers and sidecar pachd instances.
 	// Because both pachd and worker need to know the spec commit (the worker so
@@ -77,21 +87,41 @@ type PachdSpecificConfiguration struct {
 	IdentityServerDatabase string `env:"IDENTITY_SERVER_DATABASE,default=dex"`
 	IdentityServerUser     string `env:"IDENTITY_SERVER_USER,default=postgres"`
 	IdentityServerPassword string `env:"IDENTITY_SERVER_PASSWORD"`
+
+	// EnterpriseActivationCodeRateLimit is the number of GetActivationCode
+	// calls per second that each auth subject is allowed (zero means
+	// unlimited), with bursts of up to EnterpriseActivationCodeRateBurst calls.
+	EnterpriseActivationCodeRateLimit float64 `env:"ENTERPRISE_ACTIVATION_CODE_RATE_LIMIT,default=0"`
+	EnterpriseActivationCodeRateBurst int     `env:"ENTERPRISE_ACTIVATION_CODE_RATE_BURST,default=10"`
+
+	// EnterpriseWebhookURL is the URL that license state changes are posted
+	// to. The notifications are disabled if it's empty, or if
+	// EnterpriseWebhookDisabled is set.
+	EnterpriseWebhookURL      string `env:"ENTERPRISE_WEBHOOK_URL,default="`
+	EnterpriseWebhookDisabled bool   `env:"ENTERPRISE_WEBHOOK_DISABLED,default=false"`
+
+	// EnterpriseReadOnly makes the enterprise servers read-only, for standby
+	// pachds that serve the license state but don't change it.
+	EnterpriseReadOnly bool `env:"ENTERPRISE_READ_ONLY,default=false"`
 }
 
 // StorageConfiguration contains the storage configuration.
 type StorageConfiguration struct {
-	StorageMemoryThreshold         int64  `env:"STORAGE_MEMORY_THRESHOLD"`
-	StorageShardThreshold          int64  `env:"STORAGE_SHARD_THRESHOLD"`
-	StorageLevelZeroSize           int64  `env:"STORAGE_LEVEL_ZERO_SIZE"`
-	StorageLevelSizeBase           int    `env:"STORAGE_LEVEL_SIZE_BASE"`
-	StorageUploadConcurrencyLimit  int    `env:"STORAGE_UPLOAD_CONCURRENCY_LIMIT,default=100"`
-	StoragePutFileConcurrencyLimit int    `env:"STORAGE_PUT_FILE_CONCURRENCY_LIMIT,default=100"`
-	StorageGCPolling               string `env:"STORAGE_GC_POLLING"`
-	StorageGCTimeout               string `env:"STORAGE_GC_TIMEOUT"`
-	StorageCompactionMaxFanIn      int    `env:"STORAGE_COMPACTION_MAX_FANIN,default=50"`
-	StorageFileSetsMaxOpen         int    `env:"STORAGE_FILESETS_MAX_OPEN,default=50"`
-	StorageDiskCacheSize           int    `env:"STORAGE_DISK_CACHE_SIZE,default=100"`
+	StorageMemoryThreshold            int64  `env:"STORAGE_MEMORY_THRESHOLD"`
+	StorageShardThreshold             int64  `env:"STORAGE_SHARD_THRESHOLD"`
+	StorageLevelZeroSize              int64  `env:"STORAGE_LEVEL_ZERO_SIZE"`
+	StorageLevelSizeBase              int    `env:"STORAGE_LEVEL_SIZE_BASE"`
+	StorageUploadConcurrencyLimit     int    `env:"STORAGE_UPLOAD_CONCURRENCY_LIMIT,default=100"`
+	StoragePutFileConcurrencyLimit    int    `env:"STORAGE_PUT_FILE_CONCURRENCY_LIMIT,default=100"`
+	StorageGCPolling                  string `env:"STORAGE_GC_POLLING"`
+	StorageGCTimeout                  string `env:"STORAGE_GC_TIMEOUT"`
+	StorageCompactionMaxFanIn         int    `env:"STORAGE_COMPACTION_MAX_FANIN,default=50"`
+	StorageCompactionConcurrency      int    `env:"STORAGE_COMPACTION_CONCURRENCY,default=0"`
+	StorageCompactionPriority         string `env:"STORAGE_COMPACTION_PRIORITY,default=fifo"`
+	StorageCompactionInlineThreshold  int64  `env:"STORAGE_COMPACTION_INLINE_THRESHOLD,default=0"`
+	StorageCompactionMaxInFlightBytes int64  `env:"STORAGE_COMPACTION_MAX_IN_FLIGHT_BYTES,default=0"`
+	StorageFileSetsMaxOpen            int    `env:"STORAGE_FILESETS_MAX_OPEN,default=50"`
+	StorageDiskCacheSize              int    `env:"STORAGE_DISK_CACHE_SIZE,default=100"`
 }
 
 // WorkerFullConfiguration contains the full worker configuration.
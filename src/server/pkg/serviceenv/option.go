@@ -41,5 +41,8 @@ func (env *ServiceEnv) FileSetStorageOptions() []fileset.StorageOption {
 	if env.StorageLevelSizeBase > 0 {
 		opts = append(opts, fileset.WithLevelSizeBase(env.StorageLevelSizeBase))
 	}
+	if env.StorageCompactionMaxFanIn > 0 {
+		opts = append(opts, fileset.WithMaxFanIn(env.StorageCompactionMaxFanIn))
+	}
 	return opts
 }
@@ -4,12 +4,18 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math/rand"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/chmduquesne/rollinghash/buzhash64"
 	units "github.com/docker/go-units"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	"github.com/pachyderm/pachyderm/src/server/pkg/dbutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/track"
@@ -92,6 +98,99 @@ func TestCopy(t *testing.T) {
 	}
 }
 
+var (
+	errTestUnavailable = errors.Errorf("503 service unavailable")
+	errTestNotFound    = errors.Errorf("404 not found")
+	errTestForbidden   = errors.Errorf("403 forbidden")
+)
+
+// flakyClient is an object client that fails reads with the queued errors.
+type flakyClient struct {
+	obj.Client
+	errs  []error
+	reads int
+}
+
+func (fc *flakyClient) Reader(ctx context.Context, name string, offset, size uint64) (io.ReadCloser, error) {
+	fc.reads++
+	if len(fc.errs) > 0 {
+		err := fc.errs[0]
+		fc.errs = fc.errs[1:]
+		if err == io.ErrUnexpectedEOF {
+			// The read fails after part of the object is read.
+			r, err := fc.Client.Reader(ctx, name, offset, size)
+			if err != nil {
+				return nil, err
+			}
+			return ioutil.NopCloser(io.MultiReader(io.LimitReader(r, 10), iotest.TimeoutReader(r))), nil
+		}
+		return nil, err
+	}
+	return fc.Client.Reader(ctx, name, offset, size)
+}
+
+// IsRetryable also considers non existence errors retryable, to check that
+// they are still not retried.
+func (fc *flakyClient) IsRetryable(err error) bool {
+	return errors.Is(err, errTestUnavailable) || errors.Is(err, errTestNotFound) || errors.Is(err, iotest.ErrTimeout)
+}
+
+func (fc *flakyClient) IsNotExist(err error) bool {
+	return errors.Is(err, errTestNotFound) || fc.Client.IsNotExist(err)
+}
+
+func TestGetRetries(t *testing.T) {
+	ctx := context.Background()
+	objC, _ := newTestStorage(t)
+	data := RandSeq(100 * units.KB)
+	chunkID := Hash(data)
+	w, err := objC.Writer(ctx, chunkPath(chunkID))
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	fc := &flakyClient{Client: objC}
+	c := NewClient(fc, nil, nil, "")
+	c.newBackOff = func() backoff.BackOff {
+		return backoff.RetryEvery(time.Millisecond).For(time.Second)
+	}
+	get := func(errs ...error) ([]byte, error) {
+		fc.errs = errs
+		fc.reads = 0
+		buf := &bytes.Buffer{}
+		err := c.Get(ctx, chunkID, buf)
+		return buf.Bytes(), err
+	}
+	// Retryable errors are retried, and the partial data from a failed read
+	// is discarded.
+	buf, err := get(errTestUnavailable, io.ErrUnexpectedEOF, errTestUnavailable)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(data, buf))
+	require.Equal(t, 4, fc.reads)
+	// Non existence and authorization errors fail fast.
+	for _, testErr := range []error{errTestNotFound, errTestForbidden} {
+		buf, err = get(testErr)
+		require.YesError(t, err)
+		require.True(t, errors.Is(err, testErr), "unexpected error: %v", err)
+		require.Equal(t, testErr == errTestNotFound, errors.Is(err, ErrChunkNotExists))
+		require.Equal(t, 0, len(buf))
+		require.Equal(t, 1, fc.reads)
+	}
+	// A non-retryable error that follows retryable errors still fails fast.
+	_, err = get(errTestUnavailable, errTestForbidden)
+	require.YesError(t, err)
+	require.True(t, errors.Is(err, errTestForbidden))
+	require.Equal(t, 2, fc.reads)
+	// Retries stop when the backoff stops.
+	var errs []error
+	for i := 0; i < 10000; i++ {
+		errs = append(errs, errTestUnavailable)
+	}
+	_, err = get(errs...)
+	require.YesError(t, err)
+	require.True(t, errors.Is(err, errTestUnavailable))
+}
+
 func BenchmarkWriter(b *testing.B) {
 	_, chunks := newTestStorage(b)
 	seq := RandSeq(100 * units.MB)
@@ -1,7 +1,9 @@
 package chunk
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"path"
@@ -9,10 +11,16 @@ import (
 	"time"
 
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/track"
+	log "github.com/sirupsen/logrus"
 )
 
+// maxReadRetryTime is the maximum amount of time that a chunk read is
+// retried for.
+const maxReadRetryTime = 2 * time.Minute
+
 // Client allows manipulation of individual chunks, by maintaining consistency between
 // a tracker and an obj.Client.
 type Client struct {
@@ -21,21 +29,35 @@ type Client struct {
 	tracker track.Tracker
 	renewer *track.Renewer
 	ttl     time.Duration
+	// newBackOff returns the backoff for retrying a chunk read (overridden in
+	// tests).
+	newBackOff func() backoff.BackOff
 }
 
 // NewClient returns a client which will write to objc, mdstore, and tracker.  Name is used
 // for the set of temporary objects
 func NewClient(objc obj.Client, mdstore MetadataStore, tr track.Tracker, name string) *Client {
+	return newClient(objc, mdstore, tr, name, defaultChunkTTL)
+}
+
+// newClient returns a client that creates chunks (and renews them under name)
+// with ttl.
+func newClient(objc obj.Client, mdstore MetadataStore, tr track.Tracker, name string, ttl time.Duration) *Client {
 	var renewer *track.Renewer
 	if name != "" {
-		renewer = track.NewRenewer(tr, name, defaultChunkTTL)
+		renewer = track.NewRenewer(tr, name, ttl)
 	}
 	c := &Client{
 		objc:    objc,
 		tracker: tr,
 		mdstore: mdstore,
 		renewer: renewer,
-		ttl:     defaultChunkTTL,
+		ttl:     ttl,
+		newBackOff: func() backoff.BackOff {
+			b := backoff.NewExponentialBackOff()
+			b.MaxElapsedTime = maxReadRetryTime
+			return b
+		},
 	}
 	return c
 }
@@ -85,7 +107,33 @@ func (c *Client) Create(ctx context.Context, md Metadata, r io.Reader) (_ ID, re
 }
 
 // Get writes data for a chunk with ID chunkID to w.
-func (c *Client) Get(ctx context.Context, chunkID ID, w io.Writer) (retErr error) {
+// Reads that fail with a retryable object storage error (e.g. a transient
+// server error) are retried with backoff, while other errors (e.g. the chunk
+// not existing, or insufficient permissions) are returned immediately. The
+// chunk is buffered, so a failed read does not write partial data to w.
+// The error for a chunk that does not exist matches ErrChunkNotExists.
+func (c *Client) Get(ctx context.Context, chunkID ID, w io.Writer) error {
+	buf := &bytes.Buffer{}
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		buf.Reset()
+		return c.get(ctx, chunkID, buf)
+	}, c.newBackOff(), func(err error, d time.Duration) error {
+		if classifyError(c.objc, err) != errorClassRetryable {
+			return err
+		}
+		log.Infof("error reading chunk %v, retrying in %v: %v", chunkID.HexString(), d, err)
+		return nil
+	}); err != nil {
+		if classifyError(c.objc, err) == errorClassNotExist {
+			return &notExistError{chunkID: chunkID, err: err}
+		}
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (c *Client) get(ctx context.Context, chunkID ID, w io.Writer) (retErr error) {
 	p := chunkPath(chunkID)
 	objR, err := c.objc.Reader(ctx, p, 0, 0)
 	if err != nil {
@@ -100,6 +148,53 @@ func (c *Client) Get(ctx context.Context, chunkID ID, w io.Writer) (retErr error
 	return err
 }
 
+// notExistError is returned by Get for a chunk that does not exist in object
+// storage. It matches ErrChunkNotExists, and wraps the object storage error.
+type notExistError struct {
+	chunkID ID
+	err     error
+}
+
+func (e *notExistError) Error() string {
+	return fmt.Sprintf("chunk %v does not exist: %v", e.chunkID.HexString(), e.err)
+}
+
+func (e *notExistError) Unwrap() error {
+	return e.err
+}
+
+func (e *notExistError) Is(target error) bool {
+	return target == ErrChunkNotExists
+}
+
+// errorClass is a class of object storage errors, which determines whether
+// the operation that failed is retried.
+type errorClass int
+
+const (
+	// errorClassRetryable errors are transient (e.g. 5xx responses or
+	// temporary network errors), so the operation is retried.
+	errorClassRetryable errorClass = iota
+	// errorClassNotExist errors are returned for objects that do not exist.
+	errorClassNotExist
+	// errorClassPermanent errors (e.g. authorization errors) will not succeed
+	// on retry.
+	errorClassPermanent
+)
+
+// classifyError classifies an error returned by objc. Non existence errors
+// are never retried, even if objc considers them retryable.
+func classifyError(objc obj.Client, err error) errorClass {
+	switch {
+	case objc.IsNotExist(err):
+		return errorClassNotExist
+	case obj.IsRetryable(objc, err):
+		return errorClassRetryable
+	default:
+		return errorClassPermanent
+	}
+}
+
 // Close closes the client, stopping the background renewal of created objects
 func (c *Client) Close() error {
 	if c.renewer != nil {
@@ -2,6 +2,7 @@ package chunk
 
 import (
 	"math"
+	"math/bits"
 	"time"
 
 	"github.com/chmduquesne/rollinghash/buzhash64"
@@ -53,6 +54,27 @@ func WithMinMax(min, max int) WriterOption {
 	}
 }
 
+// WithTargetSize configures the writer to create chunks near the target size.
+// Chunk sizes are bounded to [size / 2, size * 2].
+func WithTargetSize(size int) WriterOption {
+	return func(w *Writer) {
+		// The expected chunk size is the minimum plus the average distance
+		// between split points.
+		WithRollingHashConfig(bits.Len(uint(size/2))-1, defaultSeed)(w)
+		WithMinMax(size/2, size*2)(w)
+		w.chunkSize.avg = size
+	}
+}
+
+// WithRewriteSmallChunks configures the writer to rewrite copied data that
+// references chunks smaller than the minimum chunk size into new chunks,
+// rather than referencing the small chunks.
+func WithRewriteSmallChunks() WriterOption {
+	return func(w *Writer) {
+		w.rewriteSmallChunks = true
+	}
+}
+
 // WithNoUpload sets the writer to no upload (will not upload chunks).
 func WithNoUpload() WriterOption {
 	return func(w *Writer) {
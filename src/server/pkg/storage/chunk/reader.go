@@ -85,6 +85,12 @@ func (dr *DataReader) Get(w io.Writer) error {
 	return err
 }
 
+// Prefetch fetches the chunk referenced by the data reference, so that a
+// later Get does not need to wait on object storage.
+func (dr *DataReader) Prefetch() error {
+	return dr.getChunk()
+}
+
 func (dr *DataReader) getChunk() error {
 	dr.getChunkMu.Lock()
 	defer dr.getChunkMu.Unlock()
@@ -108,3 +114,15 @@ func (dr *DataReader) getChunk() error {
 	dr.chunk = buf.Bytes()
 	return nil
 }
+
+// VerifyDataRef checks that data matches the hash of the data reference.
+func VerifyDataRef(dataRef *DataRef, data []byte) error {
+	expected := dataRef.Hash
+	if expected == "" {
+		expected = ID(dataRef.Ref.Id).HexString()
+	}
+	if actual := Hash(data).HexString(); actual != expected {
+		return errors.Errorf("data reference hash mismatch for chunk %v, expected: %v, actual: %v", ID(dataRef.Ref.Id).HexString(), expected, actual)
+	}
+	return nil
+}
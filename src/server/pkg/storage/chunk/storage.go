@@ -1,9 +1,11 @@
 package chunk
 
 import (
+	"bytes"
 	"context"
 	"time"
 
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/track"
 )
@@ -41,15 +43,22 @@ func NewStorage(objClient obj.Client, mdstore MetadataStore, tracker track.Track
 // NewReader creates a new Reader.
 func (s *Storage) NewReader(ctx context.Context, dataRefs []*DataRef) *Reader {
 	// using the empty string for the tmp id to disable the renewer
-	client := NewClient(s.objClient, s.mdstore, s.tracker, "")
+	client := newClient(s.objClient, s.mdstore, s.tracker, "", s.defaultChunkTTL)
 	return newReader(ctx, client, dataRefs)
 }
 
+// NewDataReader creates a new DataReader for a data reference.
+// The chunk is shared with the seed when the seed references the same chunk.
+func (s *Storage) NewDataReader(ctx context.Context, dataRef *DataRef, seed *DataReader) *DataReader {
+	client := newClient(s.objClient, s.mdstore, s.tracker, "", s.defaultChunkTTL)
+	return newDataReader(ctx, client, dataRef, seed)
+}
+
 // NewWriter creates a new Writer for a stream of bytes to be chunked.
 // Chunks are created based on the content, then hashed and deduplicated/uploaded to
 // object storage.
 func (s *Storage) NewWriter(ctx context.Context, tmpID string, cb WriterCallback, opts ...WriterOption) *Writer {
-	client := NewClient(s.objClient, s.mdstore, s.tracker, tmpID)
+	client := newClient(s.objClient, s.mdstore, s.tracker, tmpID, s.defaultChunkTTL)
 	return newWriter(ctx, client, cb, opts...)
 }
 
@@ -58,6 +67,68 @@ func (s *Storage) List(ctx context.Context, cb func(string) error) error {
 	return s.objClient.Walk(ctx, prefix, cb)
 }
 
+// CopyTo copies the chunks with chunkIDs, and the chunks that they point to,
+// to dst. A chunk's ID is the hash of its content, so the copied chunks have
+// the same IDs in dst, and data references to them remain valid. The copied
+// chunks are renewed under tmpID while cb is called, so cb should create the
+// tracker object in dst that points to them.
+func (s *Storage) CopyTo(ctx context.Context, dst *Storage, tmpID string, chunkIDs []ID, cb func() error) (retErr error) {
+	srcClient := newClient(s.objClient, s.mdstore, s.tracker, "", s.defaultChunkTTL)
+	dstClient := newClient(dst.objClient, dst.mdstore, dst.tracker, tmpID, dst.defaultChunkTTL)
+	defer func() {
+		if err := dstClient.Close(); retErr == nil {
+			retErr = err
+		}
+	}()
+	copied := make(map[string]bool)
+	var copyChunk func(ID) error
+	copyChunk = func(chunkID ID) error {
+		if copied[chunkID.HexString()] {
+			return nil
+		}
+		copied[chunkID.HexString()] = true
+		md, err := s.mdstore.Get(ctx, chunkID)
+		if err != nil {
+			return err
+		}
+		// The chunks that a chunk points to are copied first, so the chunk's
+		// tracker object does not have dangling references.
+		for _, pointsTo := range md.PointsTo {
+			if err := copyChunk(pointsTo); err != nil {
+				return err
+			}
+		}
+		buf := &bytes.Buffer{}
+		if err := srcClient.Get(ctx, chunkID, buf); err != nil {
+			return err
+		}
+		dstID, err := dstClient.Create(ctx, *md, buf)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(dstID, chunkID) {
+			return errors.Errorf("copy of chunk %v has a different ID (%v)", chunkID.HexString(), dstID.HexString())
+		}
+		return nil
+	}
+	for _, chunkID := range chunkIDs {
+		if err := copyChunk(chunkID); err != nil {
+			return err
+		}
+	}
+	return cb()
+}
+
+// Check returns an error that matches ErrChunkNotExists if the chunk with
+// chunkID is not in object storage. The content of the chunk is not read.
+func (s *Storage) Check(ctx context.Context, chunkID ID) error {
+	p := chunkPath(chunkID)
+	if !s.objClient.Exists(ctx, p) {
+		return &notExistError{chunkID: chunkID, err: errors.Errorf("object %v does not exist", p)}
+	}
+	return nil
+}
+
 // NewDeleter creates a deleter for use with a tracker.GC
 func (s *Storage) NewDeleter() track.Deleter {
 	return &deleter{
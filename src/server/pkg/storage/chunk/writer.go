@@ -55,6 +55,9 @@ type Writer struct {
 	chunkSize *chunkSize
 	splitMask uint64
 	noUpload  bool
+	// rewriteSmallChunks rewrites the data of copied data refs that
+	// reference chunks smaller than the minimum chunk size.
+	rewriteSmallChunks bool
 
 	ctx                     context.Context
 	cancel                  context.CancelFunc
@@ -336,7 +339,9 @@ func (w *Writer) maybeBufferDataRef(dataRef *DataRef) error {
 		// - We are at a chunk split point.
 		// - The data ref does not reference an edge chunk.
 		// - It is the first data reference for the chunk.
-		if w.buf.Len() != 0 || dataRef.Ref.Edge || dataRef.OffsetBytes != 0 {
+		// - The data ref references a chunk that is at least the minimum chunk size
+		//   (if small chunks are rewritten).
+		if w.buf.Len() != 0 || dataRef.Ref.Edge || dataRef.OffsetBytes != 0 || (w.rewriteSmallChunks && dataRef.Ref.SizeBytes < int64(w.chunkSize.min)) {
 			return w.flushDataRef(dataRef)
 		}
 	} else {
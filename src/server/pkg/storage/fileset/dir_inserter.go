@@ -18,33 +18,52 @@ func NewDirInserter(x FileSet) FileSet {
 	return &dirInserter{x: x}
 }
 
-// Iterate calls cb once for every file in lexicographical order by path
+// Iterate calls cb once for every file in lexicographical order by path.
+// An entry is inserted for each directory the first time that it is needed,
+// and directory entries that have already been emitted are skipped.
 func (s *dirInserter) Iterate(ctx context.Context, cb func(File) error, _ ...bool) error {
-	lastPath := ""
-	var emit func(p string, f File) error
-	emit = func(p string, f File) error {
-		parent := Clean(parentOf(p), true)
-		if p == "/" || lastPath >= parent {
-			if err := cb(f); err != nil {
+	// dirs are the directories, from the root, that contain the last emitted
+	// path. Because paths are emitted in lexicographical order, a directory is
+	// never needed again once a path outside of it has been emitted.
+	var dirs []string
+	return s.x.Iterate(ctx, func(f File) error {
+		p := f.Index().Path
+		chain := ancestorsOf(p)
+		if IsDir(p) {
+			chain = append(chain, p)
+		}
+		i := 0
+		for i < len(dirs) && i < len(chain) && dirs[i] == chain[i] {
+			i++
+		}
+		dirs = dirs[:i]
+		for _, dir := range chain[i:] {
+			df := File(dirFile{path: dir})
+			if dir == p {
+				df = f
+			}
+			if err := cb(df); err != nil {
 				return err
 			}
-			lastPath = p
-			return nil
-		}
-		// need to create entry for parent
-		df := dirFile{
-			path: parent,
+			dirs = append(dirs, dir)
 		}
-		if err := emit(parent, df); err != nil {
-			return err
+		if IsDir(p) {
+			return nil
 		}
-		return emit(p, f)
-	}
-	return s.x.Iterate(ctx, func(f File) error {
-		return emit(f.Index().Path, f)
+		return cb(f)
 	})
 }
 
+// ancestorsOf returns the directories that contain x, from the root.
+func ancestorsOf(x string) []string {
+	var ancestors []string
+	for x != "/" {
+		x = Clean(parentOf(x), true)
+		ancestors = append([]string{x}, ancestors...)
+	}
+	return ancestors
+}
+
 func parentOf(x string) string {
 	x = strings.TrimRight(x, "/")
 	y := path.Dir(x)
@@ -17,8 +17,17 @@ import (
 type MergeReader struct {
 	chunks   *chunk.Storage
 	fileSets []FileSet
+	resolve  ConflictFunc
 }
 
+// ConflictFunc resolves a conflict, which is a path with content in more than
+// one of the file sets being merged (after the deletes in the later file sets
+// are applied). files contains the file from each of the file sets with
+// content for the path, in file set order, without the content that is
+// deleted by a later file set. The returned file is used in place of the
+// merged file, and the path is skipped if the returned file is nil.
+type ConflictFunc func(path string, files []File) (File, error)
+
 func newMergeReader(chunks *chunk.Storage, fileSets []FileSet) *MergeReader {
 	return &MergeReader{
 		chunks:   chunks,
@@ -38,7 +47,7 @@ func (mr *MergeReader) iterate(ctx context.Context, cb func(File) error) error {
 	var ss []stream
 	for _, fs := range mr.fileSets {
 		ss = append(ss, &fileStream{
-			iterator: NewIterator(ctx, fs, true),
+			iterator: NewIterator(ctx, fs, WithDeletive()),
 			priority: len(ss),
 			deletive: true,
 		})
@@ -77,6 +86,15 @@ func (mr *MergeReader) iterate(ctx context.Context, cb func(File) error) error {
 			}
 			return cb(newFileReader(ctx, mr.chunks, fss[0].file.Index()))
 		}
+		if mr.resolve != nil {
+			if files := mr.additiveFiles(ctx, fss); len(files) > 1 {
+				f, err := mr.resolve(fss[0].key(), files)
+				if err != nil || f == nil {
+					return err
+				}
+				return cb(f)
+			}
+		}
 		idx := mergeFile(fss)
 		// Handle a full delete.
 		if len(idx.File.Parts) == 0 {
@@ -86,6 +104,97 @@ func (mr *MergeReader) iterate(ctx context.Context, cb func(File) error) error {
 	})
 }
 
+// additiveFiles returns the additive files in the file streams, in file set
+// order (the file streams are in priority order), with the deletes from the
+// later file sets applied. A file that is fully deleted by a later file set
+// is not returned, and the parts with the tags that are deleted by a later
+// file set are removed from a file.
+func (mr *MergeReader) additiveFiles(ctx context.Context, fss []*fileStream) []File {
+	var files []File
+	deletedTags := make(map[string]struct{})
+	for _, fs := range fss {
+		idx := fs.file.Index()
+		if fs.deletive {
+			// Handle a full delete.
+			if idx.File.Parts == nil {
+				break
+			}
+			for _, part := range idx.File.Parts {
+				deletedTags[part.Tag] = struct{}{}
+			}
+			continue
+		}
+		var parts []*index.Part
+		for _, part := range idx.File.Parts {
+			if _, ok := deletedTags[part.Tag]; !ok {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		f := fs.file
+		if len(parts) < len(idx.File.Parts) {
+			idx = proto.Clone(idx).(*index.Index)
+			idx.File.Parts = parts
+			f = mr.withIndex(ctx, f, idx)
+		}
+		files = append([]File{f}, files...)
+	}
+	return files
+}
+
+// withIndex returns a file with the content of idx in place of f, keeping the
+// file set position of an inputFile.
+func (mr *MergeReader) withIndex(ctx context.Context, f File, idx *index.Index) File {
+	if f, ok := f.(*inputFile); ok {
+		return &inputFile{File: mr.withIndex(ctx, f.File, idx), input: f.input}
+	}
+	return newFileReader(ctx, mr.chunks, idx)
+}
+
+// inputFileSet tags the files of one of the file sets being merged with the
+// file set's position in the merge, so that a ConflictFunc can tell which file
+// set each of the conflicting files came from.
+type inputFileSet struct {
+	x     FileSet
+	input int
+}
+
+func (ifs *inputFileSet) Iterate(ctx context.Context, cb func(File) error, deletive ...bool) error {
+	return ifs.x.Iterate(ctx, func(f File) error {
+		return cb(&inputFile{File: f, input: ifs.input})
+	}, deletive...)
+}
+
+type inputFile struct {
+	File
+	input int
+}
+
+// priorityResolver returns a ConflictFunc that picks the file from the file
+// set with the highest priority, where priorities[i] is the priority of the
+// i-th file set (the later file set wins a tie). The files must be from
+// inputFileSets. onConflict, if set, is called with the path and the
+// position of the winning file set.
+func priorityResolver(priorities []int, onConflict func(path string, winner int) error) ConflictFunc {
+	return func(p string, files []File) (File, error) {
+		var winner *inputFile
+		for _, f := range files {
+			f := f.(*inputFile)
+			if winner == nil || priorities[f.input] >= priorities[winner.input] {
+				winner = f
+			}
+		}
+		if onConflict != nil {
+			if err := onConflict(p, winner.input); err != nil {
+				return nil, err
+			}
+		}
+		return winner.File, nil
+	}
+}
+
 func mergeFile(fss []*fileStream) *index.Index {
 	mergeIdx := &index.Index{
 		Path: fss[0].file.Index().Path,
@@ -150,7 +259,7 @@ func (mr *MergeReader) iterateDeletive(ctx context.Context, cb func(File) error)
 	var ss []stream
 	for _, fs := range mr.fileSets {
 		ss = append(ss, &fileStream{
-			iterator: NewIterator(ctx, fs, true),
+			iterator: NewIterator(ctx, fs, WithDeletive()),
 			priority: len(ss),
 		})
 	}
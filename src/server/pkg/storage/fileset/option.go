@@ -1,8 +1,10 @@
 package fileset
 
 import (
+	"io"
 	"time"
 
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/renew"
 	"golang.org/x/sync/semaphore"
@@ -43,6 +45,14 @@ func WithLevelSizeBase(base int) StorageOption {
 	}
 }
 
+// WithMaxFanIn sets the maximum number of file sets that are merged by one
+// level of a distributed compaction, which EstimateCompaction assumes.
+func WithMaxFanIn(max int) StorageOption {
+	return func(s *Storage) {
+		s.maxFanIn = max
+	}
+}
+
 // WithMaxOpenFileSets sets the maximum number of filesets that will be open
 // (potentially buffered in memory) at a time.
 func WithMaxOpenFileSets(max int) StorageOption {
@@ -51,6 +61,56 @@ func WithMaxOpenFileSets(max int) StorageOption {
 	}
 }
 
+// WithPrefetch sets the number of chunks that are fetched concurrently ahead
+// of the file being read when iterating over a file set. Files are still
+// delivered in order, and at most window chunks are buffered at a time.
+func WithPrefetch(window int) StorageOption {
+	return func(s *Storage) {
+		s.prefetch = window
+	}
+}
+
+// IteratorOption configures an iterator.
+type IteratorOption func(i *Iterator)
+
+// WithDeletive configures the iterator to iterate over the deletive files
+// in the file set.
+func WithDeletive() IteratorOption {
+	return func(i *Iterator) {
+		i.deletive = true
+	}
+}
+
+// WithPrefetchFiles configures the iterator to fetch the content of up to n
+// files ahead of the file returned by Next. Files are still returned in
+// order, and the prefetched content is buffered in memory, up to the limit
+// set by WithPrefetchBytes.
+func WithPrefetchFiles(n int) IteratorOption {
+	return func(i *Iterator) {
+		i.prefetch = n
+	}
+}
+
+// WithPrefetchBytes sets the limit on the number of bytes of prefetched
+// content that the iterator buffers at a time (64MB by default). Files that
+// are larger than the limit are not prefetched.
+func WithPrefetchBytes(n int64) IteratorOption {
+	return func(i *Iterator) {
+		i.prefetchBytes = n
+	}
+}
+
+// WithFileBudget configures the iterator to stop if receiving the next file
+// from the file set takes longer than budget, for returning partial results
+// rather than blocking. A truncated iteration returns io.EOF, and Truncated
+// reports that it was truncated. Files are received one at a time (rather
+// than in batches), so the budget applies to each file.
+func WithFileBudget(budget time.Duration) IteratorOption {
+	return func(i *Iterator) {
+		i.fileBudget = budget
+	}
+}
+
 // UnorderedWriterOption configures an UnorderedWriter.
 type UnorderedWriterOption func(*UnorderedWriter)
 
@@ -87,3 +147,133 @@ func WithTTL(ttl time.Duration) WriterOption {
 		w.ttl = ttl
 	}
 }
+
+// WithChunkWriterOptions sets the options for the chunk writer used by the
+// file set writer.
+func WithChunkWriterOptions(opts ...chunk.WriterOption) WriterOption {
+	return func(w *Writer) {
+		w.chunkWriterOpts = append(w.chunkWriterOpts, opts...)
+	}
+}
+
+// CompactOption configures a compaction.
+type CompactOption func(*compactConfig)
+
+type compactConfig struct {
+	indexOpts        []index.Option
+	targetChunkSize  int
+	dryRun           bool
+	sidecar          io.Writer
+	forceSingleLayer bool
+}
+
+// WithIndexOptions sets the index options used when reading the input file
+// sets of a compaction.
+func WithIndexOptions(opts ...index.Option) CompactOption {
+	return func(c *compactConfig) {
+		c.indexOpts = append(c.indexOpts, opts...)
+	}
+}
+
+// WithTargetChunkSize sets the target size for the chunks written by a
+// compaction. Data in input chunks that are smaller than half of the target
+// size is rewritten, which coalesces small chunks into chunks near the target
+// size.
+func WithTargetChunkSize(size int) CompactOption {
+	return func(c *compactConfig) {
+		c.targetChunkSize = size
+	}
+}
+
+// WithDryRun configures Compact to plan a distributed compaction of the input
+// file sets, rather than compacting them. The plan is returned in the
+// CompactStats, and no output file set is written.
+func WithDryRun() CompactOption {
+	return func(c *compactConfig) {
+		c.dryRun = true
+	}
+}
+
+// WithIndexSidecar configures Compact to write a sorted index sidecar of the
+// output file set to w (see IndexSidecar), which can be loaded with
+// LoadIndexSidecar. The sidecar isn't written for a dry run.
+func WithIndexSidecar(w io.Writer) CompactOption {
+	return func(c *compactConfig) {
+		c.sidecar = w
+	}
+}
+
+// ForceSingleLayer ensures that the output of a compaction is a single layer,
+// which is read without a merge. Opening the output also reads the other file
+// sets under its path (as a prefix), so they are compacted into the output
+// along with the inputs (after them, since they would be merged after the
+// output), and deleted.
+func ForceSingleLayer() CompactOption {
+	return func(c *compactConfig) {
+		c.forceSingleLayer = true
+	}
+}
+
+// CopyOption configures a CopyFilesIsolated copy.
+type CopyOption func(*copyConfig)
+
+type copyConfig struct {
+	progressInterval int
+	progress         func(*CopyStats) error
+}
+
+// WithCopyProgress calls cb with the running stats of a copy after every n
+// files (copied or skipped), and with the final stats once the copy is done.
+// The copy stops if cb returns an error.
+func WithCopyProgress(n int, cb func(*CopyStats) error) CopyOption {
+	return func(c *copyConfig) {
+		c.progressInterval = n
+		c.progress = cb
+	}
+}
+
+// ImportOption configures a tar stream import.
+type ImportOption func(*importConfig)
+
+type importConfig struct {
+	contentTypeCallback func(p, contentType string) error
+	manifest            io.Reader
+}
+
+// WithContentTypes detects the content type of each imported file from its
+// first 512 bytes, and calls cb with the file's path and content type. The
+// content type is not stored in the file set, so cb is the only way to get
+// it. Detection reads ahead in each file, so it is only done when this option
+// is set.
+func WithContentTypes(cb func(p, contentType string) error) ImportOption {
+	return func(c *importConfig) {
+		c.contentTypeCallback = cb
+	}
+}
+
+// WithManifest verifies the imported files against the manifest in r, as
+// written by WriteManifest. The import errors if the size or content hash of
+// a file does not match its manifest entry, or if the files in the tar stream
+// and the manifest differ.
+func WithManifest(r io.Reader) ImportOption {
+	return func(c *importConfig) {
+		c.manifest = r
+	}
+}
+
+// TarOption configures a tar stream export.
+type TarOption func(*tarConfig)
+
+type tarConfig struct {
+	sha256Sums bool
+}
+
+// WithSHA256Sums appends a SHA256SumsPath entry to the tar stream, listing the
+// SHA-256 hash of the content of each file in the format of sha256sum, so
+// the extracted archive can be verified with sha256sum -c. The paths in the
+// listing are relative to the root of the archive.
+func WithSHA256Sums() TarOption {
+	return func(c *tarConfig) {
+		c.sha256Sums = true
+	}
+}
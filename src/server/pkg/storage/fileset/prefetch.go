@@ -0,0 +1,140 @@
+package fileset
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync/atomic"
+
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// iteratePrefetch iterates over the files read by the index reader, while
+// the chunks referenced by the upcoming files are fetched concurrently.
+// The files are read ahead in a separate goroutine, and each chunk holds a
+// slot in the prefetch window until all of the data that references it has
+// been read (or skipped).
+func (r *Reader) iteratePrefetch(ctx context.Context, ir *index.Reader, cb func(File) error) error {
+	sem := semaphore.NewWeighted(int64(r.prefetch))
+	files := make(chan *prefetchFileReader, r.prefetch)
+	eg, prefetchCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		defer close(files)
+		var last *prefetchChunk
+		defer func() {
+			if last != nil {
+				last.unref()
+			}
+		}()
+		return ir.Iterate(prefetchCtx, func(idx *index.Index) error {
+			dataRefs := getDataRefs(idx.File.Parts)
+			pfr := &prefetchFileReader{
+				FileReader:  newFileReader(ctx, r.chunks, idx),
+				dataReaders: make(chan *prefetchDataReader, len(dataRefs)),
+			}
+			defer close(pfr.dataReaders)
+			// The file is sent before its chunks are prefetched, so that a
+			// file that references more chunks than the prefetch window can
+			// be read while its chunks are being fetched.
+			select {
+			case files <- pfr:
+			case <-prefetchCtx.Done():
+				return prefetchCtx.Err()
+			}
+			for _, dataRef := range dataRefs {
+				if last == nil || !bytes.Equal(last.dr.DataRef().Ref.Id, dataRef.Ref.Id) {
+					if last != nil {
+						last.unref()
+						last = nil
+					}
+					if err := sem.Acquire(prefetchCtx, 1); err != nil {
+						return err
+					}
+					last = &prefetchChunk{
+						dr:      r.chunks.NewDataReader(prefetchCtx, dataRef, nil),
+						refs:    1,
+						release: func() { sem.Release(1) },
+					}
+					go func(dr *chunk.DataReader) {
+						// Errors are surfaced when the data is read.
+						dr.Prefetch()
+					}(last.dr)
+				}
+				last.ref()
+				pfr.dataReaders <- &prefetchDataReader{
+					DataReader: r.chunks.NewDataReader(prefetchCtx, dataRef, last.dr),
+					chunk:      last,
+				}
+			}
+			return nil
+		})
+	})
+	eg.Go(func() error {
+		for pfr := range files {
+			if err := cb(pfr); err != nil {
+				return err
+			}
+			pfr.skip()
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+// prefetchChunk is a chunk in the prefetch window.
+type prefetchChunk struct {
+	dr      *chunk.DataReader
+	refs    int64
+	release func()
+}
+
+func (pc *prefetchChunk) ref() {
+	atomic.AddInt64(&pc.refs, 1)
+}
+
+func (pc *prefetchChunk) unref() {
+	if atomic.AddInt64(&pc.refs, -1) == 0 {
+		pc.release()
+	}
+}
+
+type prefetchDataReader struct {
+	*chunk.DataReader
+	chunk *prefetchChunk
+}
+
+// prefetchFileReader is a file reader with prefetched content.
+type prefetchFileReader struct {
+	*FileReader
+	dataReaders chan *prefetchDataReader
+	read        bool
+}
+
+// Content writes the content of the file.
+// The prefetched content can only be read once, later calls read the
+// content from chunk storage.
+func (pfr *prefetchFileReader) Content(w io.Writer) error {
+	if pfr.read {
+		return pfr.FileReader.Content(w)
+	}
+	pfr.read = true
+	for dr := range pfr.dataReaders {
+		err := dr.Get(w)
+		dr.chunk.unref()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skip releases the prefetched content that was not read.
+func (pfr *prefetchFileReader) skip() {
+	pfr.read = true
+	for dr := range pfr.dataReaders {
+		dr.chunk.unref()
+	}
+}
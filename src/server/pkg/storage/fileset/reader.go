@@ -1,10 +1,13 @@
 package fileset
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"sort"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
 )
@@ -15,6 +18,7 @@ type Reader struct {
 	chunks    *chunk.Storage
 	path      string
 	indexOpts []index.Option
+	prefetch  int
 }
 
 func newReader(store Store, chunks *chunk.Storage, p string, opts ...index.Option) *Reader {
@@ -40,6 +44,9 @@ func (r *Reader) Iterate(ctx context.Context, cb func(File) error, deletive ...b
 		})
 	}
 	ir := index.NewReader(r.chunks, md.Additive, r.indexOpts...)
+	if r.prefetch > 0 {
+		return r.iteratePrefetch(ctx, ir, cb)
+	}
 	return ir.Iterate(ctx, func(idx *index.Index) error {
 		return cb(newFileReader(ctx, r.chunks, idx))
 	})
@@ -71,3 +78,57 @@ func (fr *FileReader) Content(w io.Writer) error {
 	r := fr.chunks.NewReader(fr.ctx, dataRefs)
 	return r.Get(w)
 }
+
+// fileReaderAt reads ranges of a file's content, by mapping offsets in the
+// content to the data references that store them. Only the chunks that
+// store a range are read.
+type fileReaderAt struct {
+	ctx      context.Context
+	chunks   *chunk.Storage
+	dataRefs []*chunk.DataRef
+	// offsets contains the offset in the content of each data reference.
+	offsets []int64
+	size    int64
+}
+
+func newFileReaderAt(ctx context.Context, chunks *chunk.Storage, idx *index.Index) *fileReaderAt {
+	r := &fileReaderAt{
+		ctx:      ctx,
+		chunks:   chunks,
+		dataRefs: getDataRefs(idx.File.Parts),
+	}
+	for _, dataRef := range r.dataRefs {
+		r.offsets = append(r.offsets, r.size)
+		r.size += dataRef.SizeBytes
+	}
+	return r
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *fileReaderAt) ReadAt(data []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.Errorf("negative offset (%v)", off)
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	// Find the first data reference that stores content at the offset.
+	i := sort.Search(len(r.dataRefs), func(i int) bool {
+		return r.offsets[i]+r.dataRefs[i].SizeBytes > off
+	})
+	var n int
+	var seed *chunk.DataReader
+	for ; i < len(r.dataRefs) && n < len(data); i++ {
+		dr := r.chunks.NewDataReader(r.ctx, r.dataRefs[i], seed)
+		buf := &bytes.Buffer{}
+		if err := dr.Get(buf); err != nil {
+			return n, err
+		}
+		n += copy(data[n:], buf.Bytes()[off+int64(n)-r.offsets[i]:])
+		seed = dr
+	}
+	if n < len(data) {
+		return n, io.EOF
+	}
+	return n, nil
+}
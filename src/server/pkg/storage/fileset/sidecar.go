@@ -0,0 +1,70 @@
+package fileset
+
+import (
+	"io"
+	"sort"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/pbutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
+)
+
+// IndexSidecar is a standalone sorted index of a file set, written by
+// Compact with WithIndexSidecar. It maps each file's path to the data
+// references (chunk, offset and size) that store its content, so a file's
+// content can be looked up without reading the file set's index.
+//
+// The serialized sidecar is a stream of index.Index messages in
+// lexicographical path order, each prefixed with its length as a little
+// endian int64 (see pbutil), and containing only a path and its data
+// references.
+type IndexSidecar struct {
+	entries []*index.Index
+}
+
+// LoadIndexSidecar loads a sidecar written by Compact.
+func LoadIndexSidecar(r io.Reader) (*IndexSidecar, error) {
+	pbr := pbutil.NewReader(r)
+	sidecar := &IndexSidecar{}
+	for {
+		idx := &index.Index{}
+		if err := pbr.Read(idx); err != nil {
+			if errors.Is(err, io.EOF) {
+				return sidecar, nil
+			}
+			return nil, err
+		}
+		if n := len(sidecar.entries); n > 0 && sidecar.entries[n-1].Path >= idx.Path {
+			return nil, errors.Errorf("index sidecar is not sorted, %v follows %v", idx.Path, sidecar.entries[n-1].Path)
+		}
+		sidecar.entries = append(sidecar.entries, idx)
+	}
+}
+
+// Lookup returns the data references that store the content of the file at
+// p, or false if the file isn't in the sidecar.
+func (s *IndexSidecar) Lookup(p string) ([]*chunk.DataRef, bool) {
+	i := sort.Search(len(s.entries), func(i int) bool {
+		return s.entries[i].Path >= p
+	})
+	if i == len(s.entries) || s.entries[i].Path != p {
+		return nil, false
+	}
+	return s.entries[i].File.DataRefs, true
+}
+
+// Len returns the number of files in the sidecar.
+func (s *IndexSidecar) Len() int {
+	return len(s.entries)
+}
+
+// writeSidecarEntry writes the sidecar entry for idx, which is an index
+// written by a compaction.
+func writeSidecarEntry(pbw pbutil.Writer, idx *index.Index) error {
+	_, err := pbw.Write(&index.Index{
+		Path: idx.Path,
+		File: &index.File{DataRefs: idx.File.DataRefs},
+	})
+	return err
+}
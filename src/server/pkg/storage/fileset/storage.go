@@ -1,19 +1,27 @@
 package fileset
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
 	units "github.com/docker/go-units"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/pbutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/renew"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/track"
+	"github.com/pachyderm/pachyderm/src/server/pkg/tarutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -30,6 +38,9 @@ const (
 	// DefaultLevelSizeBase is the default base of the exponential growth function
 	// for level sizes in the compacted representation of a file set.
 	DefaultLevelSizeBase = 10
+	// DefaultMaxFanIn is the default for the maximum number of file sets that
+	// are merged by one level of a distributed compaction.
+	DefaultMaxFanIn = 50
 	// Diff is the suffix of a path that points to the diff of the prefix.
 	Diff = "diff"
 	// Compacted is the suffix of a path that points to the compaction of the prefix.
@@ -41,6 +52,8 @@ const (
 var (
 	// ErrNoFileSetFound is returned by the methods on Storage when a fileset does not exist
 	ErrNoFileSetFound = errors.Errorf("no fileset found")
+	// ErrFileNotFound is returned by Stat when a file does not exist in a fileset
+	ErrFileNotFound = errors.Errorf("file not found")
 )
 
 // Storage is the abstraction that manages fileset storage.
@@ -51,7 +64,9 @@ type Storage struct {
 	memThreshold, shardThreshold int64
 	levelZeroSize                int64
 	levelSizeBase                int
+	maxFanIn                     int
 	filesetSem                   *semaphore.Weighted
+	prefetch                     int
 }
 
 // NewStorage creates a new Storage.
@@ -64,6 +79,7 @@ func NewStorage(store Store, tr track.Tracker, chunks *chunk.Storage, opts ...St
 		shardThreshold: DefaultShardThreshold,
 		levelZeroSize:  DefaultLevelZeroSize,
 		levelSizeBase:  DefaultLevelSizeBase,
+		maxFanIn:       DefaultMaxFanIn,
 		filesetSem:     semaphore.NewWeighted(math.MaxInt64),
 	}
 	for _, opt := range opts {
@@ -99,10 +115,10 @@ func (s *Storage) newWriter(ctx context.Context, fileSet string, opts ...WriterO
 	return newWriter(ctx, s.store, s.tracker, s.chunks, fileSet, opts...)
 }
 
-// TODO: Expose some notion of read ahead (read a certain number of chunks in parallel).
-// this will be necessary to speed up reading large files.
 func (s *Storage) newReader(fileSet string, opts ...index.Option) *Reader {
-	return newReader(s.store, s.chunks, fileSet, opts...)
+	r := newReader(s.store, s.chunks, fileSet, opts...)
+	r.prefetch = s.prefetch
+	return r
 }
 
 // Open opens a file set for reading.
@@ -126,6 +142,171 @@ func (s *Storage) Open(ctx context.Context, fileSets []string, opts ...index.Opt
 	return newMergeReader(s.chunks, fss), nil
 }
 
+// OpenWithTouch opens file sets for reading, like Open, after extending their
+// leases to at least ttl from now, so that file sets that are actively being
+// read are not garbage collected. Touching never shortens a lease, and file
+// sets that don't expire are left that way. Opening a file set that has
+// already expired errors with track.ErrExpired.
+func (s *Storage) OpenWithTouch(ctx context.Context, fileSets []string, ttl time.Duration, opts ...index.Option) (FileSet, error) {
+	for _, fileSet := range fileSets {
+		if err := s.tracker.ExtendTTLPrefix(ctx, filesetObjectID(fileSet), ttl); err != nil {
+			return nil, errors.Wrapf(err, "error touching fileset %v", fileSet)
+		}
+	}
+	return s.Open(ctx, fileSets, opts...)
+}
+
+// OpenWithConflicts opens file sets for reading, like Open, except that the
+// paths with content in more than one of the file sets are resolved by
+// resolve rather than merged.
+func (s *Storage) OpenWithConflicts(ctx context.Context, fileSets []string, resolve ConflictFunc, opts ...index.Option) (FileSet, error) {
+	if len(fileSets) == 1 {
+		return s.Open(ctx, fileSets, opts...)
+	}
+	var fss []FileSet
+	for _, fileSet := range fileSets {
+		fs, err := s.Open(ctx, []string{fileSet}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		fss = append(fss, fs)
+	}
+	mr := newMergeReader(s.chunks, fss)
+	mr.resolve = resolve
+	return mr, nil
+}
+
+// OpenWithPriority opens file sets for reading, like OpenWithConflicts, except
+// that the conflicts are resolved by priority. priorities[i] is the priority
+// of fileSets[i], and the file from the file set with the highest priority
+// wins a conflict (the later file set wins a tie). onConflict, if set, is
+// called with the path and the winning file set of each conflict.
+func (s *Storage) OpenWithPriority(ctx context.Context, fileSets []string, priorities []int, onConflict func(path, winner string) error, opts ...index.Option) (FileSet, error) {
+	if len(priorities) != len(fileSets) {
+		return nil, errors.Errorf("got %v priorities for %v file sets", len(priorities), len(fileSets))
+	}
+	if len(fileSets) == 1 {
+		return s.Open(ctx, fileSets, opts...)
+	}
+	var fss []FileSet
+	for i, fileSet := range fileSets {
+		fs, err := s.Open(ctx, []string{fileSet}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		fss = append(fss, &inputFileSet{x: fs, input: i})
+	}
+	mr := newMergeReader(s.chunks, fss)
+	mr.resolve = priorityResolver(priorities, func(p string, winner int) error {
+		if onConflict == nil {
+			return nil
+		}
+		return onConflict(p, fileSets[winner])
+	})
+	return mr, nil
+}
+
+// IterateFrom iterates over the files in a file set, starting at startPath
+// (inclusive). The start path is pushed down into the index reads, so the
+// index and content chunks for the files before startPath are not read.
+// This can be used for paginating through a file set.
+func (s *Storage) IterateFrom(ctx context.Context, fileSet, startPath string, cb func(File) error) error {
+	fs, err := s.Open(ctx, []string{fileSet}, index.WithRange(&index.PathRange{Lower: startPath}))
+	if err != nil {
+		return err
+	}
+	return fs.Iterate(ctx, cb)
+}
+
+// Stat returns the tar header for the file at path p in a file set, which is
+// the header that the file's tar entry is written with. The header is read
+// from the index, so the file's content is not read.
+func (s *Storage) Stat(ctx context.Context, fileSet, p string) (*tar.Header, error) {
+	fs, err := s.Open(ctx, []string{fileSet}, index.WithExact(p))
+	if err != nil {
+		return nil, err
+	}
+	var hdr *tar.Header
+	if err := fs.Iterate(ctx, func(f File) error {
+		idx := f.Index()
+		hdr = tarutil.NewHeader(idx.Path, index.SizeBytes(idx))
+		return errutil.ErrBreak
+	}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+		return nil, err
+	}
+	if hdr == nil {
+		return nil, errors.Wrapf(ErrFileNotFound, "stat %v in fileset %v", p, fileSet)
+	}
+	return hdr, nil
+}
+
+// OpenReaderAt returns a reader for random access to the content of the file
+// at path p in a file set, along with the size of the content. Each read
+// maps its range to the data references that store it, so only the chunks
+// that store the range are read.
+func (s *Storage) OpenReaderAt(ctx context.Context, fileSet, p string) (io.ReaderAt, int64, error) {
+	fs, err := s.Open(ctx, []string{fileSet}, index.WithExact(p))
+	if err != nil {
+		return nil, 0, err
+	}
+	var idx *index.Index
+	if err := fs.Iterate(ctx, func(f File) error {
+		idx = f.Index()
+		return errutil.ErrBreak
+	}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+		return nil, 0, err
+	}
+	if idx == nil {
+		return nil, 0, errors.Wrapf(ErrFileNotFound, "open %v in fileset %v", p, fileSet)
+	}
+	r := newFileReaderAt(ctx, s.chunks, idx)
+	return r, r.size, nil
+}
+
+// CountPrefix returns the number of files, and the total size of their
+// content, under a path prefix in a file set (e.g. "/dir/" for the files in
+// a directory). The prefix is pushed down into the index reads, so the index
+// entries outside of the prefix are not read, and neither is any content.
+func (s *Storage) CountPrefix(ctx context.Context, fileSet, prefix string) (files, bytes int64, err error) {
+	fs, err := s.Open(ctx, []string{fileSet}, index.WithPrefix(prefix))
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := fs.Iterate(ctx, func(f File) error {
+		files++
+		bytes += index.SizeBytes(f.Index())
+		return nil
+	}); err != nil {
+		return 0, 0, err
+	}
+	return files, bytes, nil
+}
+
+// IterateChunks calls cb with a data reference for each of the chunks that
+// store the content of the files in a file set. Each chunk is visited once,
+// even if it stores the content of more than one file. The chunks that store
+// the file set's index are not visited.
+func (s *Storage) IterateChunks(ctx context.Context, fileSet string, cb func(*chunk.DataRef) error) error {
+	fs, err := s.Open(ctx, []string{fileSet})
+	if err != nil {
+		return err
+	}
+	visited := make(map[string]bool)
+	return fs.Iterate(ctx, func(f File) error {
+		for _, dataRef := range getDataRefs(f.Index().File.Parts) {
+			id := string(dataRef.Ref.Id)
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			if err := cb(chunk.Reference(dataRef)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // Shard shards the file set into path ranges.
 // TODO This should be extended to be more configurable (different criteria
 // for creating shards).
@@ -133,13 +314,15 @@ func (s *Storage) Shard(ctx context.Context, fs FileSet, cb ShardCallback) error
 	return shard(ctx, fs, s.shardThreshold, cb)
 }
 
-// ShardCallback is a callback that returns a path range for each shard.
-type ShardCallback func(*index.PathRange) error
+// ShardCallback is a callback that returns a path range for each shard, along
+// with the size of the content in the path range.
+type ShardCallback func(pathRange *index.PathRange, sizeBytes int64) error
 
 // shard creates shards (path ranges) from the file set streams being merged.
 // A shard is created when the size of the content for a path range is greater than
 // the passed in shard threshold.
-// For each shard, the callback is called with the path range for the shard.
+// For each shard, the callback is called with the path range and content size
+// for the shard.
 func shard(ctx context.Context, fs FileSet, shardThreshold int64, cb ShardCallback) error {
 	var size int64
 	pathRange := &index.PathRange{}
@@ -147,7 +330,7 @@ func shard(ctx context.Context, fs FileSet, shardThreshold int64, cb ShardCallba
 		// A shard is created when we have encountered more than shardThreshold content bytes.
 		if size >= shardThreshold {
 			pathRange.Upper = f.Index().Path
-			if err := cb(pathRange); err != nil {
+			if err := cb(pathRange, size); err != nil {
 				return err
 			}
 			size = 0
@@ -160,7 +343,7 @@ func shard(ctx context.Context, fs FileSet, shardThreshold int64, cb ShardCallba
 	}); err != nil {
 		return err
 	}
-	return cb(pathRange)
+	return cb(pathRange, size)
 }
 
 // Copy copies the fileset at srcPrefix to dstPrefix. It does *not* perform compaction
@@ -173,19 +356,166 @@ func (s *Storage) Copy(ctx context.Context, srcPrefix, dstPrefix string, ttl tim
 	})
 }
 
+// CopyTo copies the fileset at srcPrefix to dstPrefix in dst, along with the
+// chunks that store its index and content, for example to replicate a fileset
+// to another object store. The references in the copied fileset do not need
+// to be rewritten, because chunks have the same IDs in every store.
+// ttl sets the time to live on the keys under dstPrefix if ttl == 0, it is ignored
+func (s *Storage) CopyTo(ctx context.Context, dst *Storage, srcPrefix, dstPrefix string, ttl time.Duration) error {
+	return s.store.Walk(ctx, srcPrefix, func(srcPath string) error {
+		dstPath := dstPrefix + srcPath[len(srcPrefix):]
+		md, err := s.store.Get(ctx, srcPath)
+		if err != nil {
+			return err
+		}
+		var chunkIDs []chunk.ID
+		for _, idx := range []*index.Index{md.Additive, md.Deletive} {
+			chunkIDs = append(chunkIDs, index.PointsTo(idx)...)
+		}
+		return s.chunks.CopyTo(ctx, dst.chunks, "copy-"+uuid.NewWithoutDashes(), chunkIDs, func() error {
+			return copyPath(ctx, s.store, dst.store, srcPath, dstPath, dst.tracker, ttl)
+		})
+	})
+}
+
+// Concat concatenates the file sets in inputFileSets into outputFileSet
+// without compacting them. Each of the input file sets becomes a layer of
+// the output file set, so no chunks are rewritten, and the physical
+// compaction of the layers is deferred to a later compaction. The paths in
+// each input file set must sort after the paths in the previous input file
+// sets, otherwise an error is returned.
+// ttl sets the time to live on the keys under outputFileSet if ttl == 0, it is ignored
+func (s *Storage) Concat(ctx context.Context, outputFileSet string, inputFileSets []string, ttl time.Duration) error {
+	var prevFileSet, prevPath string
+	for _, fileSet := range inputFileSets {
+		first, last, err := s.pathBounds(ctx, fileSet)
+		if err != nil {
+			return err
+		}
+		// Empty file sets can't overlap.
+		if first == "" {
+			continue
+		}
+		if prevPath != "" && first <= prevPath {
+			return errors.Errorf("error concatenating file sets: path %v in file set %v overlaps with path %v in file set %v", first, fileSet, prevPath, prevFileSet)
+		}
+		prevFileSet, prevPath = fileSet, last
+	}
+	for i, fileSet := range inputFileSets {
+		if err := s.Copy(ctx, fileSet, path.Join(outputFileSet, SubFileSetStr(int64(i))), ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pathBounds returns the first and last paths in a file set, or empty paths
+// if the file set is empty. Only the index of the file set is read.
+func (s *Storage) pathBounds(ctx context.Context, fileSet string) (first, last string, err error) {
+	fs, err := s.Open(ctx, []string{fileSet})
+	if err != nil {
+		return "", "", err
+	}
+	if err := fs.Iterate(ctx, func(f File) error {
+		if first == "" {
+			first = f.Index().Path
+		}
+		last = f.Index().Path
+		return nil
+	}); err != nil {
+		return "", "", err
+	}
+	return first, last, nil
+}
+
 // CompactStats contains information about what was compacted.
 type CompactStats struct {
 	OutputSize int64
+	// Plan is the compaction plan, for a dry run.
+	Plan *CompactionPlan
 }
 
+// maxCompactAttempts is the number of times that Compact attempts a
+// compaction whose inputs change while it runs.
+const maxCompactAttempts = 3
+
 // Compact compacts a set of filesets into an output fileset.
-func (s *Storage) Compact(ctx context.Context, outputFileSet string, inputFileSets []string, ttl time.Duration, opts ...index.Option) (*CompactStats, error) {
+func (s *Storage) Compact(ctx context.Context, outputFileSet string, inputFileSets []string, ttl time.Duration, opts ...CompactOption) (*CompactStats, error) {
+	config := &compactConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.dryRun {
+		plan, err := s.PlanCompaction(ctx, inputFileSets)
+		if err != nil {
+			return nil, err
+		}
+		var size int64
+		for _, shard := range plan.Shards {
+			size += shard.SizeBytes
+		}
+		return &CompactStats{OutputSize: size, Plan: plan}, nil
+	}
+	var layers []string
+	if config.forceSingleLayer {
+		if err := s.store.Walk(ctx, outputFileSet, func(p string) error {
+			if p != outputFileSet {
+				layers = append(layers, p)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		inputFileSets = append(append([]string{}, inputFileSets...), layers...)
+	}
+	// An input that is deleted (e.g. garbage collected) after the inputs are
+	// resolved fails the compaction with ErrPathNotExists, in which case the
+	// inputs are resolved again and the compaction is retried.
+	var stats *CompactStats
+	var err error
+	for attempt := 1; ; attempt++ {
+		stats, err = s.compact(ctx, outputFileSet, inputFileSets, ttl, config)
+		if err == nil || !errors.Is(err, ErrPathNotExists) || attempt >= maxCompactAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return stats, err
+	}
+	for _, layer := range layers {
+		if err := s.Delete(ctx, layer); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// compact makes one attempt at compacting the input file sets.
+func (s *Storage) compact(ctx context.Context, outputFileSet string, inputFileSets []string, ttl time.Duration, config *compactConfig) (*CompactStats, error) {
 	var size int64
-	w := s.newWriter(ctx, outputFileSet, WithTTL(ttl), WithIndexCallback(func(idx *index.Index) error {
-		size += index.SizeBytes(idx)
-		return nil
-	}))
-	fs, err := s.Open(ctx, inputFileSets)
+	// The sidecar is buffered, so that a failed attempt doesn't write a
+	// partial sidecar.
+	var sidecarBuf *bytes.Buffer
+	var sidecar pbutil.Writer
+	if config.sidecar != nil {
+		sidecarBuf = &bytes.Buffer{}
+		sidecar = pbutil.NewWriter(sidecarBuf)
+	}
+	writerOpts := []WriterOption{
+		WithTTL(ttl),
+		WithIndexCallback(func(idx *index.Index) error {
+			size += index.SizeBytes(idx)
+			if sidecar != nil {
+				return writeSidecarEntry(sidecar, idx)
+			}
+			return nil
+		}),
+	}
+	if config.targetChunkSize > 0 {
+		writerOpts = append(writerOpts, WithChunkWriterOptions(chunk.WithTargetSize(config.targetChunkSize), chunk.WithRewriteSmallChunks()))
+	}
+	w := s.newWriter(ctx, outputFileSet, writerOpts...)
+	fs, err := s.Open(ctx, inputFileSets, config.indexOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -195,9 +525,209 @@ func (s *Storage) Compact(ctx context.Context, outputFileSet string, inputFileSe
 	if err := w.Close(); err != nil {
 		return nil, err
 	}
+	if err := s.checkReferences(ctx, outputFileSet); err != nil {
+		return nil, err
+	}
+	if sidecarBuf != nil {
+		if _, err := io.Copy(config.sidecar, sidecarBuf); err != nil {
+			return nil, err
+		}
+	}
 	return &CompactStats{OutputSize: size}, nil
 }
 
+// checkReferences checks that the tracker object of the file set at p
+// references the chunks that its indexes point to. The writer does not
+// recreate a tracker object that already exists (e.g. from an earlier attempt
+// that failed before storing the file set), so without the check a compaction
+// could store an output whose chunks are deleted by garbage collection.
+func (s *Storage) checkReferences(ctx context.Context, p string) error {
+	md, err := s.store.Get(ctx, p)
+	if err != nil {
+		return err
+	}
+	pointsTo, err := s.tracker.GetDownstream(ctx, filesetObjectID(p))
+	if err != nil {
+		return err
+	}
+	refs := make(map[string]bool)
+	for _, id := range pointsTo {
+		refs[id] = true
+	}
+	for _, idx := range []*index.Index{md.Additive, md.Deletive} {
+		for _, cid := range index.PointsTo(idx) {
+			if !refs[chunk.ObjectID(cid)] {
+				return errors.Errorf("file set %v does not reference chunk %v in the tracker", p, cid.HexString())
+			}
+		}
+	}
+	return nil
+}
+
+// SplitFanIn splits the n inputs of one level of a distributed compaction
+// between the level's children, so that the level merges at most maxFanIn
+// child outputs. It calls cb with the [start, end) range of the inputs of
+// each child, in order, and doesn't call it at all if the level can merge the
+// n inputs itself.
+func SplitFanIn(n, maxFanIn int, cb func(start, end int) error) error {
+	if n <= maxFanIn {
+		return nil
+	}
+	childSize := maxFanIn
+	for n/childSize > maxFanIn {
+		childSize *= maxFanIn
+	}
+	for start := 0; start < n; start += childSize {
+		end := start + childSize
+		if end > n {
+			end = n
+		}
+		if err := cb(start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CompactionPlan is the plan for one level of a distributed compaction.
+type CompactionPlan struct {
+	// Inputs is the input file sets of the level. If there are more than
+	// the max fan in, they are split between the children, and the level
+	// merges the children's outputs.
+	Inputs   []string
+	Children []*CompactionPlan
+	// Shards is the shards of the level's merge, each of which is compacted
+	// by a subtask.
+	Shards []*CompactionShard
+}
+
+// CompactionShard is a shard of a compaction.
+type CompactionShard struct {
+	PathRange *index.PathRange
+	SizeBytes int64
+}
+
+// PlanCompaction plans a distributed compaction of the file sets in ids,
+// without writing any file sets. The plan follows the compaction in pfs: the
+// inputs are merged in levels of at most maxFanIn file sets, and each merge
+// is split into one subtask per shard.
+func (s *Storage) PlanCompaction(ctx context.Context, ids []string) (*CompactionPlan, error) {
+	var paths []string
+	for _, id := range ids {
+		if err := s.store.Walk(ctx, id, func(p string) error {
+			paths = append(paths, p)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if len(paths) == 0 {
+		return nil, errors.Errorf("error planning compaction: non-existent fileset: %v", ids)
+	}
+	return s.planCompaction(ctx, paths)
+}
+
+func (s *Storage) planCompaction(ctx context.Context, paths []string) (*CompactionPlan, error) {
+	plan := &CompactionPlan{Inputs: paths}
+	if err := SplitFanIn(len(paths), s.maxFanIn, func(start, end int) error {
+		child, err := s.planCompaction(ctx, paths[start:end])
+		if err != nil {
+			return err
+		}
+		plan.Children = append(plan.Children, child)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// The children's outputs contain the merge of their inputs, so the
+	// level's shards are the shards of the merged inputs.
+	fs, err := s.Open(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Shard(ctx, fs, func(pathRange *index.PathRange, sizeBytes int64) error {
+		plan.Shards = append(plan.Shards, &CompactionShard{
+			PathRange: pathRange,
+			SizeBytes: sizeBytes,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// EstimateCompaction estimates the work of a distributed compaction of the
+// file sets in ids, using only their metadata (no index or content is read).
+// The estimate follows the compaction in pfs: the inputs are merged in levels
+// of at most maxFanIn file sets, and each merge is split into one subtask per
+// shard. The estimated bytes are the content bytes read across all of the
+// levels, assuming that the merges don't shrink the data.
+func (s *Storage) EstimateCompaction(ctx context.Context, ids []string) (estimatedSubtasks int, estimatedBytes int64, err error) {
+	var sizes []int64
+	var found bool
+	for _, id := range ids {
+		var size int64
+		if err := s.store.Walk(ctx, id, func(p string) error {
+			md, err := s.store.Get(ctx, p)
+			if err != nil {
+				return err
+			}
+			found = true
+			size += md.SizeBytes
+			return nil
+		}); err != nil {
+			return 0, 0, err
+		}
+		sizes = append(sizes, size)
+	}
+	if !found {
+		return 0, 0, errors.Errorf("error estimating compaction: non-existent fileset: %v", ids)
+	}
+	// A single file set is copied rather than compacted.
+	if len(sizes) == 1 {
+		return 0, 0, nil
+	}
+	estimatedSubtasks, estimatedBytes, _ = s.estimateCompaction(sizes)
+	return estimatedSubtasks, estimatedBytes, nil
+}
+
+// estimateCompaction estimates the subtasks and bytes read by one level of
+// compaction of file sets with sizes, and the size of its output. Like the
+// compaction itself, it splits the inputs recursively when there are more
+// than maxFanIn of them.
+func (s *Storage) estimateCompaction(sizes []int64) (subtasks int, bytes, outputSize int64) {
+	if len(sizes) <= s.maxFanIn {
+		for _, size := range sizes {
+			outputSize += size
+		}
+		return s.estimateShards(outputSize), outputSize, outputSize
+	}
+	var childOutputSizes []int64
+	// The callback never returns an error.
+	SplitFanIn(len(sizes), s.maxFanIn, func(start, end int) error {
+		childSubtasks, childBytes, childOutputSize := s.estimateCompaction(sizes[start:end])
+		subtasks += childSubtasks
+		bytes += childBytes
+		childOutputSizes = append(childOutputSizes, childOutputSize)
+		return nil
+	})
+	// The child outputs are merged in a single level.
+	for _, size := range childOutputSizes {
+		outputSize += size
+	}
+	return subtasks + s.estimateShards(outputSize), bytes + outputSize, outputSize
+}
+
+// estimateShards estimates the number of shards that Shard creates for
+// sizeBytes of content (there is always at least one shard).
+func (s *Storage) estimateShards(sizeBytes int64) int {
+	if sizeBytes <= s.shardThreshold {
+		return 1
+	}
+	return int((sizeBytes + s.shardThreshold - 1) / s.shardThreshold)
+}
+
 // CompactSpec specifies the input and output for a compaction operation.
 type CompactSpec struct {
 	Output string
@@ -289,7 +819,69 @@ func (s *Storage) Delete(ctx context.Context, fileSet string) error {
 	})
 }
 
+// Drop deletes the file set(s) with prefix fileSet, along with the chunks
+// that they reference and that can be deleted, rather than waiting for garbage
+// collection to delete them. A chunk can only be deleted once nothing
+// references it and its lease has expired, since a writer may have just
+// uploaded (or deduplicated against) it and not referenced it yet. Chunks
+// that are still referenced or leased are left to garbage collection.
+func (s *Storage) Drop(ctx context.Context, fileSet string) error {
+	pointsTo := make(map[string]bool)
+	if err := s.store.Walk(ctx, fileSet, func(name string) error {
+		oid := filesetObjectID(name)
+		if err := s.store.Delete(ctx, name); err != nil {
+			return err
+		}
+		if err := s.tracker.MarkTombstone(ctx, oid); err != nil {
+			return err
+		}
+		ids, err := s.tracker.GetDownstream(ctx, oid)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if strings.HasPrefix(id, chunk.TrackerPrefix) {
+				pointsTo[id] = true
+			}
+		}
+		return s.tracker.FinishDelete(ctx, oid)
+	}); err != nil {
+		return err
+	}
+	if len(pointsTo) == 0 {
+		return nil
+	}
+	var deletable []string
+	if err := s.tracker.IterateDeletable(ctx, func(id string) error {
+		if pointsTo[id] {
+			deletable = append(deletable, id)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	chunkDeleter := s.chunks.NewDeleter()
+	for _, id := range deletable {
+		// The chunk may have been referenced since it was listed.
+		if err := s.tracker.MarkTombstone(ctx, id); err != nil {
+			if errors.Is(err, track.ErrDanglingRef) {
+				continue
+			}
+			return err
+		}
+		if err := chunkDeleter.Delete(ctx, id); err != nil {
+			return err
+		}
+		if err := s.tracker.FinishDelete(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetTTL sets the time-to-live for the prefix p.
+// This extends the lease on the file sets without rewriting them, and errors
+// with track.ErrExpired if they have already expired.
 func (s *Storage) SetTTL(ctx context.Context, p string, ttl time.Duration) (time.Time, error) {
 	oid := filesetObjectID(p)
 	return s.tracker.SetTTLPrefix(ctx, oid, ttl)
@@ -307,6 +899,10 @@ func (s *Storage) WithRenewer(ctx context.Context, ttl time.Duration, cb func(co
 // GC creates a track.GarbageCollector with a Deleter that can handle deleting filesets and chunks
 func (s *Storage) GC(ctx context.Context) error {
 	const period = 10 * time.Second
+	return s.newGarbageCollector(period).Run(ctx)
+}
+
+func (s *Storage) newGarbageCollector(period time.Duration) *track.GarbageCollector {
 	tmpDeleter := track.NewTmpDeleter()
 	chunkDeleter := s.chunks.NewDeleter()
 	filesetDeleter := &deleter{
@@ -324,8 +920,41 @@ func (s *Storage) GC(ctx context.Context) error {
 			return nil
 		}
 	})
-	gc := track.NewGarbageCollector(s.tracker, period, mux)
-	return gc.Run(ctx)
+	return track.NewGarbageCollector(s.tracker, period, mux)
+}
+
+// ChunkReferences returns the tracker IDs of the chunks referenced by the file
+// set(s) with prefix fileSet, either directly or through the index chunks
+// that reference them. These are the references that keep the chunks from
+// being garbage collected while the file sets are alive.
+func (s *Storage) ChunkReferences(ctx context.Context, fileSet string) ([]string, error) {
+	seen := make(map[string]bool)
+	var ids []string
+	var walk func(oid string) error
+	walk = func(oid string) error {
+		pointsTo, err := s.tracker.GetDownstream(ctx, oid)
+		if err != nil {
+			return err
+		}
+		for _, id := range pointsTo {
+			if !strings.HasPrefix(id, chunk.TrackerPrefix) || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+			if err := walk(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := s.store.Walk(ctx, fileSet, func(name string) error {
+		return walk(filesetObjectID(name))
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+	return ids, nil
 }
 
 func (s *Storage) levelSize(i int) int64 {
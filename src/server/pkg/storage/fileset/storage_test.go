@@ -0,0 +1,2359 @@
+package fileset
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/dbutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/hash"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/track"
+	"github.com/pachyderm/pachyderm/src/server/pkg/tarutil"
+)
+
+const testTTL = time.Hour
+
+// writeTestFileSet writes a file set with numFiles files of fileSize bytes.
+// The file paths are prefixed with the file set name, so that file sets with
+// different names do not overlap.
+func writeTestFileSet(t testing.TB, s *Storage, fileSet string, numFiles, fileSize int, opts ...WriterOption) {
+	w := s.NewWriter(context.Background(), fileSet, opts...)
+	for i := 0; i < numFiles; i++ {
+		require.NoError(t, w.Append(fmt.Sprintf("/%v/%04d", fileSet, i), func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(chunk.RandSeq(fileSize))
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+}
+
+// writeFiles writes a file set with files, which maps each file's path to its
+// content. The files are written in path order.
+func writeFiles(t testing.TB, s *Storage, fileSet string, files map[string]string, opts ...WriterOption) {
+	var paths []string
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	w := s.NewWriter(context.Background(), fileSet, opts...)
+	for _, p := range paths {
+		data := files[p]
+		require.NoError(t, w.Append(p, func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write([]byte(data))
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestCompactTargetChunkSize(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	inputChunkSize := 64 * units.KB
+	targetChunkSize := units.MB
+	// Write input file sets that consist of many small chunks.
+	var inputs []string
+	for i := 0; i < 5; i++ {
+		fileSet := fmt.Sprintf("input-%v", i)
+		writeTestFileSet(t, s, fileSet, 10, 100*units.KB, WithChunkWriterOptions(chunk.WithTargetSize(inputChunkSize)))
+		inputs = append(inputs, fileSet)
+	}
+	// chunkSizes returns the chunks referenced by a file set, in order, and
+	// their sizes.
+	chunkSizes := func(fileSets ...string) ([]string, map[string]int64) {
+		fs, err := s.Open(ctx, fileSets)
+		require.NoError(t, err)
+		var ids []string
+		sizes := make(map[string]int64)
+		require.NoError(t, fs.Iterate(ctx, func(f File) error {
+			for _, dataRef := range f.Index().File.DataRefs {
+				id := string(dataRef.Ref.Id)
+				if _, ok := sizes[id]; !ok {
+					ids = append(ids, id)
+				}
+				sizes[id] = dataRef.Ref.SizeBytes
+			}
+			return nil
+		}))
+		return ids, sizes
+	}
+	_, err := s.Compact(ctx, "output", inputs, testTTL, WithTargetChunkSize(targetChunkSize))
+	require.NoError(t, err)
+	ids, sizes := chunkSizes("output")
+	require.True(t, len(ids) > 1)
+	// Every chunk except for the last one should be near the target size.
+	for _, id := range ids[:len(ids)-1] {
+		size := sizes[id]
+		require.True(t, size >= int64(targetChunkSize/2), "chunk size %v is below the minimum", size)
+		require.True(t, size <= int64(targetChunkSize*2), "chunk size %v is above the maximum", size)
+	}
+	// Without a target size, the small input chunks are referenced rather
+	// than rewritten.
+	_, err = s.Compact(ctx, "untargeted", inputs, testTTL)
+	require.NoError(t, err)
+	_, inputSizes := chunkSizes(inputs...)
+	ids, _ = chunkSizes("untargeted")
+	var referenced bool
+	for _, id := range ids {
+		if _, ok := inputSizes[id]; ok {
+			referenced = true
+		}
+	}
+	require.True(t, referenced, "no input chunks are referenced by the compaction")
+}
+
+func TestSubtract(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	data := make(map[string]string)
+	var paths []string
+	for i := 0; i < 10; i++ {
+		p := fmt.Sprintf("/%04d", i)
+		data[p] = string(chunk.RandSeq(units.KB))
+		paths = append(paths, p)
+	}
+	writeFiles(t, s, "a", data)
+	// b overlaps with the even files in a, has a file with the same path but
+	// different content, and a file that is not in a.
+	bData := make(map[string]string)
+	for i := 0; i < 10; i += 2 {
+		bData[paths[i]] = data[paths[i]]
+	}
+	bData["/0003"] = string(chunk.RandSeq(units.KB))
+	bData["/0010"] = string(chunk.RandSeq(units.KB))
+	writeFiles(t, s, "b", bData)
+	checkSubtract := func(compareContent bool, expected []string) {
+		a, err := s.Open(ctx, []string{"a"})
+		require.NoError(t, err)
+		b, err := s.Open(ctx, []string{"b"})
+		require.NoError(t, err)
+		var actual []string
+		require.NoError(t, Subtract(a, b, compareContent).Iterate(ctx, func(f File) error {
+			actual = append(actual, f.Index().Path)
+			return nil
+		}))
+		require.Equal(t, expected, actual)
+	}
+	checkSubtract(false, []string{"/0001", "/0005", "/0007", "/0009"})
+	checkSubtract(true, []string{"/0001", "/0003", "/0005", "/0007", "/0009"})
+}
+
+func TestDirFilter(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	files := []string{"/a/b/c/0", "/a/b/c/1", "/a/b/d", "/a/e", "/f"}
+	w := s.NewWriter(ctx, "test")
+	for _, p := range files {
+		require.NoError(t, w.Append(p, func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(chunk.RandSeq(100))
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	fs = NewDirInserter(fs)
+	iteratePaths := func(fs FileSet) []string {
+		var paths []string
+		require.NoError(t, fs.Iterate(ctx, func(f File) error {
+			paths = append(paths, f.Index().Path)
+			return nil
+		}))
+		return paths
+	}
+	require.Equal(t, []string{"/", "/a/", "/a/b/", "/a/b/c/", "/a/b/c/0", "/a/b/c/1", "/a/b/d", "/a/e", "/f"}, iteratePaths(fs))
+	require.Equal(t, files, iteratePaths(NewDirFilter(fs)))
+	// The filter also applies to imperative iteration.
+	iter := NewIterator(ctx, NewDirFilter(fs))
+	var paths []string
+	for {
+		f, err := iter.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		paths = append(paths, f.Index().Path)
+	}
+	require.Equal(t, files, paths)
+}
+
+func TestFilterSize(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	sizes := []int{0, 10, 99, 100, 500, 1000, 1001, 5000}
+	w := s.NewWriter(ctx, "test")
+	for i, size := range sizes {
+		require.NoError(t, w.Append(fmt.Sprintf("/%04d", i), func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(chunk.RandSeq(size))
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	filteredSizes := func(min, max int64) []int {
+		var result []int
+		require.NoError(t, FilterSize(fs, min, max).Iterate(ctx, func(f File) error {
+			i, err := strconv.Atoi(strings.TrimPrefix(f.Index().Path, "/"))
+			if err != nil {
+				return err
+			}
+			result = append(result, sizes[i])
+			return nil
+		}))
+		return result
+	}
+	// The range is inclusive at both ends.
+	require.Equal(t, []int{100, 500, 1000}, filteredSizes(100, 1000))
+	require.Equal(t, []int{1001, 5000}, filteredSizes(1001, math.MaxInt64))
+	require.Equal(t, []int{0}, filteredSizes(0, 0))
+	require.Equal(t, 0, len(filteredSizes(2000, 3000)))
+}
+
+func TestSkipMissingChunks(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	objC, chunks := chunk.NewTestStorage(t, db, tr)
+	s := NewStorage(NewTestStore(t, db), tr, chunks)
+	// Each file is written in its own file set, so that the files don't
+	// share chunks.
+	for i := 0; i < 3; i++ {
+		writeTestFileSet(t, s, fmt.Sprintf("test/%v", i), 1, 10*units.KB)
+	}
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// Remove the content chunks of the second file from object storage.
+	missingPath := "/test/1/0000"
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		if f.Index().Path != missingPath {
+			return nil
+		}
+		for _, dataRef := range getDataRefs(f.Index().File.Parts) {
+			if err := objC.Delete(ctx, chunk.ObjectID(chunk.ID(dataRef.Ref.Id))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	// Reading the file set fails without skipping the missing chunks.
+	require.YesError(t, fs.Iterate(ctx, func(f File) error {
+		return f.Content(ioutil.Discard)
+	}))
+	var paths, missing []string
+	require.NoError(t, SkipMissingChunks(s.ChunkStorage(), fs, func(f File, err error) error {
+		require.True(t, errors.Is(err, chunk.ErrChunkNotExists), "unexpected error: %v", err)
+		missing = append(missing, f.Index().Path)
+		return nil
+	}).Iterate(ctx, func(f File) error {
+		paths = append(paths, f.Index().Path)
+		buf := &bytes.Buffer{}
+		if err := f.Content(buf); err != nil {
+			return err
+		}
+		require.Equal(t, int64(buf.Len()), index.SizeBytes(f.Index()))
+		return nil
+	}))
+	require.Equal(t, []string{"/test/0/0000", "/test/2/0000"}, paths)
+	require.Equal(t, []string{missingPath}, missing)
+	// The content of the files is not read to check for missing chunks.
+	paths, missing = nil, nil
+	require.NoError(t, SkipMissingChunks(s.ChunkStorage(), &noContentFileSet{FileSet: fs, t: t}, func(f File, _ error) error {
+		missing = append(missing, f.Index().Path)
+		return nil
+	}).Iterate(ctx, func(f File) error {
+		paths = append(paths, f.Index().Path)
+		return nil
+	}))
+	require.Equal(t, []string{"/test/0/0000", "/test/2/0000"}, paths)
+	require.Equal(t, []string{missingPath}, missing)
+	// The iteration stops if the callback for a missing file errors.
+	require.YesError(t, SkipMissingChunks(s.ChunkStorage(), fs, func(File, error) error {
+		return errors.Errorf("missing file")
+	}).Iterate(ctx, func(File) error {
+		return nil
+	}))
+}
+
+func TestCopyFilesIsolated(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	objC, chunks := chunk.NewTestStorage(t, db, tr)
+	s := NewStorage(NewTestStore(t, db), tr, chunks)
+	// Each file is written in its own file set, so that the files don't
+	// share chunks.
+	for i := 0; i < 3; i++ {
+		writeTestFileSet(t, s, fmt.Sprintf("test/%v", i), 1, 10*units.KB)
+	}
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// Remove the content chunks of the second file, so that its copy fails.
+	failedPath := "/test/1/0000"
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		if f.Index().Path != failedPath {
+			return nil
+		}
+		for _, dataRef := range getDataRefs(f.Index().File.Parts) {
+			if err := objC.Delete(ctx, chunk.ObjectID(chunk.ID(dataRef.Ref.Id))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	var failed []string
+	var progress []CopyStats
+	w := s.NewWriter(ctx, "copy")
+	// The content of the files is not read by the copy.
+	stats, err := CopyFilesIsolated(ctx, w, &noContentFileSet{FileSet: fs, t: t}, func(f File, err error) error {
+		require.True(t, errors.Is(err, chunk.ErrChunkNotExists), "unexpected error: %v", err)
+		failed = append(failed, f.Index().Path)
+		return nil
+	}, WithCopyProgress(2, func(stats *CopyStats) error {
+		progress = append(progress, *stats)
+		return nil
+	}))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Equal(t, []string{failedPath}, failed)
+	require.Equal(t, &CopyStats{Copied: 2, CopiedBytes: 20 * units.KB, Failed: 1}, stats)
+	// Progress is reported after the second file, and at the end.
+	require.Equal(t, []CopyStats{
+		{Copied: 1, CopiedBytes: 10 * units.KB, Failed: 1},
+		{Copied: 2, CopiedBytes: 20 * units.KB, Failed: 1},
+	}, progress)
+	// The final progress is reported once when the last file is on a progress
+	// boundary.
+	for _, interval := range []int{1, 3} {
+		progress = nil
+		_, err = CopyFilesIsolated(ctx, s.NewWriter(ctx, fmt.Sprintf("copy-%v", interval)), fs, func(File, error) error {
+			return nil
+		}, WithCopyProgress(interval, func(stats *CopyStats) error {
+			progress = append(progress, *stats)
+			return nil
+		}))
+		require.NoError(t, err)
+		expected := []CopyStats{
+			{Copied: 1, CopiedBytes: 10 * units.KB},
+			{Copied: 1, CopiedBytes: 10 * units.KB, Failed: 1},
+			{Copied: 2, CopiedBytes: 20 * units.KB, Failed: 1},
+		}
+		if interval == 3 {
+			expected = expected[2:]
+		}
+		require.Equal(t, expected, progress)
+	}
+	// The rest of the files are copied, with their content.
+	copyFs, err := s.Open(ctx, []string{"copy"})
+	require.NoError(t, err)
+	var paths []string
+	require.NoError(t, copyFs.Iterate(ctx, func(f File) error {
+		paths = append(paths, f.Index().Path)
+		buf := &bytes.Buffer{}
+		if err := f.Content(buf); err != nil {
+			return err
+		}
+		require.Equal(t, 10*units.KB, buf.Len())
+		return nil
+	}))
+	require.Equal(t, []string{"/test/0/0000", "/test/2/0000"}, paths)
+	// The copy stops if the error callback errors.
+	_, err = CopyFilesIsolated(ctx, s.NewWriter(ctx, "stopped"), fs, func(File, error) error {
+		return errors.Errorf("copy failed")
+	})
+	require.YesError(t, err)
+	require.Matches(t, "copy failed", err.Error())
+}
+
+// noContentFileSet is a file set that fails the test if the content of its
+// files is read.
+type noContentFileSet struct {
+	FileSet
+	t testing.TB
+}
+
+func (ncfs *noContentFileSet) Iterate(ctx context.Context, cb func(File) error, deletive ...bool) error {
+	return ncfs.FileSet.Iterate(ctx, func(f File) error {
+		return cb(&noContentFile{File: f, t: ncfs.t})
+	}, deletive...)
+}
+
+type noContentFile struct {
+	File
+	t testing.TB
+}
+
+func (ncf *noContentFile) Content(_ io.Writer) error {
+	ncf.t.Errorf("content of %v was read", ncf.Index().Path)
+	return errors.Errorf("content of %v was read", ncf.Index().Path)
+}
+
+func TestRewrite(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	files := map[string]string{
+		"/a/b/c": "foo",
+		"/a/d":   "bar",
+		"/e":     "baz",
+	}
+	writeFiles(t, s, "test", files)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// Prefixing every path keeps the file set sorted.
+	prefixed := Rewrite(fs, func(p string) (string, error) {
+		return path.Join("/data", p), nil
+	})
+	var rewritten []string
+	require.NoError(t, prefixed.Iterate(ctx, func(f File) error {
+		p := f.Index().Path
+		rewritten = append(rewritten, p)
+		buf := &bytes.Buffer{}
+		if err := f.Content(buf); err != nil {
+			return err
+		}
+		require.Equal(t, files[strings.TrimPrefix(p, "/data")], buf.String())
+		return nil
+	}))
+	require.Equal(t, []string{"/data/a/b/c", "/data/a/d", "/data/e"}, rewritten)
+	require.True(t, sort.StringsAreSorted(rewritten))
+	// The original file set is not modified.
+	var original []string
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		original = append(original, f.Index().Path)
+		return nil
+	}))
+	require.Equal(t, []string{"/a/b/c", "/a/d", "/e"}, original)
+	// Rewrites that break the ordering error.
+	reversed := Rewrite(fs, func(p string) (string, error) {
+		return map[string]string{"/a/b/c": "/z", "/a/d": "/y", "/e": "/x"}[p], nil
+	})
+	err = reversed.Iterate(ctx, func(_ File) error { return nil })
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "breaks the file set ordering"), "unexpected error: %v", err)
+	// Errors from the rewrite are returned.
+	err = Rewrite(fs, func(p string) (string, error) {
+		return "", errors.Errorf("rewrite error")
+	}).Iterate(ctx, func(_ File) error { return nil })
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "rewrite error"), "unexpected error: %v", err)
+}
+
+func TestSetTTL(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	ttl := time.Second
+	writeTestFileSet(t, s, "touched", 1, units.KB, WithTTL(ttl))
+	writeTestFileSet(t, s, "expired", 1, units.KB, WithTTL(ttl))
+	_, err := s.SetTTL(ctx, "touched", time.Hour)
+	require.NoError(t, err)
+	time.Sleep(2 * ttl)
+	// The touched file set should survive past its original TTL.
+	deletable := make(map[string]bool)
+	require.NoError(t, s.tracker.IterateDeletable(ctx, func(id string) error {
+		deletable[id] = true
+		return nil
+	}))
+	require.False(t, deletable[filesetObjectID("touched")])
+	require.True(t, deletable[filesetObjectID("expired")])
+	_, err = s.SetTTL(ctx, "expired", time.Hour)
+	require.True(t, errors.Is(err, track.ErrExpired))
+}
+
+func TestOpenWithTouch(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	ttl := time.Second
+	writeTestFileSet(t, s, "hot", 1, units.KB, WithTTL(ttl))
+	writeTestFileSet(t, s, "cold", 1, units.KB, WithTTL(ttl))
+	writeTestFileSet(t, s, "long", 1, units.KB, WithTTL(time.Hour))
+	writeTestFileSet(t, s, "permanent", 1, units.KB)
+	// Reading the hot file set repeatedly should keep it alive past its
+	// original TTL.
+	for i := 0; i < 4; i++ {
+		time.Sleep(ttl / 2)
+		fs, err := s.OpenWithTouch(ctx, []string{"hot"}, ttl)
+		require.NoError(t, err)
+		require.NoError(t, fs.Iterate(ctx, func(_ File) error { return nil }))
+	}
+	// Touching with a shorter TTL neither shortens a longer lease, nor gives
+	// a permanent file set an expiration.
+	for _, fileSet := range []string{"long", "permanent"} {
+		_, err := s.OpenWithTouch(ctx, []string{fileSet}, time.Millisecond)
+		require.NoError(t, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	// Plain reads don't touch the file sets.
+	_, err := s.Open(ctx, []string{"cold"})
+	require.NoError(t, err)
+	deletable := make(map[string]bool)
+	require.NoError(t, s.tracker.IterateDeletable(ctx, func(id string) error {
+		deletable[id] = true
+		return nil
+	}))
+	require.False(t, deletable[filesetObjectID("hot")])
+	require.True(t, deletable[filesetObjectID("cold")])
+	require.False(t, deletable[filesetObjectID("long")])
+	require.False(t, deletable[filesetObjectID("permanent")])
+	// Reading an expired file set errors rather than racing with garbage
+	// collection.
+	_, err = s.OpenWithTouch(ctx, []string{"cold"}, ttl)
+	require.True(t, errors.Is(err, track.ErrExpired))
+}
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	objC, chunks := chunk.NewTestStorage(t, db, tr)
+	s := NewStorage(NewTestStore(t, db), tr, chunks)
+	writeTestFileSet(t, s, "test", 10, units.KB)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// Corrupt one byte in each chunk that stores file content (the index
+	// chunks are left intact).
+	contentChunks := make(map[string]bool)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		for _, dataRef := range getDataRefs(f.Index().File.Parts) {
+			contentChunks[chunk.ID(dataRef.Ref.Id).HexString()] = true
+		}
+		return nil
+	}))
+	var names []string
+	require.NoError(t, objC.Walk(ctx, "", func(name string) error {
+		if contentChunks[path.Base(name)] {
+			names = append(names, name)
+		}
+		return nil
+	}))
+	require.Equal(t, len(contentChunks), len(names))
+	for _, name := range names {
+		r, err := objC.Reader(ctx, name, 0, 0)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		data[len(data)/2] ^= 0xFF
+		require.NoError(t, objC.Delete(ctx, name))
+		w, err := objC.Writer(ctx, name)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	}
+	// Normal iteration does not detect the corruption, even when the content is read.
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		return f.Content(ioutil.Discard)
+	}))
+	// Verified iteration detects the corruption.
+	err = NewVerifier(fs).Iterate(ctx, func(f File) error {
+		return nil
+	})
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "hash mismatch"), "unexpected error: %v", err)
+}
+
+// closeCounter counts the number of times it is closed.
+type closeCounter struct {
+	io.Writer
+	closes *int
+}
+
+func (cc *closeCounter) Close() error {
+	*cc.closes++
+	return nil
+}
+
+func TestWriteTarStreamParts(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	numFiles, fileSize := 10, units.KB
+	writeTestFileSet(t, s, "test", numFiles, fileSize)
+	// Each entry is a header block followed by the block aligned content.
+	entrySize := int64(tarBlockSize + fileSize)
+	writeParts := func(maxBytes int64) []*bytes.Buffer {
+		fs, err := s.Open(ctx, []string{"test"})
+		require.NoError(t, err)
+		var parts []*bytes.Buffer
+		var closes int
+		require.NoError(t, WriteTarStreamParts(ctx, fs, maxBytes, func(i int) io.WriteCloser {
+			require.Equal(t, len(parts), i)
+			buf := &bytes.Buffer{}
+			parts = append(parts, buf)
+			return &closeCounter{Writer: buf, closes: &closes}
+		}))
+		require.Equal(t, len(parts), closes)
+		return parts
+	}
+	checkArchive := func(parts []*bytes.Buffer) {
+		var rs []io.Reader
+		for _, part := range parts {
+			rs = append(rs, bytes.NewReader(part.Bytes()))
+		}
+		tr := tar.NewReader(io.MultiReader(rs...))
+		var i int
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("/test/%04d", i), hdr.Name)
+			data, err := ioutil.ReadAll(tr)
+			require.NoError(t, err)
+			require.Equal(t, fileSize, len(data))
+			i++
+		}
+		require.Equal(t, numFiles, i)
+	}
+	parts := writeParts(3 * entrySize)
+	require.Equal(t, 4, len(parts))
+	// Only the last part contains the end of archive marker.
+	for _, part := range parts[:len(parts)-1] {
+		require.Equal(t, 3*entrySize, int64(part.Len()))
+	}
+	checkArchive(parts)
+	// Files that exceed the limit on their own are written to separate parts.
+	parts = writeParts(entrySize / 2)
+	require.Equal(t, numFiles, len(parts))
+	checkArchive(parts)
+}
+
+func TestWriteTarStreamHead(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	numFiles, fileSize := 10, units.KB
+	writeTestFileSet(t, s, "test", numFiles, fileSize)
+	readArchive := func(buf *bytes.Buffer) ([]string, [][]byte) {
+		var names []string
+		var contents [][]byte
+		tr := tar.NewReader(buf)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				return names, contents
+			}
+			require.NoError(t, err)
+			data, err := ioutil.ReadAll(tr)
+			require.NoError(t, err)
+			names = append(names, hdr.Name)
+			contents = append(contents, data)
+		}
+	}
+	writeHead := func(maxFiles int, maxBytes int64) ([]string, [][]byte) {
+		fs, err := s.Open(ctx, []string{"test"})
+		require.NoError(t, err)
+		buf := &bytes.Buffer{}
+		require.NoError(t, WriteTarStreamHead(ctx, buf, fs, maxFiles, maxBytes))
+		return readArchive(buf)
+	}
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteTarStream(ctx, buf, fs))
+	allNames, allContents := readArchive(buf)
+	require.Equal(t, numFiles, len(allNames))
+	// The file limit stops the archive after the first files.
+	names, contents := writeHead(3, 0)
+	require.Equal(t, allNames[:3], names)
+	require.Equal(t, allContents[:3], contents)
+	// The byte limit truncates the file that reaches it.
+	names, contents = writeHead(0, int64(2*fileSize+fileSize/2))
+	require.Equal(t, allNames[:3], names)
+	require.Equal(t, allContents[:2], contents[:2])
+	require.Equal(t, allContents[2][:fileSize/2], contents[2])
+	// Whichever limit is hit first stops the archive.
+	names, _ = writeHead(2, int64(2*fileSize+fileSize/2))
+	require.Equal(t, allNames[:2], names)
+	names, _ = writeHead(5, int64(fileSize))
+	require.Equal(t, allNames[:1], names)
+	// Without limits, the whole file set is written.
+	names, contents = writeHead(0, 0)
+	require.Equal(t, allNames, names)
+	require.Equal(t, allContents, contents)
+}
+
+func TestWriteManifest(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	files := []struct {
+		path, data string
+	}{
+		{"/a/b", "foo"},
+		{"/a/c", ""},
+		{"/d", "bar baz"},
+	}
+	w := s.NewWriter(ctx, "test")
+	for _, file := range files {
+		data := file.data
+		require.NoError(t, w.Append(file.path, func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write([]byte(data))
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteManifest(ctx, fs, buf))
+	expected := &strings.Builder{}
+	for _, file := range files {
+		fmt.Fprintf(expected, "%v\t%v\t%v\n", file.path, len(file.data), hash.EncodeHash(hash.Sum([]byte(file.data))))
+	}
+	require.Equal(t, expected.String(), buf.String())
+}
+
+func TestWriteLayer(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	openFiles := func(fileSet string, files map[string]string) FileSet {
+		writeFiles(t, s, fileSet, files)
+		fs, err := s.Open(ctx, []string{fileSet})
+		require.NoError(t, err)
+		return fs
+	}
+	base := openFiles("base", map[string]string{
+		"/a/0": "unchanged",
+		"/a/1": "foo",
+		"/b/0": "deleted",
+		"/c":   "deleted",
+	})
+	// /a/1 is modified, /b/1 and /d/e/f are added, and /b/0 and /c are
+	// deleted.
+	fs := openFiles("fs", map[string]string{
+		"/a/0":   "unchanged",
+		"/a/1":   "bar",
+		"/b/1":   "baz",
+		"/d/e/f": "qux",
+	})
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteLayer(ctx, buf, base, fs))
+	tr := tar.NewReader(buf)
+	var entries []string
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, 0, hdr.Uid)
+		require.Equal(t, 0, hdr.Gid)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		entries = append(entries, fmt.Sprintf("%v %c %o %v", hdr.Name, hdr.Typeflag, hdr.Mode, string(data)))
+	}
+	// The whiteouts for the deleted files come first.
+	require.Equal(t, []string{
+		"b/.wh.0 0 644 ",
+		".wh.c 0 644 ",
+		"a/ 5 755 ",
+		"a/1 0 644 bar",
+		"b/ 5 755 ",
+		"b/1 0 644 baz",
+		"d/ 5 755 ",
+		"d/e/ 5 755 ",
+		"d/e/f 0 644 qux",
+	}, entries)
+}
+
+func TestEqual(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	type testFile struct {
+		path, data string
+	}
+	writeFiles := func(fileSet string, files []testFile) {
+		w := s.NewWriter(ctx, fileSet)
+		for _, file := range files {
+			data := file.data
+			require.NoError(t, w.Append(file.path, func(fw *FileWriter) error {
+				fw.Append("0")
+				_, err := fw.Write([]byte(data))
+				return err
+			}))
+		}
+		require.NoError(t, w.Close())
+	}
+	var n int
+	checkEqual := func(a, b []testFile, expectedDiff string) {
+		nameA, nameB := fmt.Sprintf("a-%v", n), fmt.Sprintf("b-%v", n)
+		n++
+		writeFiles(nameA, a)
+		writeFiles(nameB, b)
+		fsA, err := s.Open(ctx, []string{nameA})
+		require.NoError(t, err)
+		fsB, err := s.Open(ctx, []string{nameB})
+		require.NoError(t, err)
+		equal, diff, err := Equal(ctx, fsA, fsB)
+		require.NoError(t, err)
+		require.Equal(t, expectedDiff == "", equal)
+		require.Equal(t, expectedDiff, diff)
+	}
+	files := []testFile{{"/a", "foo"}, {"/b", "bar"}}
+	checkEqual(files, files, "")
+	checkEqual(files, append(files, testFile{"/c", "baz"}), "/c is only in the second file set")
+	checkEqual(files, []testFile{{"/a", "foo"}}, "/b is only in the first file set")
+	checkEqual(files, []testFile{{"/a", "foo"}, {"/c", "bar"}}, "/b is only in the first file set")
+	checkEqual(files, []testFile{{"/a", "foo"}, {"/b", "baz"}}, "/b has different content")
+	checkEqual(files, []testFile{{"/a", "foo"}, {"/b", "barr"}}, "/b has size 3 in the first file set and 4 in the second")
+}
+
+func TestValidateOrdering(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	writeFiles(t, s, "test", map[string]string{
+		"/a": "/a",
+		"/b": "/b",
+		"/c": "/c",
+		"/d": "/d",
+	})
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	require.NoError(t, ValidateOrdering(ctx, fs))
+	// The invalid file sets are made by remapping one of the paths.
+	remap := func(from, to string) FileSet {
+		return NewIndexMapper(fs, func(idx *index.Index) *index.Index {
+			if idx.Path == from {
+				idx.Path = to
+			}
+			return idx
+		})
+	}
+	err = ValidateOrdering(ctx, remap("/b", "/a"))
+	require.YesError(t, err)
+	require.Matches(t, "path /a is a duplicate", err.Error())
+	err = ValidateOrdering(ctx, remap("/b", "/b//"))
+	require.YesError(t, err)
+	require.Matches(t, "path /b// is not clean \\(previous path: /a\\)", err.Error())
+	err = ValidateOrdering(ctx, remap("/d", "/bb"))
+	require.YesError(t, err)
+	require.Matches(t, "path /bb is out of order \\(previous path: /c\\)", err.Error())
+}
+
+func TestDirInserterDeepTree(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	files := map[string]string{
+		"/a/b/c/d/e/0": "foo",
+		"/a/b/c/d/e/1": "bar",
+		"/a/b/c/d/f":   "baz",
+		"/a/b/c/g":     "qux",
+		"/a/b-c/h":     "quux",
+		"/a/i":         "corge",
+		"/j":           "grault",
+	}
+	writeFiles(t, s, "test", files)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// Inserting directories into a stream that already has directory entries
+	// does not duplicate them.
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteTarStream(ctx, buf, NewDirInserter(NewDirInserter(fs))))
+	data := buf.Bytes()
+	tr := tar.NewReader(bytes.NewReader(data))
+	dirs := make(map[string]int)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+		if IsDir(hdr.Name) {
+			dirs[hdr.Name]++
+		}
+	}
+	require.True(t, sort.StringsAreSorted(names), "unsorted entries: %v", names)
+	for _, dir := range []string{"/", "/a/", "/a/b/", "/a/b/c/", "/a/b/c/d/", "/a/b/c/d/e/", "/a/b-c/"} {
+		require.Equal(t, 1, dirs[dir], "directory %v", dir)
+	}
+	require.Equal(t, 7, len(dirs))
+	// The archive extracts to the original files.
+	dir, err := ioutil.TempDir("", "dir-inserter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, tarutil.Import(dir, bytes.NewReader(data)))
+	for p, expected := range files {
+		actual, err := ioutil.ReadFile(path.Join(dir, p))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(actual))
+	}
+}
+
+func TestImportTarStream(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	writeTestFileSet(t, s, "test", 10, units.KB)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// Export the file set with directory entries, then import it.
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteTarStream(ctx, buf, NewDirInserter(fs)))
+	w := s.NewWriter(ctx, "imported")
+	require.NoError(t, ImportTarStream(ctx, buf, w, "0"))
+	require.NoError(t, w.Close())
+	imported, err := s.Open(ctx, []string{"imported"})
+	require.NoError(t, err)
+	equal, diff, err := Equal(ctx, fs, imported)
+	require.NoError(t, err)
+	require.True(t, equal, diff)
+	// Unsafe paths are rejected.
+	buf = &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "../escape",
+		Typeflag: tar.TypeReg,
+	}))
+	require.NoError(t, tw.Close())
+	w = s.NewWriter(ctx, "unsafe")
+	err = ImportTarStream(ctx, buf, w, "0")
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "unsafe path"), "unexpected error: %v", err)
+}
+
+func TestImportTarStreamLinks(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	for _, hdr := range []*tar.Header{
+		{Name: "/link", Typeflag: tar.TypeSymlink, Linkname: "/target"},
+		{Name: "/link", Typeflag: tar.TypeLink, Linkname: "/target"},
+		{Name: "/fifo", Typeflag: tar.TypeFifo},
+	} {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     "/target",
+			Typeflag: tar.TypeReg,
+			Size:     4,
+		}))
+		_, err := tw.Write([]byte("data"))
+		require.NoError(t, err)
+		require.NoError(t, tw.WriteHeader(hdr))
+		require.NoError(t, tw.Close())
+		// The entry would not survive the round trip as a link or fifo, so
+		// the import fails rather than storing it as a regular file.
+		w := s.NewWriter(ctx, "links")
+		err = ImportTarStream(ctx, buf, w, "0")
+		require.YesError(t, err)
+		require.True(t, errors.Is(err, ErrUnsupportedTarEntry), "unexpected error: %v", err)
+		require.True(t, strings.Contains(err.Error(), hdr.Name), "unexpected error: %v", err)
+		require.True(t, strings.Contains(err.Error(), hdr.Linkname), "unexpected error: %v", err)
+	}
+}
+
+func TestImportTarStreamGlobalHeader(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	// A tar stream written by git archive starts with a global PAX header,
+	// which is skipped, and contiguous files are imported as regular files.
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:       "pax_global_header",
+		Typeflag:   tar.TypeXGlobalHeader,
+		PAXRecords: map[string]string{"comment": "0123456789abcdef"},
+	}))
+	for _, hdr := range []*tar.Header{
+		{Name: "/cont", Typeflag: tar.TypeCont, Size: 4},
+		{Name: "/reg", Typeflag: tar.TypeReg, Size: 4},
+	} {
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte("data"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	w := s.NewWriter(ctx, "imported")
+	require.NoError(t, ImportTarStream(ctx, buf, w, "0"))
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"imported"})
+	require.NoError(t, err)
+	files := make(map[string]string)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		buf := &bytes.Buffer{}
+		if err := f.Content(buf); err != nil {
+			return err
+		}
+		files[f.Index().Path] = buf.String()
+		return nil
+	}))
+	require.Equal(t, map[string]string{
+		"/cont": "data",
+		"/reg":  "data",
+	}, files)
+}
+
+func TestImportTarStreamContentTypes(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	files := []struct {
+		name, content, contentType string
+	}{
+		{"/a.png", "\x89PNG\x0D\x0A\x1A\x0A" + string(chunk.RandSeq(1000)), "image/png"},
+		{"/b.html", "<!DOCTYPE html><html><body>hello</body></html>", "text/html; charset=utf-8"},
+		{"/c.txt", "plain text", "text/plain; charset=utf-8"},
+		{"/d.gz", "\x1F\x8B\x08" + string(chunk.RandSeq(10)), "application/x-gzip"},
+	}
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     f.name,
+			Typeflag: tar.TypeReg,
+			Size:     int64(len(f.content)),
+		}))
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	contentTypes := make(map[string]string)
+	w := s.NewWriter(ctx, "imported")
+	require.NoError(t, ImportTarStream(ctx, bytes.NewReader(buf.Bytes()), w, "0", WithContentTypes(func(p, contentType string) error {
+		contentTypes[p] = contentType
+		return nil
+	})))
+	require.NoError(t, w.Close())
+	for _, f := range files {
+		require.Equal(t, f.contentType, contentTypes[f.name], "file %v", f.name)
+	}
+	// The content read for detection is still imported.
+	fs, err := s.Open(ctx, []string{"imported"})
+	require.NoError(t, err)
+	var i int
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		require.Equal(t, files[i].name, f.Index().Path)
+		content := &bytes.Buffer{}
+		require.NoError(t, f.Content(content))
+		require.Equal(t, files[i].content, content.String())
+		i++
+		return nil
+	}))
+	require.Equal(t, len(files), i)
+}
+
+func TestImportTarStreamManifest(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	writeTestFileSet(t, s, "test", 10, units.KB)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	archive := &bytes.Buffer{}
+	require.NoError(t, WriteTarStream(ctx, archive, fs))
+	manifest := &bytes.Buffer{}
+	require.NoError(t, WriteManifest(ctx, fs, manifest))
+	// An archive that matches its manifest is imported.
+	w := s.NewWriter(ctx, "imported")
+	require.NoError(t, ImportTarStream(ctx, bytes.NewReader(archive.Bytes()), w, "0", WithManifest(bytes.NewReader(manifest.Bytes()))))
+	require.NoError(t, w.Close())
+	imported, err := s.Open(ctx, []string{"imported"})
+	require.NoError(t, err)
+	equal, diff, err := Equal(ctx, fs, imported)
+	require.NoError(t, err)
+	require.True(t, equal, diff)
+	// Tamper with the content of a file in the archive, without changing
+	// its size.
+	tampered := &bytes.Buffer{}
+	tr := tar.NewReader(bytes.NewReader(archive.Bytes()))
+	tw := tar.NewWriter(tampered)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		if hdr.Name == "/test/0005" {
+			data[0] ^= 0xFF
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	w = s.NewWriter(ctx, "tampered")
+	err = ImportTarStream(ctx, tampered, w, "0", WithManifest(bytes.NewReader(manifest.Bytes())))
+	require.YesError(t, err)
+	require.True(t, errors.Is(err, ErrChecksumMismatch), "unexpected error: %v", err)
+	require.True(t, strings.Contains(err.Error(), "checksum mismatch"), "unexpected error: %v", err)
+	require.True(t, strings.Contains(err.Error(), "/test/0005"), "unexpected error: %v", err)
+}
+
+func TestWriteTarStreamSHA256Sums(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	writeTestFileSet(t, s, "test", 10, units.KB)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	archive := &bytes.Buffer{}
+	require.NoError(t, WriteTarStream(ctx, archive, NewDirInserter(fs), WithSHA256Sums()))
+	// Extract the archive.
+	contents := make(map[string][]byte)
+	var names []string
+	tr := tar.NewReader(archive)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+		contents[hdr.Name] = data
+	}
+	// The SHA256SUMS entry is last, and lists each file (but not the
+	// directories) with the hash of its content.
+	require.Equal(t, SHA256SumsPath, names[len(names)-1])
+	lines := strings.Split(strings.TrimSuffix(string(contents[SHA256SumsPath]), "\n"), "\n")
+	require.Equal(t, 10, len(lines))
+	for i, line := range lines {
+		fields := strings.SplitN(line, "  ", 2)
+		require.Equal(t, 2, len(fields))
+		require.Equal(t, fmt.Sprintf("test/%04d", i), fields[1])
+		sum := sha256.Sum256(contents["/"+fields[1]])
+		require.Equal(t, hex.EncodeToString(sum[:]), fields[0])
+	}
+	// A file at the SHA256SUMS path conflicts with the entry.
+	w := s.NewWriter(ctx, "conflict")
+	require.NoError(t, w.Append(SHA256SumsPath, func(fw *FileWriter) error {
+		fw.Append("0")
+		_, err := fw.Write([]byte("data"))
+		return err
+	}))
+	require.NoError(t, w.Close())
+	fs, err = s.Open(ctx, []string{"conflict"})
+	require.NoError(t, err)
+	require.YesError(t, WriteTarStream(ctx, &bytes.Buffer{}, fs, WithSHA256Sums()))
+}
+
+func TestDrop(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	ttl := time.Second
+	_, chunks := chunk.NewTestStorage(t, db, tr, chunk.WithGCTimeout(ttl))
+	s := NewStorage(NewTestStore(t, db), tr, chunks)
+	// Both file sets start with the same file, so they share its chunks.
+	shared := chunk.RandSeq(units.MB)
+	writeFileSet := func(fileSet string) {
+		w := s.NewWriter(ctx, fileSet, WithChunkWriterOptions(chunk.WithTargetSize(64*units.KB)))
+		require.NoError(t, w.Append("/a", func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(shared)
+			return err
+		}))
+		require.NoError(t, w.Append("/b", func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(chunk.RandSeq(units.MB))
+			return err
+		}))
+		require.NoError(t, w.Close())
+	}
+	writeFileSet("dropped")
+	writeFileSet("kept")
+	chunkIDs := func(fileSet string) map[string]bool {
+		ids, err := s.tracker.GetDownstream(ctx, filesetObjectID(fileSet))
+		require.NoError(t, err)
+		chunkIDs := make(map[string]bool)
+		for _, id := range ids {
+			chunkIDs[id] = true
+		}
+		return chunkIDs
+	}
+	droppedChunks, keptChunks := chunkIDs("dropped"), chunkIDs("kept")
+	var numShared, numExclusive int
+	for id := range droppedChunks {
+		if keptChunks[id] {
+			numShared++
+		} else {
+			numExclusive++
+		}
+	}
+	require.True(t, numShared > 0, "the file sets do not share chunks")
+	require.True(t, numExclusive > 0, "the dropped file set has no exclusive chunks")
+	// Wait for the chunks' leases to expire, and collect the writers'
+	// expired temporary references to them, so that only the file sets
+	// reference the chunks.
+	time.Sleep(2 * ttl)
+	require.NoError(t, s.newGarbageCollector(ttl).RunCycle(ctx))
+	require.NoError(t, s.Drop(ctx, "dropped"))
+	// The shared chunks survive, and the exclusive chunks are deleted.
+	remaining := make(map[string]bool)
+	require.NoError(t, s.ChunkStorage().List(ctx, func(id string) error {
+		remaining[id] = true
+		return nil
+	}))
+	for id := range keptChunks {
+		require.True(t, remaining[id], "chunk %v referenced by the kept file set was deleted", id)
+	}
+	for id := range droppedChunks {
+		if !keptChunks[id] {
+			require.False(t, remaining[id], "chunk %v exclusive to the dropped file set was not deleted", id)
+		}
+	}
+	// The kept file set is still readable.
+	fs, err := s.Open(ctx, []string{"kept"})
+	require.NoError(t, err)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		return f.Content(ioutil.Discard)
+	}))
+	require.NoError(t, s.store.Walk(ctx, "dropped", func(name string) error {
+		return errors.Errorf("file set %v was not dropped", name)
+	}))
+}
+
+func TestDropLiveLease(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	writeTestFileSet(t, s, "dropped", 5, 100*units.KB)
+	dropped, err := s.ChunkReferences(ctx, "dropped")
+	require.NoError(t, err)
+	require.True(t, len(dropped) > 0)
+	require.NoError(t, s.Drop(ctx, "dropped"))
+	// Nothing references the chunks anymore, but their leases are still
+	// live (a writer may be about to reference them), so they survive the
+	// drop and are left to garbage collection.
+	remaining := make(map[string]bool)
+	require.NoError(t, s.ChunkStorage().List(ctx, func(id string) error {
+		remaining[id] = true
+		return nil
+	}))
+	for _, id := range dropped {
+		require.True(t, remaining[id], "chunk %v with a live lease was deleted", id)
+	}
+}
+func TestStat(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	w := s.NewWriter(ctx, "test")
+	data := chunk.RandSeq(100 * units.KB)
+	for _, p := range []string{"/a", "/b"} {
+		require.NoError(t, w.Append(p, func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(data)
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+	hdr, err := s.Stat(ctx, "test", "/b")
+	require.NoError(t, err)
+	require.Equal(t, "/b", hdr.Name)
+	require.Equal(t, int64(len(data)), hdr.Size)
+	// The header matches the header of the file's tar entry.
+	fs, err := s.Open(ctx, []string{"test"}, index.WithExact("/b"))
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteTarStream(ctx, buf, fs))
+	tarHdr, err := tar.NewReader(buf).Next()
+	require.NoError(t, err)
+	require.Equal(t, tarHdr.Size, hdr.Size)
+	require.Equal(t, tarHdr.Mode, hdr.Mode)
+	_, err = s.Stat(ctx, "test", "/c")
+	require.YesError(t, err)
+	require.True(t, errors.Is(err, ErrFileNotFound))
+}
+
+func TestWithLatency(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	numFiles := 5
+	writeTestFileSet(t, s, "test", numFiles, units.KB)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// The delay is applied before each file.
+	delay := 50 * time.Millisecond
+	var n int
+	start := time.Now()
+	require.NoError(t, WithLatency(fs, delay).Iterate(ctx, func(f File) error {
+		n++
+		require.True(t, time.Since(start) >= time.Duration(n)*delay, "file %v was delivered early", n)
+		return nil
+	}))
+	require.Equal(t, numFiles, n)
+	// The sleep is interrupted when the context is done.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	n = 0
+	start = time.Now()
+	err = WithLatency(fs, time.Hour).Iterate(timeoutCtx, func(f File) error {
+		n++
+		return nil
+	})
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+	require.True(t, time.Since(start) < 10*time.Second)
+	require.Equal(t, 0, n)
+}
+
+func TestCountPrefix(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	writeFiles(t, s, "test", map[string]string{
+		"/a/b/z": string(chunk.RandSeq(3 * units.KB)),
+		"/a/x":   string(chunk.RandSeq(units.KB)),
+		"/a/y":   string(chunk.RandSeq(2 * units.KB)),
+		"/ab":    string(chunk.RandSeq(4 * units.KB)),
+		"/c/w":   string(chunk.RandSeq(5 * units.KB)),
+	})
+	for prefix, expected := range map[string][2]int64{
+		"/":     {5, 15 * units.KB},
+		"/a/":   {3, 6 * units.KB},
+		"/a/b/": {1, 3 * units.KB},
+		"/c/":   {1, 5 * units.KB},
+		"/d/":   {0, 0},
+		// The prefix is not limited to directories.
+		"/a": {4, 10 * units.KB},
+	} {
+		files, size, err := s.CountPrefix(ctx, "test", prefix)
+		require.NoError(t, err)
+		require.Equal(t, expected, [2]int64{files, size}, "unexpected counts for prefix %v", prefix)
+	}
+}
+
+func TestIterateChunks(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	// The files are small, so many of them share each chunk, and the same
+	// content is written twice, so the chunks are referenced more than once.
+	w := s.NewWriter(ctx, "test", WithChunkWriterOptions(chunk.WithTargetSize(16*units.KB)))
+	content := chunk.RandSeq(100 * units.KB)
+	for i := 0; i < 200; i++ {
+		require.NoError(t, w.Append(fmt.Sprintf("/%04d", i), func(fw *FileWriter) error {
+			fw.Append("0")
+			offset := (i % 100) * units.KB
+			_, err := fw.Write(content[offset : offset+units.KB])
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	expected := make(map[string]int64)
+	var numRefs int
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		for _, dataRef := range getDataRefs(f.Index().File.Parts) {
+			expected[chunk.ID(dataRef.Ref.Id).HexString()] = dataRef.Ref.SizeBytes
+			numRefs++
+		}
+		return nil
+	}))
+	require.True(t, numRefs > len(expected), "the files do not share chunks")
+	visits := make(map[string]int)
+	require.NoError(t, s.IterateChunks(ctx, "test", func(dataRef *chunk.DataRef) error {
+		id := chunk.ID(dataRef.Ref.Id).HexString()
+		visits[id]++
+		// The data reference is for the whole chunk.
+		require.Equal(t, expected[id], dataRef.SizeBytes)
+		require.Equal(t, int64(0), dataRef.OffsetBytes)
+		return nil
+	}))
+	require.Equal(t, len(expected), len(visits))
+	for id, n := range visits {
+		require.Equal(t, 1, n, "chunk %v", id)
+	}
+}
+
+// countingClient counts the reads of each object.
+type countingClient struct {
+	obj.Client
+	mu    sync.Mutex
+	reads map[string]int
+}
+
+func (cc *countingClient) Reader(ctx context.Context, name string, offset, size uint64) (io.ReadCloser, error) {
+	cc.mu.Lock()
+	cc.reads[path.Base(name)]++
+	cc.mu.Unlock()
+	return cc.Client.Reader(ctx, name, offset, size)
+}
+
+func TestIterateFrom(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	objC := &countingClient{
+		Client: obj.NewTestClient(t),
+		reads:  make(map[string]int),
+	}
+	s := NewStorage(NewTestStore(t, db), tr, chunk.NewStorage(objC, chunk.NewTestStore(t, db), tr))
+	numFiles := 100
+	writeTestFileSet(t, s, "test", numFiles, 10*units.KB, WithChunkWriterOptions(chunk.WithTargetSize(4*units.KB)))
+	start := numFiles / 2
+	startPath := fmt.Sprintf("/test/%04d", start)
+	// Find the content chunks that are only referenced by files before the start path.
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	before := make(map[string]bool)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		for _, dataRef := range getDataRefs(f.Index().File.Parts) {
+			before[chunk.ID(dataRef.Ref.Id).HexString()] = f.Index().Path < startPath
+		}
+		return nil
+	}))
+	objC.mu.Lock()
+	objC.reads = make(map[string]int)
+	objC.mu.Unlock()
+	var paths []string
+	require.NoError(t, s.IterateFrom(ctx, "test", startPath, func(f File) error {
+		paths = append(paths, f.Index().Path)
+		return f.Content(ioutil.Discard)
+	}))
+	var expected []string
+	for i := start; i < numFiles; i++ {
+		expected = append(expected, fmt.Sprintf("/test/%04d", i))
+	}
+	require.Equal(t, expected, paths)
+	var numBefore int
+	for id, isBefore := range before {
+		if isBefore {
+			numBefore++
+			require.Equal(t, 0, objC.reads[id], "chunk %v before the start path was read", id)
+		}
+	}
+	require.True(t, numBefore > 0)
+}
+
+func TestDirFilterTarStream(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	files := []string{"/a/b/c/0", "/a/b/c/1", "/a/b/d", "/a/e", "/f"}
+	w := s.NewWriter(ctx, "test")
+	for _, p := range files {
+		require.NoError(t, w.Append(p, func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(chunk.RandSeq(100))
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// Export a file set with directory entries through the filter, the files
+	// in the omitted directories should still be exported.
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteTarStream(ctx, buf, NewDirFilter(NewDirInserter(fs))))
+	tr := tar.NewReader(buf)
+	var paths []string
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, byte(tar.TypeReg), hdr.Typeflag, "unexpected entry type for %v", hdr.Name)
+		paths = append(paths, hdr.Name)
+	}
+	require.Equal(t, files, paths)
+}
+
+func TestTestStorageIsolation(t *testing.T) {
+	// Each test storage has its own object storage directory and database, so
+	// parallel tests can write the same paths without interfering.
+	for i := 0; i < 2; i++ {
+		data := []byte(fmt.Sprintf("data-%v", i))
+		t.Run(fmt.Sprintf("storage-%v", i), func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			s := NewTestStorage(t)
+			w := s.NewWriter(ctx, "test")
+			require.NoError(t, w.Append("/file", func(fw *FileWriter) error {
+				fw.Append("0")
+				_, err := fw.Write(data)
+				return err
+			}))
+			require.NoError(t, w.Close())
+			fs, err := s.Open(ctx, []string{"test"})
+			require.NoError(t, err)
+			var files int
+			require.NoError(t, fs.Iterate(ctx, func(f File) error {
+				files++
+				buf := &bytes.Buffer{}
+				if err := f.Content(buf); err != nil {
+					return err
+				}
+				require.Equal(t, data, buf.Bytes())
+				return nil
+			}))
+			require.Equal(t, 1, files)
+		})
+	}
+}
+
+// latencyClient adds latency to each object read.
+type latencyClient struct {
+	obj.Client
+	latency time.Duration
+}
+
+func (lc *latencyClient) Reader(ctx context.Context, name string, offset, size uint64) (io.ReadCloser, error) {
+	time.Sleep(lc.latency)
+	return lc.Client.Reader(ctx, name, offset, size)
+}
+
+func newTestPrefetchStorage(t testing.TB, objC obj.Client, window int) *Storage {
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	return NewStorage(NewTestStore(t, db), tr, chunk.NewStorage(objC, chunk.NewTestStore(t, db), tr), WithPrefetch(window))
+}
+
+func TestIteratePrefetch(t *testing.T) {
+	ctx := context.Background()
+	s := newTestPrefetchStorage(t, &latencyClient{Client: obj.NewTestClient(t), latency: time.Millisecond}, 4)
+	numFiles := 50
+	// Files span multiple chunks, and chunks are shared between files.
+	w := s.NewWriter(ctx, "test", WithChunkWriterOptions(chunk.WithTargetSize(4*units.KB)))
+	var expected [][]byte
+	for i := 0; i < numFiles; i++ {
+		data := chunk.RandSeq(i * units.KB)
+		expected = append(expected, data)
+		require.NoError(t, w.Append(fmt.Sprintf("/%04d", i), func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(data)
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	i := 0
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		require.Equal(t, fmt.Sprintf("/%04d", i), f.Index().Path)
+		// Skipping the content of some files must not stall the prefetching.
+		if i%3 != 0 {
+			buf := &bytes.Buffer{}
+			require.NoError(t, f.Content(buf))
+			require.True(t, bytes.Equal(expected[i], buf.Bytes()), "content mismatch for %v", f.Index().Path)
+		}
+		i++
+		return nil
+	}))
+	require.Equal(t, numFiles, i)
+	// Errors from the callback stop the iteration.
+	i = 0
+	require.YesError(t, fs.Iterate(ctx, func(f File) error {
+		if i == 10 {
+			return errors.Errorf("stop")
+		}
+		i++
+		return nil
+	}))
+	require.Equal(t, 10, i)
+}
+
+func BenchmarkIteratePrefetch(b *testing.B) {
+	for _, window := range []int{0, 16} {
+		b.Run(fmt.Sprintf("window=%v", window), func(b *testing.B) {
+			s := newTestPrefetchStorage(b, &latencyClient{Client: obj.NewTestClient(b), latency: 10 * time.Millisecond}, window)
+			numFiles, fileSize := 20, 100*units.KB
+			writeTestFileSet(b, s, "test", numFiles, fileSize, WithChunkWriterOptions(chunk.WithTargetSize(16*units.KB)))
+			b.SetBytes(int64(numFiles * fileSize))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fs, err := s.Open(context.Background(), []string{"test"})
+				require.NoError(b, err)
+				require.NoError(b, fs.Iterate(context.Background(), func(f File) error {
+					return f.Content(ioutil.Discard)
+				}))
+			}
+		})
+	}
+}
+
+func TestIteratorPrefetchFiles(t *testing.T) {
+	ctx := context.Background()
+	s := newTestPrefetchStorage(t, &latencyClient{Client: obj.NewTestClient(t), latency: time.Millisecond}, 0)
+	numFiles := 50
+	w := s.NewWriter(ctx, "test", WithChunkWriterOptions(chunk.WithTargetSize(4*units.KB)))
+	var expected [][]byte
+	for i := 0; i < numFiles; i++ {
+		data := chunk.RandSeq(i * units.KB)
+		expected = append(expected, data)
+		require.NoError(t, w.Append(fmt.Sprintf("/%04d", i), func(fw *FileWriter) error {
+			fw.Append("0")
+			_, err := fw.Write(data)
+			return err
+		}))
+	}
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	// The files are returned in order with their content, even though their
+	// content is fetched concurrently.
+	iter := NewIterator(ctx, fs, WithPrefetchFiles(4))
+	for i := 0; i < numFiles; i++ {
+		f, err := iter.Next()
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("/%04d", i), f.Index().Path)
+		buf := &bytes.Buffer{}
+		require.NoError(t, f.Content(buf))
+		require.True(t, bytes.Equal(expected[i], buf.Bytes()), "content mismatch for %v", f.Index().Path)
+	}
+	_, err = iter.Next()
+	require.Equal(t, io.EOF, err)
+	// Files that are larger than the prefetch limit are streamed rather than
+	// prefetched.
+	limit := 10 * units.KB
+	iter = NewIterator(ctx, fs, WithPrefetchFiles(4), WithPrefetchBytes(int64(limit)))
+	for i := 0; i < numFiles; i++ {
+		f, err := iter.Next()
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("/%04d", i), f.Index().Path)
+		_, prefetched := f.(*prefetchedFile)
+		require.Equal(t, len(expected[i]) <= limit, prefetched, "unexpected prefetching for %v", f.Index().Path)
+		buf := &bytes.Buffer{}
+		require.NoError(t, f.Content(buf))
+		require.True(t, bytes.Equal(expected[i], buf.Bytes()), "content mismatch for %v", f.Index().Path)
+	}
+	_, err = iter.Next()
+	require.Equal(t, io.EOF, err)
+	// Canceling the context stops the prefetching.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	iter = NewIterator(cancelCtx, fs, WithPrefetchFiles(4))
+	_, err = iter.Next()
+	require.NoError(t, err)
+	cancel()
+	for err == nil {
+		_, err = iter.Next()
+	}
+	require.NotEqual(t, io.EOF, err)
+}
+
+func TestIteratorNextN(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	// The file set spans more than one batch of the iterator.
+	numFiles := 3*iteratorBatchSize + 10
+	writeTestFileSet(t, s, "test", numFiles, 10)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	iter := NewIterator(ctx, fs)
+	next := 0
+	checkFile := func(f File) {
+		require.Equal(t, fmt.Sprintf("/test/%04d", next), f.Index().Path)
+		next++
+	}
+	// Peek doesn't progress the iterator, so the peeked file is the first
+	// file returned by NextN.
+	f, err := iter.Peek()
+	require.NoError(t, err)
+	require.Equal(t, "/test/0000", f.Index().Path)
+	for _, n := range []int{1, 5, iteratorBatchSize, 2*iteratorBatchSize - 7, 0} {
+		files, err := iter.NextN(n)
+		require.NoError(t, err)
+		require.Equal(t, n, len(files))
+		for _, f := range files {
+			checkFile(f)
+		}
+		f, err := iter.Peek()
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("/test/%04d", next), f.Index().Path)
+		f, err = iter.Next()
+		require.NoError(t, err)
+		checkFile(f)
+	}
+	// The files left are fewer than n, and the iterator is then exhausted.
+	files, err := iter.NextN(numFiles)
+	require.NoError(t, err)
+	for _, f := range files {
+		checkFile(f)
+	}
+	require.Equal(t, numFiles, next)
+	_, err = iter.NextN(1)
+	require.Equal(t, io.EOF, err)
+	_, err = iter.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestIteratorFileBudget(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	writeTestFileSet(t, s, "test", 10, 10)
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	iterate := func(fs FileSet, opts ...IteratorOption) ([]string, bool) {
+		var paths []string
+		truncated, err := IterateWithFileBudget(ctx, fs, 100*time.Millisecond, func(f File) error {
+			paths = append(paths, f.Index().Path)
+			return nil
+		}, opts...)
+		require.NoError(t, err)
+		return paths, truncated
+	}
+	// The iteration isn't truncated if each file is received within the budget.
+	paths, truncated := iterate(WithLatency(fs, 10*time.Millisecond))
+	require.Equal(t, 10, len(paths))
+	require.False(t, truncated)
+	// The iteration is truncated after the files that are received within
+	// the budget, with or without prefetching.
+	for _, opts := range [][]IteratorOption{nil, {WithPrefetchFiles(2)}} {
+		paths, truncated = iterate(&stallFileSet{x: fs, n: 5, delay: time.Minute}, opts...)
+		require.Equal(t, 5, len(paths))
+		require.Equal(t, "/test/0004", paths[4])
+		require.True(t, truncated)
+	}
+	// A truncated iterator returns the files received within the budget, and
+	// then io.EOF.
+	iter := NewIterator(ctx, WithLatency(fs, time.Minute), WithFileBudget(100*time.Millisecond))
+	files, err := iter.NextN(10)
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, 0, len(files))
+	require.True(t, iter.Truncated())
+	_, err = iter.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+// stallFileSet delivers the first n files from x, and then sleeps for delay
+// (or until the context is done) before delivering each of the rest.
+type stallFileSet struct {
+	x     FileSet
+	n     int
+	delay time.Duration
+}
+
+func (sfs *stallFileSet) Iterate(ctx context.Context, cb func(File) error, deletive ...bool) error {
+	var i int
+	return sfs.x.Iterate(ctx, func(f File) error {
+		i++
+		if i <= sfs.n {
+			return cb(f)
+		}
+		timer := time.NewTimer(sfs.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return cb(f)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, deletive...)
+}
+
+func BenchmarkIteratorPrefetchFiles(b *testing.B) {
+	for _, n := range []int{0, 8} {
+		b.Run(fmt.Sprintf("files=%v", n), func(b *testing.B) {
+			s := newTestPrefetchStorage(b, &latencyClient{Client: obj.NewTestClient(b), latency: 10 * time.Millisecond}, 0)
+			numFiles, fileSize := 20, 100*units.KB
+			writeTestFileSet(b, s, "test", numFiles, fileSize, WithChunkWriterOptions(chunk.WithTargetSize(16*units.KB)))
+			b.SetBytes(int64(numFiles * fileSize))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fs, err := s.Open(context.Background(), []string{"test"})
+				require.NoError(b, err)
+				iter := NewIterator(context.Background(), fs, WithPrefetchFiles(n))
+				for {
+					f, err := iter.Next()
+					if err == io.EOF {
+						break
+					}
+					require.NoError(b, err)
+					require.NoError(b, f.Content(ioutil.Discard))
+				}
+			}
+		})
+	}
+}
+
+func TestOpenWithConflicts(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	// Each file's content is its file set and path.
+	write := func(fileSet string, paths ...string) {
+		files := make(map[string]string)
+		for _, p := range paths {
+			files[p] = fileSet + p
+		}
+		writeFiles(t, s, fileSet, files)
+	}
+	write("first", "/a", "/b", "/c")
+	write("second", "/b", "/c", "/d")
+	content := func(f File) string {
+		buf := &bytes.Buffer{}
+		require.NoError(t, f.Content(buf))
+		return buf.String()
+	}
+	conflicts := make(map[string][]string)
+	fs, err := s.OpenWithConflicts(ctx, []string{"first", "second"}, func(p string, files []File) (File, error) {
+		for _, f := range files {
+			conflicts[p] = append(conflicts[p], content(f))
+		}
+		// Skip /c, and use the first file set's content for /b.
+		if p == "/c" {
+			return nil, nil
+		}
+		return files[0], nil
+	})
+	require.NoError(t, err)
+	files := make(map[string]string)
+	var paths []string
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		paths = append(paths, f.Index().Path)
+		files[f.Index().Path] = content(f)
+		return nil
+	}))
+	require.Equal(t, map[string][]string{
+		"/b": {"first/b", "second/b"},
+		"/c": {"first/c", "second/c"},
+	}, conflicts)
+	require.Equal(t, []string{"/a", "/b", "/d"}, paths)
+	require.Equal(t, map[string]string{
+		"/a": "first/a",
+		"/b": "first/b",
+		"/d": "second/d",
+	}, files)
+	// Resolution errors are returned.
+	fs, err = s.OpenWithConflicts(ctx, []string{"first", "second"}, func(p string, _ []File) (File, error) {
+		return nil, errors.Errorf("conflict at %v", p)
+	})
+	require.NoError(t, err)
+	err = fs.Iterate(ctx, func(_ File) error { return nil })
+	require.YesError(t, err)
+	require.Matches(t, "conflict at /b", err.Error())
+	// The deletes in a later file set are applied before the conflicts are
+	// resolved, so a fully deleted file is not a conflict, and the deleted
+	// tags are not passed to the resolver.
+	w := s.NewWriter(ctx, "added")
+	require.NoError(t, w.Append("/e", func(fw *FileWriter) error {
+		fw.Append("0")
+		_, err := fw.Write([]byte("added/e"))
+		return err
+	}))
+	require.NoError(t, w.Append("/f", func(fw *FileWriter) error {
+		fw.Append("0")
+		if _, err := fw.Write([]byte("added/f0")); err != nil {
+			return err
+		}
+		fw.Append("1")
+		_, err := fw.Write([]byte("added/f1"))
+		return err
+	}))
+	require.NoError(t, w.Close())
+	w = s.NewWriter(ctx, "deleted")
+	require.NoError(t, w.Delete("/e"))
+	require.NoError(t, w.Delete("/f", "0"))
+	require.NoError(t, w.Close())
+	write("readded", "/e", "/f")
+	conflicts = make(map[string][]string)
+	fs, err = s.OpenWithConflicts(ctx, []string{"added", "deleted", "readded"}, func(p string, files []File) (File, error) {
+		for _, f := range files {
+			conflicts[p] = append(conflicts[p], content(f))
+		}
+		return files[0], nil
+	})
+	require.NoError(t, err)
+	files = make(map[string]string)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		files[f.Index().Path] = content(f)
+		return nil
+	}))
+	require.Equal(t, map[string][]string{
+		"/f": {"added/f1", "readded/f"},
+	}, conflicts)
+	require.Equal(t, map[string]string{
+		"/e": "readded/e",
+		"/f": "added/f1",
+	}, files)
+}
+
+func TestOpenWithPriority(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	// Each file's content is its file set and path.
+	write := func(fileSet string, paths ...string) {
+		files := make(map[string]string)
+		for _, p := range paths {
+			files[p] = fileSet + p
+		}
+		writeFiles(t, s, fileSet, files)
+	}
+	write("low", "/a", "/b", "/c", "/d")
+	write("high", "/b", "/c")
+	write("mid", "/a", "/c", "/e")
+	winners := make(map[string]string)
+	fs, err := s.OpenWithPriority(ctx, []string{"low", "high", "mid"}, []int{0, 2, 1}, func(p, winner string) error {
+		winners[p] = winner
+		return nil
+	})
+	require.NoError(t, err)
+	files := make(map[string]string)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		buf := &bytes.Buffer{}
+		if err := f.Content(buf); err != nil {
+			return err
+		}
+		files[f.Index().Path] = buf.String()
+		return nil
+	}))
+	require.Equal(t, map[string]string{
+		"/a": "mid",
+		"/b": "high",
+		"/c": "high",
+	}, winners)
+	require.Equal(t, map[string]string{
+		"/a": "mid/a",
+		"/b": "high/b",
+		"/c": "high/c",
+		"/d": "low/d",
+		"/e": "mid/e",
+	}, files)
+	_, err = s.OpenWithPriority(ctx, []string{"low", "high"}, []int{0}, nil)
+	require.YesError(t, err)
+	// A file that is deleted by a later file set doesn't win a conflict, even
+	// if its file set has a higher priority.
+	w := s.NewWriter(ctx, "older")
+	require.NoError(t, w.Append("/f", func(fw *FileWriter) error {
+		fw.Append("0")
+		_, err := fw.Write([]byte("older/f"))
+		return err
+	}))
+	require.NoError(t, w.Append("/g", func(fw *FileWriter) error {
+		fw.Append("0")
+		if _, err := fw.Write([]byte("older/g0")); err != nil {
+			return err
+		}
+		fw.Append("1")
+		_, err := fw.Write([]byte("older/g1"))
+		return err
+	}))
+	require.NoError(t, w.Close())
+	w = s.NewWriter(ctx, "deleted")
+	require.NoError(t, w.Delete("/f"))
+	require.NoError(t, w.Delete("/g", "0"))
+	require.NoError(t, w.Close())
+	write("newer", "/f", "/g")
+	winners = make(map[string]string)
+	fs, err = s.OpenWithPriority(ctx, []string{"older", "deleted", "newer"}, []int{1, 0, 0}, func(p, winner string) error {
+		winners[p] = winner
+		return nil
+	})
+	require.NoError(t, err)
+	files = make(map[string]string)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		buf := &bytes.Buffer{}
+		if err := f.Content(buf); err != nil {
+			return err
+		}
+		files[f.Index().Path] = buf.String()
+		return nil
+	}))
+	// The tag that is left of /g in the older file set still wins.
+	require.Equal(t, map[string]string{
+		"/g": "older",
+	}, winners)
+	require.Equal(t, map[string]string{
+		"/f": "newer/f",
+		"/g": "older/g1",
+	}, files)
+}
+
+func TestConcat(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	// The file sets are disjoint, since each file set's paths are prefixed
+	// with its name.
+	inputs := []string{"a", "b", "c"}
+	for _, fileSet := range inputs {
+		writeTestFileSet(t, s, fileSet, 10, units.KB)
+	}
+	require.NoError(t, s.Concat(ctx, "concat", inputs, testTTL))
+	// The output has a layer for each input file set.
+	var layers []string
+	require.NoError(t, s.Store().Walk(ctx, "concat", func(p string) error {
+		layers = append(layers, p)
+		return nil
+	}))
+	require.Equal(t, len(inputs), len(layers))
+	expected, err := s.Open(ctx, inputs)
+	require.NoError(t, err)
+	actual, err := s.Open(ctx, []string{"concat"})
+	require.NoError(t, err)
+	var paths []string
+	require.NoError(t, actual.Iterate(ctx, func(f File) error {
+		paths = append(paths, f.Index().Path)
+		return nil
+	}))
+	require.Equal(t, 30, len(paths))
+	require.True(t, sort.StringsAreSorted(paths))
+	equal, diff, err := Equal(ctx, expected, actual)
+	require.NoError(t, err)
+	require.True(t, equal, diff)
+	// Concatenating file sets out of order, or file sets that overlap, is
+	// an error.
+	require.YesError(t, s.Concat(ctx, "reversed", []string{"b", "a"}, testTTL))
+	require.YesError(t, s.Concat(ctx, "overlap", []string{"a", "concat"}, testTTL))
+}
+
+func TestCopyTo(t *testing.T) {
+	ctx := context.Background()
+	// Each test storage has its own object storage and database.
+	src, dst := NewTestStorage(t), NewTestStorage(t)
+	// The file set has two layers, with enough content for multiple chunks.
+	writeTestFileSet(t, src, "test/a", 100, 10*units.KB)
+	writeTestFileSet(t, src, "test/b", 100, 10*units.KB)
+	require.NoError(t, src.CopyTo(ctx, dst, "test", "copy", testTTL))
+	expected, err := src.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	actual, err := dst.Open(ctx, []string{"copy"})
+	require.NoError(t, err)
+	equal, diff, err := Equal(ctx, expected, actual)
+	require.NoError(t, err)
+	require.True(t, equal, diff)
+	// Copying a file set whose chunks already exist in the destination only
+	// copies the file set's metadata.
+	require.NoError(t, src.CopyTo(ctx, dst, "test", "copy2", testTTL))
+	actual, err = dst.Open(ctx, []string{"copy2"})
+	require.NoError(t, err)
+	equal, diff, err = Equal(ctx, expected, actual)
+	require.NoError(t, err)
+	require.True(t, equal, diff)
+	// Copying a file set that does not exist copies nothing.
+	require.NoError(t, src.CopyTo(ctx, dst, "missing", "copy3", testTTL))
+	_, err = dst.Open(ctx, []string{"copy3"})
+	require.YesError(t, err)
+}
+
+func TestOpenReaderAt(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	// The file is split into many small chunks.
+	content := chunk.RandSeq(2 * units.MB)
+	w := s.NewWriter(ctx, "test", WithChunkWriterOptions(chunk.WithTargetSize(64*units.KB)))
+	require.NoError(t, w.Append("/file", func(fw *FileWriter) error {
+		fw.Append("0")
+		_, err := fw.Write(content)
+		return err
+	}))
+	require.NoError(t, w.Close())
+	fs, err := s.Open(ctx, []string{"test"})
+	require.NoError(t, err)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		require.True(t, len(getDataRefs(f.Index().File.Parts)) > 1)
+		return nil
+	}))
+	r, size, err := s.OpenReaderAt(ctx, "test", "/file")
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), size)
+	// Read arbitrary ranges, including ranges that span chunks.
+	random := rand.New(rand.NewSource(0))
+	for i := 0; i < 50; i++ {
+		off := random.Int63n(size)
+		data := make([]byte, random.Int63n(256*units.KB))
+		n, err := r.ReadAt(data, off)
+		if off+int64(len(data)) > size {
+			require.True(t, errors.Is(err, io.EOF))
+			require.Equal(t, int(size-off), n)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, len(data), n)
+		}
+		require.True(t, bytes.Equal(content[off:off+int64(n)], data[:n]), "range [%v, %v) does not match", off, off+int64(n))
+	}
+	// The full content can be read through a section reader.
+	data, err := ioutil.ReadAll(io.NewSectionReader(r, 0, size))
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(content, data))
+	// Reads at the end of the content return io.EOF.
+	_, err = r.ReadAt(make([]byte, 1), size)
+	require.True(t, errors.Is(err, io.EOF))
+	_, _, err = s.OpenReaderAt(ctx, "test", "/missing")
+	require.True(t, errors.Is(err, ErrFileNotFound))
+}
+
+func TestEstimateCompaction(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	s.shardThreshold = 15 * units.KB
+	s.maxFanIn = 3
+	// Each input file set has 10KB of content.
+	var inputs []string
+	for i := 0; i < 7; i++ {
+		fileSet := fmt.Sprintf("input-%v", i)
+		writeTestFileSet(t, s, fileSet, 10, units.KB)
+		inputs = append(inputs, fileSet)
+	}
+	inputBytes := int64(len(inputs)) * 10 * units.KB
+	// The estimated shards match the shards of the merged inputs.
+	fs, err := s.Open(ctx, inputs)
+	require.NoError(t, err)
+	var shards int
+	require.NoError(t, s.Shard(ctx, fs, func(*index.PathRange, int64) error {
+		shards++
+		return nil
+	}))
+	require.Equal(t, shards, s.estimateShards(inputBytes))
+	// The 7 inputs are merged in groups of at most 3 (2 + 2 + 1 shards), and
+	// then the 3 outputs are merged (5 shards), so each input byte is read
+	// twice.
+	subtasks, estimatedBytes, err := s.EstimateCompaction(ctx, inputs)
+	require.NoError(t, err)
+	require.True(t, subtasks >= 8 && subtasks <= 12, "unexpected estimated subtasks: %v", subtasks)
+	require.True(t, estimatedBytes >= 2*inputBytes && estimatedBytes <= 2*inputBytes+int64(len(inputs))*units.KB, "unexpected estimated bytes: %v", estimatedBytes)
+	// A single merge is enough when the fan-in isn't exceeded.
+	s.maxFanIn = len(inputs)
+	subtasks, estimatedBytes, err = s.EstimateCompaction(ctx, inputs)
+	require.NoError(t, err)
+	require.Equal(t, shards, subtasks)
+	require.Equal(t, inputBytes, estimatedBytes)
+	// A single file set is copied rather than compacted.
+	subtasks, estimatedBytes, err = s.EstimateCompaction(ctx, inputs[:1])
+	require.NoError(t, err)
+	require.Equal(t, 0, subtasks)
+	require.Equal(t, int64(0), estimatedBytes)
+	_, _, err = s.EstimateCompaction(ctx, []string{"missing"})
+	require.YesError(t, err)
+}
+
+func TestCompactDryRun(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	s.shardThreshold = 15 * units.KB
+	s.maxFanIn = 3
+	// The input file sets overlap, so the merges concatenate the content of
+	// the same files.
+	var inputs []string
+	for i := 0; i < 7; i++ {
+		fileSet := fmt.Sprintf("input-%v", i)
+		files := make(map[string]string)
+		for j := 0; j < 10; j++ {
+			files[fmt.Sprintf("/%04d", j)] = string(chunk.RandSeq(units.KB))
+		}
+		writeFiles(t, s, fileSet, files)
+		inputs = append(inputs, fileSet)
+	}
+	stats, err := s.Compact(ctx, "dry-run", inputs, testTTL, WithDryRun())
+	require.NoError(t, err)
+	plan := stats.Plan
+	require.Equal(t, int64(len(inputs))*10*units.KB, stats.OutputSize)
+	// The dry run doesn't write the output.
+	require.NoError(t, s.Store().Walk(ctx, "dry-run", func(p string) error {
+		return errors.Errorf("unexpected output %v", p)
+	}))
+	// The 7 inputs are merged in groups of at most 3, and then the outputs
+	// of the groups are merged.
+	require.Equal(t, inputs, plan.Inputs)
+	require.Equal(t, 3, len(plan.Children))
+	for i, child := range plan.Children {
+		end := 3 * (i + 1)
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		require.Equal(t, inputs[3*i:end], child.Inputs)
+		require.Equal(t, 0, len(child.Children))
+	}
+	// The plan is checked against the subtasks that pfs dispatches in
+	// TestCompactionPlanDispatch.
+	require.True(t, len(plan.Shards) > 1)
+	_, err = s.Compact(ctx, "dry-run", []string{"missing"}, testTTL, WithDryRun())
+	require.YesError(t, err)
+}
+
+func TestCompactIndexSidecar(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	var inputs []string
+	for i := 0; i < 3; i++ {
+		fileSet := fmt.Sprintf("input-%v", i)
+		writeTestFileSet(t, s, fileSet, 20, 10*units.KB)
+		inputs = append(inputs, fileSet)
+	}
+	sidecarBuf := &bytes.Buffer{}
+	_, err := s.Compact(ctx, "output", inputs, testTTL, WithIndexSidecar(sidecarBuf))
+	require.NoError(t, err)
+	sidecar, err := LoadIndexSidecar(sidecarBuf)
+	require.NoError(t, err)
+	fs, err := s.Open(ctx, []string{"output"})
+	require.NoError(t, err)
+	var paths []string
+	contents := make(map[string][]byte)
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		buf := &bytes.Buffer{}
+		if err := f.Content(buf); err != nil {
+			return err
+		}
+		paths = append(paths, f.Index().Path)
+		contents[f.Index().Path] = buf.Bytes()
+		return nil
+	}))
+	require.Equal(t, len(paths), sidecar.Len())
+	// A lookup of a random path through the sidecar reads the same content
+	// as iterating over the file set.
+	p := paths[rand.Intn(len(paths))]
+	dataRefs, ok := sidecar.Lookup(p)
+	require.True(t, ok)
+	actual := &bytes.Buffer{}
+	require.NoError(t, s.ChunkStorage().NewReader(ctx, dataRefs).Get(actual))
+	require.True(t, bytes.Equal(contents[p], actual.Bytes()))
+	_, ok = sidecar.Lookup("/missing")
+	require.False(t, ok)
+}
+
+// vanishingStore deletes the file sets with a prefix the first time that they
+// are read, which simulates an input being deleted (e.g. garbage collected)
+// after a compaction has resolved its inputs.
+type vanishingStore struct {
+	Store
+	prefix string
+	mu     sync.Mutex
+	gets   map[string]int
+}
+
+func (vs *vanishingStore) Get(ctx context.Context, p string) (*Metadata, error) {
+	if strings.HasPrefix(p, vs.prefix) {
+		vs.mu.Lock()
+		vs.gets[p]++
+		first := vs.gets[p] == 1
+		vs.mu.Unlock()
+		if first {
+			if err := vs.Store.Delete(ctx, p); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return vs.Store.Get(ctx, p)
+}
+
+func TestCompactInputChanged(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	_, chunks := chunk.NewTestStorage(t, db, tr)
+	store := &vanishingStore{Store: NewTestStore(t, db), prefix: "vanishing", gets: make(map[string]int)}
+	s := NewStorage(store, tr, chunks)
+	for _, fileSet := range []string{"a", "vanishing", "b"} {
+		writeTestFileSet(t, s, fileSet, 3, units.KB)
+	}
+	// The vanishing input is deleted when the compaction reads it, so the
+	// compaction is retried with the remaining inputs.
+	_, err := s.Compact(ctx, "output", []string{"a", "vanishing", "b"}, testTTL)
+	require.NoError(t, err)
+	fs, err := s.Open(ctx, []string{"output"})
+	require.NoError(t, err)
+	var paths []string
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		paths = append(paths, f.Index().Path)
+		return nil
+	}))
+	require.Equal(t, []string{"/a/0000", "/a/0001", "/a/0002", "/b/0000", "/b/0001", "/b/0002"}, paths)
+	// The compaction fails if none of the inputs remain, and no output is
+	// written.
+	writeTestFileSet(t, s, "vanishing-2", 3, units.KB)
+	_, err = s.Compact(ctx, "output-2", []string{"vanishing-2"}, testTTL)
+	require.YesError(t, err)
+	require.Matches(t, "non-existent fileset", err.Error())
+	_, err = store.Get(ctx, "output-2")
+	require.True(t, errors.Is(err, ErrPathNotExists))
+}
+
+func TestCompactSingleLayer(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestStorage(t)
+	var inputs []string
+	for i := 0; i < 3; i++ {
+		fileSet := fmt.Sprintf("input-%v", i)
+		writeTestFileSet(t, s, fileSet, 3, units.KB)
+		inputs = append(inputs, fileSet)
+	}
+	_, err := s.Compact(ctx, "output", inputs, testTTL, ForceSingleLayer())
+	require.NoError(t, err)
+	// The output is read directly, rather than through a merge reader.
+	fs, err := s.Open(ctx, []string{"output"})
+	require.NoError(t, err)
+	_, ok := fs.(*Reader)
+	require.True(t, ok, "unexpected file set type: %T", fs)
+	var n int
+	require.NoError(t, fs.Iterate(ctx, func(File) error {
+		n++
+		return nil
+	}))
+	require.Equal(t, 9, n)
+	// A file set under the output's path would be merged with the output
+	// when it's read, so it is compacted into the output.
+	writeTestFileSet(t, s, "output-2/extra", 1, units.KB)
+	_, err = s.Compact(ctx, "expected", append(inputs, "output-2/extra"), testTTL)
+	require.NoError(t, err)
+	_, err = s.Compact(ctx, "output-2", inputs, testTTL, ForceSingleLayer())
+	require.NoError(t, err)
+	var layers []string
+	require.NoError(t, s.Store().Walk(ctx, "output-2", func(p string) error {
+		layers = append(layers, p)
+		return nil
+	}))
+	require.Equal(t, []string{"output-2"}, layers)
+	fs, err = s.Open(ctx, []string{"output-2"})
+	require.NoError(t, err)
+	_, ok = fs.(*Reader)
+	require.True(t, ok, "unexpected file set type: %T", fs)
+	expected, err := s.Open(ctx, []string{"expected"})
+	require.NoError(t, err)
+	equal, diff, err := Equal(ctx, expected, fs)
+	require.NoError(t, err)
+	require.True(t, equal, diff)
+}
+
+func TestCompactGC(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.NewTestDB(t)
+	tr := track.NewTestTracker(t, db)
+	ttl := time.Second
+	objC, chunks := chunk.NewTestStorage(t, db, tr, chunk.WithGCTimeout(ttl))
+	s := NewStorage(NewTestStore(t, db), tr, chunks)
+	var inputs []string
+	for i := 0; i < 3; i++ {
+		fileSet := fmt.Sprintf("input-%v", i)
+		writeTestFileSet(t, s, fileSet, 5, 100*units.KB, WithTTL(ttl))
+		inputs = append(inputs, fileSet)
+	}
+	_, err := s.Compact(ctx, "output", inputs, testTTL)
+	require.NoError(t, err)
+	referenced, err := s.ChunkReferences(ctx, "output")
+	require.NoError(t, err)
+	require.True(t, len(referenced) > 0)
+	// A file set that nothing else references the chunks of.
+	writeTestFileSet(t, s, "orphan", 5, 100*units.KB, WithTTL(ttl))
+	orphaned, err := s.ChunkReferences(ctx, "orphan")
+	require.NoError(t, err)
+	require.True(t, len(orphaned) > 0)
+	// Once the inputs, the orphan and the chunks' own TTLs have expired, the
+	// output's references are all that keep its chunks alive.
+	time.Sleep(2 * ttl)
+	require.NoError(t, s.newGarbageCollector(time.Second).RunCycle(ctx))
+	for _, id := range referenced {
+		require.True(t, objC.Exists(ctx, id), "referenced chunk %v was deleted", id)
+	}
+	for _, id := range orphaned {
+		require.False(t, objC.Exists(ctx, id), "orphaned chunk %v was not deleted", id)
+	}
+	// The output is still readable.
+	fs, err := s.Open(ctx, []string{"output"})
+	require.NoError(t, err)
+	var n int
+	require.NoError(t, fs.Iterate(ctx, func(f File) error {
+		n++
+		return f.Content(ioutil.Discard)
+	}))
+	require.Equal(t, 15, n)
+}
@@ -0,0 +1,33 @@
+package fileset
+
+import (
+	"context"
+	"time"
+)
+
+var _ FileSet = &latencyFileSet{}
+
+type latencyFileSet struct {
+	x     FileSet
+	delay time.Duration
+}
+
+// WithLatency returns a file set that sleeps for delay before delivering each
+// file from x, for testing timeout and cancellation handling. The sleep is
+// interrupted (with the context's error) when the context is done.
+func WithLatency(x FileSet, delay time.Duration) FileSet {
+	return &latencyFileSet{x: x, delay: delay}
+}
+
+func (lfs *latencyFileSet) Iterate(ctx context.Context, cb func(File) error, deletive ...bool) error {
+	return lfs.x.Iterate(ctx, func(f File) error {
+		timer := time.NewTimer(lfs.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return cb(f)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, deletive...)
+}
@@ -1,10 +1,15 @@
 package fileset
 
 import (
+	"bytes"
 	"context"
 	"io"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/hash"
 )
 
 var _ FileSet = &indexFilter{}
@@ -29,6 +34,15 @@ func (fil *indexFilter) Iterate(ctx context.Context, cb func(File) error, _ ...b
 	})
 }
 
+// FilterSize filters x to the files with a size in [min, max]. The sizes are
+// read from the index entries, so the content of the files is not read.
+func FilterSize(x FileSet, min, max int64) FileSet {
+	return NewIndexFilter(x, func(idx *index.Index) bool {
+		size := index.SizeBytes(idx)
+		return size >= min && size <= max
+	})
+}
+
 var _ FileSet = &indexMapper{}
 
 type indexMapper struct {
@@ -65,3 +79,262 @@ func (im *indexMap) Index() *index.Index {
 func (im *indexMap) Content(w io.Writer) error {
 	return im.inner.Content(w)
 }
+
+var _ FileSet = &rewriter{}
+
+type rewriter struct {
+	x  FileSet
+	fn func(string) (string, error)
+}
+
+// Rewrite rewrites the paths of the files in x with fn.
+// The rewritten paths must be in the same order as the original paths, so
+// that the file set is still sorted, otherwise iteration errors.
+func Rewrite(x FileSet, fn func(string) (string, error)) FileSet {
+	return &rewriter{x: x, fn: fn}
+}
+
+func (r *rewriter) Iterate(ctx context.Context, cb func(File) error, _ ...bool) error {
+	var lastPath string
+	return r.x.Iterate(ctx, func(f File) error {
+		idx := f.Index()
+		p, err := r.fn(idx.Path)
+		if err != nil {
+			return err
+		}
+		if lastPath != "" && p <= lastPath {
+			return errors.Errorf("rewriting %v to %v breaks the file set ordering (previous path: %v)", idx.Path, p, lastPath)
+		}
+		lastPath = p
+		y := proto.Clone(idx).(*index.Index)
+		y.Path = p
+		return cb(&indexMap{
+			idx:   y,
+			inner: f,
+		})
+	})
+}
+
+var _ FileSet = &missingChunkSkipper{}
+
+type missingChunkSkipper struct {
+	chunks    *chunk.Storage
+	x         FileSet
+	onMissing func(File, error) error
+}
+
+// SkipMissingChunks salvages the readable files of x, when some of the chunks
+// that x references are missing (e.g. after a partial loss of object
+// storage). The chunks that each file references are checked to exist (their
+// content is not read) before the file is passed to the callback, and a file
+// with missing chunks is passed to onMissing (with the error) and skipped,
+// rather than failing the iteration. The iteration stops if onMissing returns
+// an error. Missing index chunks still fail the iteration, since the files
+// that they index are unknown.
+func SkipMissingChunks(chunks *chunk.Storage, x FileSet, onMissing func(File, error) error) FileSet {
+	return &missingChunkSkipper{chunks: chunks, x: x, onMissing: onMissing}
+}
+
+func (s *missingChunkSkipper) Iterate(ctx context.Context, cb func(File) error, deletive ...bool) error {
+	if len(deletive) > 0 && deletive[0] {
+		// Deletive files have no content.
+		return s.x.Iterate(ctx, cb, deletive...)
+	}
+	return s.x.Iterate(ctx, func(f File) error {
+		if err := checkDataRefs(ctx, s.chunks, fileDataRefs(f.Index())); err != nil {
+			if !errors.Is(err, chunk.ErrChunkNotExists) {
+				return err
+			}
+			return s.onMissing(f, err)
+		}
+		return cb(f)
+	})
+}
+
+var _ FileSet = &subtractor{}
+
+type subtractor struct {
+	a, b           FileSet
+	compareContent bool
+}
+
+// Subtract returns the files in a that are not in b.
+// A file in a is removed if b has a file with the same path, or if
+// compareContent is set, a file with the same path and content.
+// Both file sets are iterated once in lexicographical order.
+func Subtract(a, b FileSet, compareContent ...bool) FileSet {
+	return &subtractor{
+		a:              a,
+		b:              b,
+		compareContent: len(compareContent) > 0 && compareContent[0],
+	}
+}
+
+func (s *subtractor) Iterate(ctx context.Context, cb func(File) error, _ ...bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	bIter := NewIterator(ctx, s.b)
+	return s.a.Iterate(ctx, func(fa File) error {
+		p := fa.Index().Path
+		for {
+			fb, err := bIter.Peek()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return cb(fa)
+				}
+				return err
+			}
+			if fb.Index().Path >= p {
+				break
+			}
+			if _, err := bIter.Next(); err != nil {
+				return err
+			}
+		}
+		fb, err := bIter.Peek()
+		if err != nil {
+			return err
+		}
+		if fb.Index().Path != p {
+			return cb(fa)
+		}
+		if !s.compareContent {
+			return nil
+		}
+		same, err := sameContent(fa, fb)
+		if err != nil {
+			return err
+		}
+		if same {
+			return nil
+		}
+		return cb(fa)
+	})
+}
+
+// sameContent checks if two files have the same content.
+// Files that reference the same data are the same without reading the
+// content, otherwise the content hashes are compared.
+func sameContent(x, y File) (bool, error) {
+	xDataRefs := getDataRefs(x.Index().File.Parts)
+	yDataRefs := getDataRefs(y.Index().File.Parts)
+	if sizeOf(xDataRefs) != sizeOf(yDataRefs) {
+		return false, nil
+	}
+	if len(xDataRefs) == len(yDataRefs) {
+		same := true
+		for i := range xDataRefs {
+			if !proto.Equal(xDataRefs[i], yDataRefs[i]) {
+				same = false
+				break
+			}
+		}
+		if same {
+			return true, nil
+		}
+	}
+	xHash, err := contentHash(x)
+	if err != nil {
+		return false, err
+	}
+	yHash, err := contentHash(y)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(xHash, yHash), nil
+}
+
+func sizeOf(dataRefs []*chunk.DataRef) int64 {
+	var size int64
+	for _, dataRef := range dataRefs {
+		size += dataRef.SizeBytes
+	}
+	return size
+}
+
+func contentHash(f File) ([]byte, error) {
+	h := hash.New()
+	if err := f.Content(h); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// NewDirFilter filters out the directory entries in x.
+func NewDirFilter(x FileSet) FileSet {
+	return NewIndexFilter(x, func(idx *index.Index) bool {
+		return !IsDir(idx.Path)
+	})
+}
+
+var _ FileSet = &verifier{}
+
+type verifier struct {
+	x FileSet
+}
+
+// NewVerifier verifies the content of the files in x against the hashes of
+// their data references, and errors on a mismatch.
+// This reads the content of every file, so it is expensive.
+func NewVerifier(x FileSet) FileSet {
+	return &verifier{x: x}
+}
+
+func (v *verifier) Iterate(ctx context.Context, cb func(File) error, _ ...bool) error {
+	return v.x.Iterate(ctx, func(f File) error {
+		if err := verifyFile(f); err != nil {
+			return errors.Wrapf(err, "error verifying %v", f.Index().Path)
+		}
+		return cb(f)
+	})
+}
+
+func verifyFile(f File) error {
+	vw := &verifyWriter{dataRefs: getDataRefs(f.Index().File.Parts)}
+	if err := f.Content(vw); err != nil {
+		return err
+	}
+	return vw.Close()
+}
+
+// verifyWriter verifies the data written to it against a list of data
+// references.
+type verifyWriter struct {
+	dataRefs []*chunk.DataRef
+	buf      []byte
+}
+
+func (vw *verifyWriter) Write(data []byte) (int, error) {
+	n := len(data)
+	for len(data) > 0 {
+		if len(vw.dataRefs) == 0 {
+			return 0, errors.Errorf("content is larger than its data references")
+		}
+		dataRef := vw.dataRefs[0]
+		remaining := int(dataRef.SizeBytes) - len(vw.buf)
+		if remaining > len(data) {
+			vw.buf = append(vw.buf, data...)
+			return n, nil
+		}
+		vw.buf = append(vw.buf, data[:remaining]...)
+		data = data[remaining:]
+		if err := chunk.VerifyDataRef(dataRef, vw.buf); err != nil {
+			return 0, err
+		}
+		vw.buf = vw.buf[:0]
+		vw.dataRefs = vw.dataRefs[1:]
+	}
+	return n, nil
+}
+
+// Close verifies the remaining data references, which will fail for any
+// data reference that was not fully written.
+func (vw *verifyWriter) Close() error {
+	for _, dataRef := range vw.dataRefs {
+		if err := chunk.VerifyDataRef(dataRef, vw.buf); err != nil {
+			return err
+		}
+		vw.buf = nil
+	}
+	return nil
+}
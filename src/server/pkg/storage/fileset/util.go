@@ -2,17 +2,38 @@ package fileset
 
 import (
 	"archive/tar"
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"fmt"
 	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	units "github.com/docker/go-units"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/server/pkg/dbutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/chunk"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/fileset/index"
+	"github.com/pachyderm/pachyderm/src/server/pkg/storage/hash"
 	"github.com/pachyderm/pachyderm/src/server/pkg/storage/track"
 	"github.com/pachyderm/pachyderm/src/server/pkg/tarutil"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+const (
+	tarBlockSize = 512
+	// contentTypeSniffLen is the number of bytes that http.DetectContentType
+	// considers.
+	contentTypeSniffLen = 512
 )
 
 // NewTestStorage constructs a local storage instance scoped to the lifetime of the test
@@ -38,6 +59,100 @@ func CopyFiles(ctx context.Context, w *Writer, fs FileSet, deletive ...bool) err
 	})
 }
 
+// CopyStats summarizes a CopyFilesIsolated copy.
+type CopyStats struct {
+	// Copied is the number of files that were copied, and CopiedBytes is
+	// their total size.
+	Copied      int64
+	CopiedBytes int64
+	// Failed is the number of files that were reported to the error callback
+	// and skipped.
+	Failed int64
+}
+
+// CopyFilesIsolated copies the files from a file set to a file set writer,
+// like CopyFiles, except that a file that can't be copied is passed to
+// onError (with the error) and skipped, rather than failing the copy. Each
+// file's path and the existence of the chunks it references are checked
+// before the file is written, so that a skipped file doesn't leave part of
+// itself in w, and the content of the files is not read. The copy stops if
+// onError returns an error. The stats of the copy are returned, even if it
+// stops early.
+func CopyFilesIsolated(ctx context.Context, w *Writer, fs FileSet, onError func(File, error) error, opts ...CopyOption) (*CopyStats, error) {
+	config := &copyConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	stats := &CopyStats{}
+	progress := func() error {
+		if config.progress == nil {
+			return nil
+		}
+		s := *stats
+		return config.progress(&s)
+	}
+	// reported is whether the progress has been reported since the last file
+	// was copied (or skipped).
+	var reported bool
+	if err := fs.Iterate(ctx, func(f File) error {
+		reported = false
+		err := checkCopy(ctx, w, f)
+		if err == nil {
+			err = w.Copy(f)
+		}
+		if err != nil {
+			stats.Failed++
+			if err := onError(f, err); err != nil {
+				return err
+			}
+		} else {
+			stats.Copied++
+			stats.CopiedBytes += index.SizeBytes(f.Index())
+		}
+		if config.progressInterval > 0 && (stats.Copied+stats.Failed)%int64(config.progressInterval) == 0 {
+			reported = true
+			return progress()
+		}
+		return nil
+	}); err != nil {
+		return stats, err
+	}
+	if reported {
+		return stats, nil
+	}
+	return stats, progress()
+}
+
+// checkCopy checks that a file can be copied to w, by checking its path
+// against the last path written to w and checking that the chunks it
+// references exist.
+func checkCopy(ctx context.Context, w *Writer, f File) error {
+	idx := f.Index()
+	if w.idx != nil {
+		if err := w.checkPath(w.idx.Path, idx.Path); err != nil {
+			return err
+		}
+	}
+	return checkDataRefs(ctx, w.chunks, fileDataRefs(idx))
+}
+
+// checkDataRefs checks that the chunks referenced by dataRefs exist, without
+// reading their content.
+func checkDataRefs(ctx context.Context, chunks *chunk.Storage, dataRefs []*chunk.DataRef) error {
+	checked := make(map[string]bool)
+	for _, dataRef := range dataRefs {
+		chunkID := chunk.ID(dataRef.Ref.Id)
+		if checked[chunkID.HexString()] {
+			continue
+		}
+		checked[chunkID.HexString()] = true
+		if err := chunks.Check(ctx, chunkID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func deleteIndex(w *Writer, idx *index.Index) error {
 	p := idx.Path
 	if len(idx.File.Parts) == 0 {
@@ -65,15 +180,570 @@ func WriteTarEntry(w io.Writer, f File) error {
 
 // WriteTarStream writes an entire tar stream to w
 // It will contain an entry for each File in fs
-func WriteTarStream(ctx context.Context, w io.Writer, fs FileSet) error {
+func WriteTarStream(ctx context.Context, w io.Writer, fs FileSet, opts ...TarOption) error {
+	config := &tarConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	sums := &bytes.Buffer{}
+	if err := fs.Iterate(ctx, func(f File) error {
+		if !config.sha256Sums {
+			return WriteTarEntry(w, f)
+		}
+		p := f.Index().Path
+		if p == SHA256SumsPath {
+			return errors.Errorf("file %v conflicts with the SHA256SUMS entry", p)
+		}
+		if IsDir(p) {
+			return WriteTarEntry(w, f)
+		}
+		h := sha256.New()
+		if err := WriteTarEntry(w, &teeFile{File: f, w: h}); err != nil {
+			return err
+		}
+		writeSHA256Sum(sums, strings.TrimPrefix(p, "/"), h.Sum(nil))
+		return nil
+	}); err != nil {
+		return err
+	}
+	tw := tar.NewWriter(w)
+	if config.sha256Sums {
+		if err := tw.WriteHeader(tarutil.NewHeader(SHA256SumsPath, int64(sums.Len()))); err != nil {
+			return err
+		}
+		if _, err := tw.Write(sums.Bytes()); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// SHA256SumsPath is the path of the tar entry written by WithSHA256Sums.
+const SHA256SumsPath = "/SHA256SUMS"
+
+// writeSHA256Sum writes the sha256sum line for the file at p with hash sum to
+// w. Like sha256sum, backslashes and newlines in p are escaped, and the line
+// is prefixed with a backslash if p was escaped.
+func writeSHA256Sum(w io.Writer, p string, sum []byte) {
+	if strings.ContainsAny(p, "\\\n") {
+		p = strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(p)
+		fmt.Fprint(w, "\\")
+	}
+	fmt.Fprintf(w, "%x  %v\n", sum, p)
+}
+
+// teeFile is a File that also writes its content to w when it is read.
+type teeFile struct {
+	File
+	w io.Writer
+}
+
+func (tf *teeFile) Content(w io.Writer) error {
+	return tf.File.Content(io.MultiWriter(w, tf.w))
+}
+
+// WriteTarStreamHead writes a tar stream of the first files in fs to w,
+// stopping once maxFiles files or maxBytes bytes of content have been written
+// (a non-positive limit means unlimited). The file that reaches the byte limit
+// is truncated to it. The iteration stops at the limits, so the rest of fs is
+// not read, and the archive is terminated as usual.
+func WriteTarStreamHead(ctx context.Context, w io.Writer, fs FileSet, maxFiles int, maxBytes int64) error {
+	var numFiles int
+	var numBytes int64
 	if err := fs.Iterate(ctx, func(f File) error {
+		size := index.SizeBytes(f.Index())
+		if maxBytes > 0 && numBytes+size > maxBytes {
+			size = maxBytes - numBytes
+		}
+		if err := writeTarEntryHead(w, f, size); err != nil {
+			return err
+		}
+		numFiles++
+		numBytes += size
+		if (maxFiles > 0 && numFiles >= maxFiles) || (maxBytes > 0 && numBytes >= maxBytes) {
+			return errutil.ErrBreak
+		}
+		return nil
+	}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+		return err
+	}
+	return tar.NewWriter(w).Close()
+}
+
+// writeTarEntryHead writes a tar entry for f with the first size bytes of its
+// content.
+func writeTarEntryHead(w io.Writer, f File, size int64) error {
+	tw := tar.NewWriter(w)
+	if err := tw.WriteHeader(tarutil.NewHeader(f.Index().Path, size)); err != nil {
+		return err
+	}
+	if err := f.Content(&headWriter{w: tw, n: size}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+		return err
+	}
+	return tw.Flush()
+}
+
+// headWriter writes the first n bytes written to it to w, and then returns
+// errutil.ErrBreak to stop the writes.
+type headWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (hw *headWriter) Write(data []byte) (int, error) {
+	if int64(len(data)) <= hw.n {
+		n, err := hw.w.Write(data)
+		hw.n -= int64(n)
+		return n, err
+	}
+	n, err := hw.w.Write(data[:hw.n])
+	hw.n -= int64(n)
+	if err != nil {
+		return n, err
+	}
+	return n, errutil.ErrBreak
+}
+
+// WriteTarStreamParts writes an entire tar stream split into parts, with the
+// writer for part i opened by open(i).
+// A new part is started when writing the next file would exceed maxBytes in
+// the current part, so a file is never split across parts and a part only
+// exceeds maxBytes if it contains a single file that does.
+// Only the last part is terminated, so concatenating the parts in order
+// yields a valid tar stream.
+func WriteTarStreamParts(ctx context.Context, fs FileSet, maxBytes int64, open func(i int) io.WriteCloser) (retErr error) {
+	var w io.WriteCloser
+	var numParts int
+	var partSize int64
+	defer func() {
+		if w != nil {
+			if err := w.Close(); retErr == nil {
+				retErr = err
+			}
+		}
+	}()
+	nextPart := func() error {
+		if w != nil {
+			prev := w
+			w = nil
+			if err := prev.Close(); err != nil {
+				return err
+			}
+		}
+		w = open(numParts)
+		numParts++
+		partSize = 0
+		return nil
+	}
+	if err := fs.Iterate(ctx, func(f File) error {
+		entrySize, err := tarEntrySize(f.Index())
+		if err != nil {
+			return err
+		}
+		if w == nil || (partSize > 0 && partSize+entrySize > maxBytes) {
+			if err := nextPart(); err != nil {
+				return err
+			}
+		}
+		partSize += entrySize
 		return WriteTarEntry(w, f)
 	}); err != nil {
 		return err
 	}
+	if w == nil {
+		if err := nextPart(); err != nil {
+			return err
+		}
+	}
 	return tar.NewWriter(w).Close()
 }
 
+// WriteManifest writes a manifest of fs to w, with a line for each file
+// containing its path, size, and content hash separated by tabs.
+// The content of each file is read to compute its hash, but is not written.
+// File modes and modification times are not stored in file sets, so they are
+// not included.
+func WriteManifest(ctx context.Context, fs FileSet, w io.Writer) error {
+	return fs.Iterate(ctx, func(f File) error {
+		idx := f.Index()
+		h, err := contentHash(f)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%v\t%v\t%v\n", idx.Path, index.SizeBytes(idx), hash.EncodeHash(h))
+		return err
+	})
+}
+
+// whiteoutPrefix is the prefix of the base name of an OCI image layer
+// whiteout file, which marks the path without the prefix as deleted.
+const whiteoutPrefix = ".wh."
+
+// WriteLayer writes the changes from base to fs to w as an OCI image layer
+// tar stream. The files that were added or modified in fs are written along
+// with their parent directories, and a whiteout file is written for each file
+// that was deleted. The whiteouts are written first, so that they only apply to
+// the lower layers. File modes and ownership are not stored in file sets, so
+// the entries are owned by root, with mode 0755 for directories and 0644 for
+// files.
+func WriteLayer(ctx context.Context, w io.Writer, base, fs FileSet) error {
+	tw := tar.NewWriter(w)
+	if err := Subtract(base, fs).Iterate(ctx, func(f File) error {
+		return tw.WriteHeader(layerHeader(whiteoutPath(f.Index().Path), 0))
+	}); err != nil {
+		return err
+	}
+	var prev string
+	if err := Subtract(fs, base, true).Iterate(ctx, func(f File) error {
+		idx := f.Index()
+		p := strings.TrimPrefix(idx.Path, "/")
+		// The paths are in lexicographical order, so the parent directories
+		// that have already been written are the ones shared with the
+		// previous path.
+		for i := 0; i < len(p)-1; i++ {
+			if p[i] == '/' && !strings.HasPrefix(prev, p[:i+1]) {
+				if err := tw.WriteHeader(layerHeader(p[:i+1], 0)); err != nil {
+					return err
+				}
+			}
+		}
+		prev = p
+		if IsDir(p) {
+			return tw.WriteHeader(layerHeader(p, 0))
+		}
+		if err := tw.WriteHeader(layerHeader(p, index.SizeBytes(idx))); err != nil {
+			return err
+		}
+		return f.Content(tw)
+	}); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// layerHeader returns the header of an OCI image layer entry, which is a
+// directory if p has a trailing slash.
+func layerHeader(p string, size int64) *tar.Header {
+	hdr := tarutil.NewHeader(p, size)
+	hdr.Typeflag = tar.TypeReg
+	hdr.Mode = 0644
+	if IsDir(p) {
+		hdr.Typeflag = tar.TypeDir
+		hdr.Mode = 0755
+	}
+	hdr.Uname, hdr.Gname = "root", "root"
+	return hdr
+}
+
+// whiteoutPath returns the path of the whiteout file for p.
+func whiteoutPath(p string) string {
+	dir, name := path.Split(strings.Trim(p, "/"))
+	return dir + whiteoutPrefix + name
+}
+
+// ErrChecksumMismatch is returned by ImportTarStream when the content of a
+// file does not match its manifest entry.
+var ErrChecksumMismatch = errors.Errorf("checksum mismatch")
+
+// ErrUnsupportedTarEntry is returned for a tar entry that can't be stored in a
+// file set. File sets only store the paths and content of regular files
+// (directories are implied by the paths), so a link, fifo or device would lose
+// its type and link target, and is rejected instead.
+var ErrUnsupportedTarEntry = errors.Errorf("unsupported tar entry")
+
+// CheckTarEntry returns ErrUnsupportedTarEntry, wrapped with the details of the
+// entry, if hdr is not a regular file or directory. Contiguous files are
+// regular files, and global PAX headers (which start the tar streams written
+// by git archive) are accepted, so that they can be skipped (see
+// SkipTarEntry).
+func CheckTarEntry(hdr *tar.Header) error {
+	switch hdr.Typeflag {
+	case tar.TypeReg, tar.TypeRegA, tar.TypeCont, tar.TypeDir, tar.TypeXGlobalHeader:
+		return nil
+	case tar.TypeSymlink:
+		return errors.Wrapf(ErrUnsupportedTarEntry, "tar entry %v is a symlink to %v, only regular files and directories are supported", hdr.Name, hdr.Linkname)
+	case tar.TypeLink:
+		return errors.Wrapf(ErrUnsupportedTarEntry, "tar entry %v is a hard link to %v, only regular files and directories are supported", hdr.Name, hdr.Linkname)
+	case tar.TypeFifo:
+		return errors.Wrapf(ErrUnsupportedTarEntry, "tar entry %v is a fifo, only regular files and directories are supported", hdr.Name)
+	case tar.TypeChar, tar.TypeBlock:
+		return errors.Wrapf(ErrUnsupportedTarEntry, "tar entry %v is a device, only regular files and directories are supported", hdr.Name)
+	default:
+		return errors.Wrapf(ErrUnsupportedTarEntry, "tar entry %v has unsupported type %q", hdr.Name, string(hdr.Typeflag))
+	}
+}
+
+// SkipTarEntry returns true if hdr is a tar entry without a file to store,
+// which is a directory (directories are implied by the file paths) or a
+// global PAX header.
+func SkipTarEntry(hdr *tar.Header) bool {
+	return hdr.Typeflag == tar.TypeDir || hdr.Typeflag == tar.TypeXGlobalHeader
+}
+
+// ImportTarStream writes the files in the tar stream r to w, with the
+// content of each file appended with tag.
+// The entries must be sorted by path, as they are in a stream written by
+// WriteTarStream. Directory entries and global PAX headers are skipped (see
+// SkipTarEntry). Entries with unsafe paths are rejected, as are
+// entries of types that file sets can't store (see CheckTarEntry).
+func ImportTarStream(ctx context.Context, r io.Reader, w *Writer, tag string, opts ...ImportOption) error {
+	config := &importConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	var manifest map[string]*manifestEntry
+	if config.manifest != nil {
+		var err error
+		manifest, err = readManifest(config.manifest)
+		if err != nil {
+			return err
+		}
+	}
+	tr := tar.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return checkManifestMissing(manifest)
+			}
+			return err
+		}
+		if err := CheckTarEntry(hdr); err != nil {
+			return err
+		}
+		if SkipTarEntry(hdr) {
+			continue
+		}
+		p, err := cleanTarPath(hdr.Name)
+		if err != nil {
+			return err
+		}
+		var content io.Reader = tr
+		if config.contentTypeCallback != nil {
+			br := bufio.NewReaderSize(tr, contentTypeSniffLen)
+			contentType, err := detectContentType(br)
+			if err != nil {
+				return err
+			}
+			if err := config.contentTypeCallback(p, contentType); err != nil {
+				return err
+			}
+			content = br
+		}
+		h := hash.New()
+		if manifest != nil {
+			content = io.TeeReader(content, h)
+		}
+		if err := w.Append(p, func(fw *FileWriter) error {
+			fw.Append(tag)
+			_, err := io.Copy(fw, content)
+			return err
+		}); err != nil {
+			return err
+		}
+		if manifest != nil {
+			if err := verifyManifestEntry(manifest, p, hdr.Size, h.Sum(nil)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+type manifestEntry struct {
+	size int64
+	hash string
+}
+
+// readManifest reads a manifest written by WriteManifest into a map from
+// file path to manifest entry.
+func readManifest(r io.Reader) (map[string]*manifestEntry, error) {
+	manifest := make(map[string]*manifestEntry)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// The path is split off from the end of the line, in case it
+		// contains tabs.
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			return nil, errors.Errorf("invalid manifest line: %q", line)
+		}
+		n := len(fields)
+		size, err := strconv.ParseInt(fields[n-2], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid size in manifest line %q", line)
+		}
+		manifest[strings.Join(fields[:n-2], "\t")] = &manifestEntry{
+			size: size,
+			hash: fields[n-1],
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// verifyManifestEntry checks the size and content hash of the imported file
+// at p against its entry in manifest, and removes the entry.
+func verifyManifestEntry(manifest map[string]*manifestEntry, p string, size int64, sum []byte) error {
+	entry, ok := manifest[p]
+	if !ok {
+		return errors.Errorf("file %v in the tar stream is not in the manifest", p)
+	}
+	delete(manifest, p)
+	if entry.size != size {
+		return errors.Wrapf(ErrChecksumMismatch, "file %v has size %v, but its manifest entry has size %v", p, size, entry.size)
+	}
+	if actual := hash.EncodeHash(sum); actual != entry.hash {
+		return errors.Wrapf(ErrChecksumMismatch, "file %v has hash %v, but its manifest entry has hash %v", p, actual, entry.hash)
+	}
+	return nil
+}
+
+// checkManifestMissing errors if there are entries remaining in manifest,
+// which are the files that were not in the tar stream.
+func checkManifestMissing(manifest map[string]*manifestEntry) error {
+	if len(manifest) == 0 {
+		return nil
+	}
+	var missing []string
+	for p := range manifest {
+		missing = append(missing, p)
+	}
+	sort.Strings(missing)
+	return errors.Errorf("files in the manifest are missing from the tar stream: %v", missing)
+}
+
+// detectContentType detects the content type of a file from the first
+// contentTypeSniffLen bytes in br, without consuming them.
+func detectContentType(br *bufio.Reader) (string, error) {
+	data, err := br.Peek(contentTypeSniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return http.DetectContentType(data), nil
+}
+
+// cleanTarPath converts a tar entry name into a clean file set path, and
+// errors if the name could refer to a path outside of the file set.
+func cleanTarPath(name string) (string, error) {
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." {
+			return "", errors.Errorf("unsafe path (%v) in tar stream", name)
+		}
+	}
+	p := Clean(path.Clean("/"+name), false)
+	if p == "/" {
+		return "", errors.Errorf("invalid path (%v) in tar stream", name)
+	}
+	return p, nil
+}
+
+// Equal checks if two file sets have the same files, with the same sizes
+// and content. If they do not, a description of the first difference is
+// returned. Both file sets are iterated once in lexicographical order.
+func Equal(ctx context.Context, a, b FileSet) (bool, string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var diff string
+	bIter := NewIterator(ctx, b)
+	if err := a.Iterate(ctx, func(fa File) error {
+		fb, err := bIter.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				diff = fmt.Sprintf("%v is only in the first file set", fa.Index().Path)
+				return errutil.ErrBreak
+			}
+			return err
+		}
+		var same bool
+		same, diff, err = compareFiles(fa, fb)
+		if err != nil {
+			return err
+		}
+		if !same {
+			return errutil.ErrBreak
+		}
+		return nil
+	}); err != nil && !errors.Is(err, errutil.ErrBreak) {
+		return false, "", err
+	}
+	if diff != "" {
+		return false, diff, nil
+	}
+	fb, err := bIter.Next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return true, "", nil
+		}
+		return false, "", err
+	}
+	return false, fmt.Sprintf("%v is only in the second file set", fb.Index().Path), nil
+}
+
+// compareFiles compares files at the same position in two file sets, and
+// describes the difference if they are not the same.
+func compareFiles(fa, fb File) (bool, string, error) {
+	pa, pb := fa.Index().Path, fb.Index().Path
+	if pa < pb {
+		return false, fmt.Sprintf("%v is only in the first file set", pa), nil
+	}
+	if pa > pb {
+		return false, fmt.Sprintf("%v is only in the second file set", pb), nil
+	}
+	sa, sb := index.SizeBytes(fa.Index()), index.SizeBytes(fb.Index())
+	if sa != sb {
+		return false, fmt.Sprintf("%v has size %v in the first file set and %v in the second", pa, sa, sb), nil
+	}
+	same, err := sameContent(fa, fb)
+	if err != nil {
+		return false, "", err
+	}
+	if !same {
+		return false, fmt.Sprintf("%v has different content", pa), nil
+	}
+	return true, "", nil
+}
+
+// ValidateOrdering checks that the paths in a file set are clean and strictly
+// increasing (so there are no duplicates), which catches writer bugs that the
+// structural checks miss. The returned error names the first offending path
+// and the path before it.
+func ValidateOrdering(ctx context.Context, fs FileSet) error {
+	var prev string
+	var seen bool
+	return fs.Iterate(ctx, func(f File) error {
+		p := f.Index().Path
+		if !IsClean(p, IsDir(p)) {
+			return errors.Errorf("path %v is not clean (previous path: %v)", p, prev)
+		}
+		if seen {
+			if p == prev {
+				return errors.Errorf("path %v is a duplicate of the previous path", p)
+			}
+			if p < prev {
+				return errors.Errorf("path %v is out of order (previous path: %v)", p, prev)
+			}
+		}
+		prev, seen = p, true
+		return nil
+	})
+}
+
+// tarEntrySize returns the number of bytes written by WriteTarEntry for idx.
+func tarEntrySize(idx *index.Index) (int64, error) {
+	buf := &bytes.Buffer{}
+	size := index.SizeBytes(idx)
+	if err := tar.NewWriter(buf).WriteHeader(tarutil.NewHeader(idx.Path, size)); err != nil {
+		return 0, err
+	}
+	// The content is padded to a multiple of the tar block size.
+	return int64(buf.Len()) + (size+tarBlockSize-1)/tarBlockSize*tarBlockSize, nil
+}
+
 // Clean cleans a file path.
 func Clean(x string, isDir bool) string {
 	y := "/" + strings.Trim(x, "/")
@@ -94,33 +764,174 @@ func IsDir(p string) bool {
 	return strings.HasSuffix(p, "/")
 }
 
+// iteratorBatchSize is the number of files that an iterator (without
+// prefetching) receives from the file set at a time.
+const iteratorBatchSize = 64
+
+// defaultPrefetchBytes is the default limit on the number of bytes of file
+// content that an iterator (with prefetching) buffers ahead of Next.
+const defaultPrefetchBytes = 64 * units.MB
+
 // Iterator provides functionality for imperative iteration over a file set.
 type Iterator struct {
-	peek     File
-	fileChan chan File
+	peek File
+	// buf contains the files that have been received from the file set, but
+	// not returned yet.
+	buf      []File
+	fileChan chan []File
 	errChan  chan error
+	deletive bool
+	prefetch int
+	// prefetchBytes is the limit on the number of bytes of prefetched content
+	// that are buffered at a time.
+	prefetchBytes int64
+	// fileBudget is how long the iterator waits for the next file before the
+	// iteration is truncated (zero means no limit).
+	fileBudget time.Duration
+	truncated  bool
+	cancel     context.CancelFunc
 }
 
 // NewIterator creates a new iterator.
-func NewIterator(ctx context.Context, fs FileSet, deletive ...bool) *Iterator {
-	fileChan := make(chan File)
-	errChan := make(chan error, 1)
+func NewIterator(ctx context.Context, fs FileSet, opts ...IteratorOption) *Iterator {
+	i := &Iterator{
+		fileChan:      make(chan []File),
+		errChan:       make(chan error, 1),
+		prefetchBytes: defaultPrefetchBytes,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	if i.fileBudget > 0 {
+		ctx, i.cancel = context.WithCancel(ctx)
+	}
 	go func() {
-		if err := fs.Iterate(ctx, func(f File) error {
-			fileChan <- f
-			return nil
-		}, deletive...); err != nil {
-			errChan <- err
+		iterate := i.iterate
+		if i.prefetch > 0 {
+			iterate = i.iteratePrefetch
+		}
+		if err := iterate(ctx, fs); err != nil {
+			i.errChan <- err
 			return
 		}
-		close(fileChan)
+		close(i.fileChan)
 	}()
-	return &Iterator{
-		fileChan: fileChan,
-		errChan:  errChan,
+	return i
+}
+
+func (i *Iterator) iterate(ctx context.Context, fs FileSet) error {
+	batchSize := iteratorBatchSize
+	if i.fileBudget > 0 {
+		batchSize = 1
+	}
+	var batch []File
+	if err := fs.Iterate(ctx, func(f File) error {
+		batch = append(batch, f)
+		if len(batch) < batchSize {
+			return nil
+		}
+		err := i.send(ctx, batch)
+		batch = nil
+		return err
+	}, i.deletive); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return i.send(ctx, batch)
+	}
+	return nil
+}
+
+// iteratePrefetch iterates over the file set while the content of the
+// upcoming files is fetched concurrently. At most i.prefetch files are
+// fetched ahead of the file returned by Next, and the files are returned in
+// order.
+func (i *Iterator) iteratePrefetch(ctx context.Context, fs FileSet) error {
+	files := make(chan *prefetchedFile, i.prefetch-1)
+	sem := semaphore.NewWeighted(i.prefetchBytes)
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		defer close(files)
+		return fs.Iterate(ctx, func(f File) error {
+			pf := &prefetchedFile{File: f}
+			// Files larger than the limit are not prefetched, their content
+			// is streamed when it is read.
+			size := index.SizeBytes(f.Index())
+			if size <= i.prefetchBytes {
+				if err := sem.Acquire(ctx, size); err != nil {
+					return err
+				}
+				pf.size = size
+				pf.done = make(chan struct{})
+			}
+			select {
+			case files <- pf:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if pf.done != nil {
+				go pf.fetch()
+			}
+			return nil
+		}, i.deletive)
+	})
+	eg.Go(func() error {
+		for pf := range files {
+			if pf.done == nil {
+				if err := i.send(ctx, []File{pf.File}); err != nil {
+					return err
+				}
+				continue
+			}
+			select {
+			case <-pf.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if err := i.send(ctx, []File{pf}); err != nil {
+				return err
+			}
+			sem.Release(pf.size)
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+func (i *Iterator) send(ctx context.Context, files []File) error {
+	select {
+	case i.fileChan <- files:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
+// prefetchedFile is a file with its content fetched ahead of the iterator.
+type prefetchedFile struct {
+	File
+	size    int64
+	done    chan struct{}
+	content []byte
+	err     error
+}
+
+func (pf *prefetchedFile) fetch() {
+	defer close(pf.done)
+	buf := &bytes.Buffer{}
+	pf.err = pf.File.Content(buf)
+	pf.content = buf.Bytes()
+}
+
+// Content writes the prefetched content of the file.
+func (pf *prefetchedFile) Content(w io.Writer) error {
+	if pf.err != nil {
+		return pf.err
+	}
+	_, err := w.Write(pf.content)
+	return err
+}
+
 // Peek returns the next file without progressing the iterator.
 func (i *Iterator) Peek() (File, error) {
 	if i.peek != nil {
@@ -138,15 +949,108 @@ func (i *Iterator) Next() (File, error) {
 		i.peek = nil
 		return tmp, nil
 	}
+	if len(i.buf) == 0 {
+		if err := i.receive(); err != nil {
+			return nil, err
+		}
+	}
+	file := i.buf[0]
+	i.buf = i.buf[1:]
+	return file, nil
+}
+
+// NextN returns the next n files and progresses the iterator past them. It
+// returns fewer than n files if the iterator is exhausted before n files are
+// returned, and io.EOF once there are no files left.
+func (i *Iterator) NextN(n int) ([]File, error) {
+	var files []File
+	if i.peek != nil && n > 0 {
+		files = append(files, i.peek)
+		i.peek = nil
+	}
+	for len(files) < n {
+		if len(i.buf) == 0 {
+			if err := i.receive(); err != nil {
+				if errors.Is(err, io.EOF) && len(files) > 0 {
+					return files, nil
+				}
+				return nil, err
+			}
+		}
+		k := n - len(files)
+		if k > len(i.buf) {
+			k = len(i.buf)
+		}
+		files = append(files, i.buf[:k]...)
+		i.buf = i.buf[k:]
+	}
+	return files, nil
+}
+
+// Truncated returns true if the iteration was truncated because receiving a
+// file took longer than the iterator's file budget.
+func (i *Iterator) Truncated() bool {
+	return i.truncated
+}
+
+// receive waits for the next batch of files from the file set.
+func (i *Iterator) receive() error {
+	if i.truncated {
+		return io.EOF
+	}
+	var timeout <-chan time.Time
+	if i.fileBudget > 0 {
+		timer := time.NewTimer(i.fileBudget)
+		defer timer.Stop()
+		timeout = timer.C
+	}
 	select {
-	case file, more := <-i.fileChan:
+	case files, more := <-i.fileChan:
 		if !more {
-			return nil, io.EOF
+			return io.EOF
 		}
-		return file, nil
+		i.buf = files
+		return nil
 	case err := <-i.errChan:
-		return nil, err
+		return err
+	case <-timeout:
+		// Stop the iteration of the file set.
+		i.truncated = true
+		i.cancel()
+		return io.EOF
+	}
+}
+
+// IterateWithFileBudget iterates over the files in a file set, like Iterate,
+// except that the iteration stops if receiving the next file from the file
+// set takes longer than budget (the time spent in cb doesn't count). It
+// returns true if the iteration was truncated, in which case cb was called
+// with a prefix of the files.
+func IterateWithFileBudget(ctx context.Context, fs FileSet, budget time.Duration, cb func(File) error, opts ...IteratorOption) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	iter := NewIterator(ctx, fs, append(opts, WithFileBudget(budget))...)
+	for {
+		f, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return iter.Truncated(), nil
+			}
+			return false, err
+		}
+		if err := cb(f); err != nil {
+			return false, err
+		}
+	}
+}
+
+// fileDataRefs returns the data refs of a file, which are the file's data
+// refs if they are resolved, and its parts' data refs otherwise.
+func fileDataRefs(idx *index.Index) []*chunk.DataRef {
+	if idx.File.DataRefs != nil {
+		return idx.File.DataRefs
 	}
+	return getDataRefs(idx.File.Parts)
 }
 
 func getDataRefs(parts []*index.Part) []*chunk.DataRef {
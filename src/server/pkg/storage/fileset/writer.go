@@ -41,6 +41,7 @@ type Writer struct {
 	ctx                context.Context
 	tracker            track.Tracker
 	store              Store
+	chunks             *chunk.Storage
 	path               string
 	additive, deletive *index.Writer
 	sizeBytes          int64
@@ -51,6 +52,7 @@ type Writer struct {
 	noUpload           bool
 	indexFunc          func(*index.Index) error
 	ttl                time.Duration
+	chunkWriterOpts    []chunk.WriterOption
 }
 
 func newWriter(ctx context.Context, store Store, tracker track.Tracker, chunks *chunk.Storage, path string, opts ...WriterOption) *Writer {
@@ -59,12 +61,13 @@ func newWriter(ctx context.Context, store Store, tracker track.Tracker, chunks *
 		ctx:     ctx,
 		store:   store,
 		tracker: tracker,
+		chunks:  chunks,
 		path:    path,
 	}
 	for _, opt := range opts {
 		opt(w)
 	}
-	var chunkWriterOpts []chunk.WriterOption
+	chunkWriterOpts := w.chunkWriterOpts
 	if w.noUpload {
 		chunkWriterOpts = append(chunkWriterOpts, chunk.WithNoUpload())
 	}
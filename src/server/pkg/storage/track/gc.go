@@ -62,6 +62,12 @@ func (gc *GarbageCollector) Run(ctx context.Context) error {
 	}
 }
 
+// RunCycle runs a single garbage collection cycle, deleting objects until
+// there are none left to delete.
+func (gc *GarbageCollector) RunCycle(ctx context.Context) error {
+	return gc.runUntilEmpty(ctx)
+}
+
 func (gc *GarbageCollector) runUntilEmpty(ctx context.Context) error {
 	for {
 		n, err := gc.runOnce(ctx)
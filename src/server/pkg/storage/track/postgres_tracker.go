@@ -71,13 +71,43 @@ func (t *postgresTracker) SetTTLPrefix(ctx context.Context, prefix string, ttl t
 		`UPDATE storage.tracker_objects
 		SET expires_at = CURRENT_TIMESTAMP + $2 * interval '1 microsecond'
 		WHERE str_id LIKE $1 || '%'
+		AND NOT tombstone
+		AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
 		RETURNING expires_at`, prefix, ttl.Microseconds())
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, ErrExpired
+		}
 		return time.Time{}, err
 	}
 	return expiresAt, nil
 }
 
+func (t *postgresTracker) ExtendTTLPrefix(ctx context.Context, prefix string, ttl time.Duration) error {
+	var n int
+	if err := t.db.GetContext(ctx, &n,
+		`WITH live AS (
+			SELECT int_id, expires_at FROM storage.tracker_objects
+			WHERE str_id LIKE $1 || '%'
+			AND NOT tombstone
+			AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		), extended AS (
+			UPDATE storage.tracker_objects
+			SET expires_at = CURRENT_TIMESTAMP + $2 * interval '1 microsecond'
+			WHERE int_id IN (
+				SELECT int_id FROM live
+				WHERE expires_at < CURRENT_TIMESTAMP + $2 * interval '1 microsecond'
+			)
+		)
+		SELECT count(*) FROM live`, prefix, ttl.Microseconds()); err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrExpired
+	}
+	return nil
+}
+
 func (t *postgresTracker) GetDownstream(ctx context.Context, id string) ([]string, error) {
 	dwn := []string{}
 	if err := t.db.SelectContext(ctx, &dwn,
@@ -21,6 +21,8 @@ var (
 	ErrNotTombstone = errors.Errorf("object cannot be deleted because it is not marked as a tombstone")
 	// ErrSelfReference object cannot reference itself
 	ErrSelfReference = errors.Errorf("object cannot reference itself")
+	// ErrExpired no live object exists because it expired or was never created
+	ErrExpired = errors.Errorf("object does not exist or has expired")
 )
 
 // Tracker tracks objects and their references to one another.
@@ -34,8 +36,16 @@ type Tracker interface {
 	CreateObject(ctx context.Context, id string, pointsTo []string, ttl time.Duration) error
 
 	// SetTTLPrefix sets the expiration time to current_time + ttl for all objects with ids starting with prefix
+	// Objects that have expired or are marked as tombstones are not renewed.
+	// It errors with ErrExpired if there are no objects to renew.
 	SetTTLPrefix(ctx context.Context, prefix string, ttl time.Duration) (time.Time, error)
 
+	// ExtendTTLPrefix extends the expiration time to at least current_time + ttl for all objects with ids
+	// starting with prefix. Expiration times are never shortened, and objects without an expiration time are
+	// left without one. Objects that have expired or are marked as tombstones are not renewed.
+	// It errors with ErrExpired if there are no objects to renew.
+	ExtendTTLPrefix(ctx context.Context, prefix string, ttl time.Duration) error
+
 	// TODO: thoughts on these?
 	// SetTTL(ctx context.Context, id string, ttl time.Duration) error
 	// SetTTLBatch(ctx context.Context, ids []string, ttl time.Duration) error
@@ -120,6 +130,44 @@ func TestTracker(t *testing.T, newTracker func(testing.TB) Tracker) {
 				require.Nil(t, tracker.FinishDelete(ctx, id))
 			},
 		},
+		{
+			"SetTTLExpired",
+			func(t *testing.T, tracker Tracker) {
+				require.Nil(t, tracker.CreateObject(ctx, "keep", []string{}, time.Hour))
+				require.Nil(t, tracker.CreateObject(ctx, "expire", []string{}, time.Microsecond))
+				time.Sleep(time.Millisecond)
+
+				_, err := tracker.SetTTLPrefix(ctx, "keep", time.Hour)
+				require.Nil(t, err)
+				_, err = tracker.SetTTLPrefix(ctx, "expire", time.Hour)
+				require.Equal(t, ErrExpired, err)
+				_, err = tracker.SetTTLPrefix(ctx, "none", time.Hour)
+				require.Equal(t, ErrExpired, err)
+			},
+		},
+		{
+			"ExtendTTL",
+			func(t *testing.T, tracker Tracker) {
+				require.Nil(t, tracker.CreateObject(ctx, "touched", []string{}, time.Second))
+				require.Nil(t, tracker.CreateObject(ctx, "long", []string{}, time.Hour))
+				require.Nil(t, tracker.CreateObject(ctx, "forever", []string{}, 0))
+				require.Nil(t, tracker.CreateObject(ctx, "expire", []string{}, time.Microsecond))
+				require.Nil(t, tracker.ExtendTTLPrefix(ctx, "touched", time.Hour))
+				// Extending never shortens a lease, or gives an object
+				// without one an expiration.
+				require.Nil(t, tracker.ExtendTTLPrefix(ctx, "long", time.Microsecond))
+				require.Nil(t, tracker.ExtendTTLPrefix(ctx, "forever", time.Microsecond))
+				time.Sleep(time.Millisecond)
+				require.Equal(t, ErrExpired, tracker.ExtendTTLPrefix(ctx, "expire", time.Hour))
+				require.Equal(t, ErrExpired, tracker.ExtendTTLPrefix(ctx, "none", time.Hour))
+				var deletable []string
+				require.Nil(t, tracker.IterateDeletable(ctx, func(id string) error {
+					deletable = append(deletable, id)
+					return nil
+				}))
+				require.ElementsEqual(t, []string{"expire"}, deletable)
+			},
+		},
 		{
 			"ExpireSingleObject",
 			func(t *testing.T, tracker Tracker) {
@@ -348,16 +348,28 @@ func (api *authServerAPI) RestoreAuthToken(ctx context.Context, req *auth.Restor
 type activateEnterpriseFunc func(context.Context, *enterprise.ActivateRequest) (*enterprise.ActivateResponse, error)
 type getStateFunc func(context.Context, *enterprise.GetStateRequest) (*enterprise.GetStateResponse, error)
 type getActivationCodeFunc func(context.Context, *enterprise.GetActivationCodeRequest) (*enterprise.GetActivationCodeResponse, error)
+type stageActivationCodeFunc func(context.Context, *enterprise.StageActivationCodeRequest) (*enterprise.StageActivationCodeResponse, error)
+type exportStateFunc func(context.Context, *enterprise.ExportStateRequest) (*enterprise.ExportStateResponse, error)
+type importStateFunc func(context.Context, *enterprise.ImportStateRequest) (*enterprise.ImportStateResponse, error)
+type decodeActivationCodeFunc func(context.Context, *enterprise.DecodeActivationCodeRequest) (*enterprise.DecodeActivationCodeResponse, error)
 type deactivateEnterpriseFunc func(context.Context, *enterprise.DeactivateRequest) (*enterprise.DeactivateResponse, error)
 
 type mockActivateEnterprise struct{ handler activateEnterpriseFunc }
 type mockGetState struct{ handler getStateFunc }
 type mockGetActivationCode struct{ handler getActivationCodeFunc }
+type mockStageActivationCode struct{ handler stageActivationCodeFunc }
+type mockExportState struct{ handler exportStateFunc }
+type mockImportState struct{ handler importStateFunc }
+type mockDecodeActivationCode struct{ handler decodeActivationCodeFunc }
 type mockDeactivateEnterprise struct{ handler deactivateEnterpriseFunc }
 
 func (mock *mockActivateEnterprise) Use(cb activateEnterpriseFunc)     { mock.handler = cb }
 func (mock *mockGetState) Use(cb getStateFunc)                         { mock.handler = cb }
 func (mock *mockGetActivationCode) Use(cb getActivationCodeFunc)       { mock.handler = cb }
+func (mock *mockStageActivationCode) Use(cb stageActivationCodeFunc)   { mock.handler = cb }
+func (mock *mockExportState) Use(cb exportStateFunc)                   { mock.handler = cb }
+func (mock *mockImportState) Use(cb importStateFunc)                   { mock.handler = cb }
+func (mock *mockDecodeActivationCode) Use(cb decodeActivationCodeFunc) { mock.handler = cb }
 func (mock *mockDeactivateEnterprise) Use(cb deactivateEnterpriseFunc) { mock.handler = cb }
 
 type enterpriseServerAPI struct {
@@ -365,11 +377,15 @@ type enterpriseServerAPI struct {
 }
 
 type mockEnterpriseServer struct {
-	api               enterpriseServerAPI
-	Activate          mockActivateEnterprise
-	GetState          mockGetState
-	GetActivationCode mockGetActivationCode
-	Deactivate        mockDeactivateEnterprise
+	api                  enterpriseServerAPI
+	Activate             mockActivateEnterprise
+	GetState             mockGetState
+	GetActivationCode    mockGetActivationCode
+	StageActivationCode  mockStageActivationCode
+	ExportState          mockExportState
+	ImportState          mockImportState
+	DecodeActivationCode mockDecodeActivationCode
+	Deactivate           mockDeactivateEnterprise
 }
 
 func (api *enterpriseServerAPI) Activate(ctx context.Context, req *enterprise.ActivateRequest) (*enterprise.ActivateResponse, error) {
@@ -390,6 +406,30 @@ func (api *enterpriseServerAPI) GetActivationCode(ctx context.Context, req *ente
 	}
 	return nil, errors.Errorf("unhandled pachd mock enterprise.GetActivationCode")
 }
+func (api *enterpriseServerAPI) StageActivationCode(ctx context.Context, req *enterprise.StageActivationCodeRequest) (*enterprise.StageActivationCodeResponse, error) {
+	if api.mock.StageActivationCode.handler != nil {
+		return api.mock.StageActivationCode.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock enterprise.StageActivationCode")
+}
+func (api *enterpriseServerAPI) ExportState(ctx context.Context, req *enterprise.ExportStateRequest) (*enterprise.ExportStateResponse, error) {
+	if api.mock.ExportState.handler != nil {
+		return api.mock.ExportState.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock enterprise.ExportState")
+}
+func (api *enterpriseServerAPI) ImportState(ctx context.Context, req *enterprise.ImportStateRequest) (*enterprise.ImportStateResponse, error) {
+	if api.mock.ImportState.handler != nil {
+		return api.mock.ImportState.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock enterprise.ImportState")
+}
+func (api *enterpriseServerAPI) DecodeActivationCode(ctx context.Context, req *enterprise.DecodeActivationCodeRequest) (*enterprise.DecodeActivationCodeResponse, error) {
+	if api.mock.DecodeActivationCode.handler != nil {
+		return api.mock.DecodeActivationCode.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock enterprise.DecodeActivationCode")
+}
 func (api *enterpriseServerAPI) Deactivate(ctx context.Context, req *enterprise.DeactivateRequest) (*enterprise.DeactivateResponse, error) {
 	if api.mock.Deactivate.handler != nil {
 		return api.mock.Deactivate.handler(ctx, req)
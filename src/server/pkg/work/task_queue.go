@@ -38,11 +38,17 @@ func (te *taskEntry) runSubtask(subtask subtaskFunc) {
 
 // runSubtaskBlock is similar to runSubtask, but blocks on the subtask.
 func (te *taskEntry) runSubtaskBlock(subtask subtaskBlockFunc) error {
-	errChan := make(chan error)
+	errChan := make(chan error, 1)
 	te.runSubtask(func(ctx context.Context) {
 		errChan <- subtask(ctx)
 	})
-	return <-errChan
+	// The subtask is not run if the context is done before it is received.
+	select {
+	case err := <-errChan:
+		return err
+	case <-te.ctx.Done():
+		return te.ctx.Err()
+	}
 }
 
 // The task queue data structure is an ordered map that stores task entries.
@@ -55,11 +61,13 @@ type taskQueue struct {
 	tasks                  *ordered_map.OrderedMap
 	mu                     sync.Mutex
 	tasksDeletedSinceRemap int
+	done                   chan struct{}
 }
 
 func newTaskQueue(ctx context.Context) *taskQueue {
 	tq := &taskQueue{
 		tasks: ordered_map.NewOrderedMap(),
+		done:  make(chan struct{}),
 	}
 	// The next subtask to process is determined by iterating through the ordered map and checking the
 	// subtask function channel for each task entry to see if the next subtask is ready to be processed.
@@ -67,6 +75,7 @@ func newTaskQueue(ctx context.Context) *taskQueue {
 	// After processing a subtask, the iteration starts from the beginning (new subtasks from earlier
 	// tasks should be processed first).
 	go func() {
+		defer close(tq.done)
 	NextSubtask:
 		for {
 			select {
@@ -93,6 +102,13 @@ func newTaskQueue(ctx context.Context) *taskQueue {
 	return tq
 }
 
+// wait waits for the task queue to stop processing subtasks.
+// The task queue stops after its context is canceled and the subtask
+// being processed (if any) returns.
+func (tq *taskQueue) wait() {
+	<-tq.done
+}
+
 // runTask runs a new task in the task queue.
 // The task code should be contained within the passed in callback.
 // The callback will receive a taskEntry, which should be used for running subtasks in the task queue.
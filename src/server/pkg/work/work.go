@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"path"
 	"sync/atomic"
+	"time"
 
 	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
@@ -17,9 +19,20 @@ import (
 )
 
 const (
-	taskPrefix    = "/task"
-	subtaskPrefix = "/subtask"
-	claimPrefix   = "/claim"
+	taskPrefix      = "/task"
+	subtaskPrefix   = "/subtask"
+	claimPrefix     = "/claim"
+	heartbeatPrefix = "/heartbeat"
+)
+
+var (
+	// heartbeatInterval is how often a worker writes a heartbeat for the
+	// subtask it is processing, and how often a master checks the heartbeats.
+	heartbeatInterval = 2 * time.Second
+	// heartbeatTimeout is how long a master waits for a new heartbeat before
+	// it considers the worker processing a subtask dead, and releases its
+	// claim so the subtask can be picked up by another worker.
+	heartbeatTimeout = 10 * time.Second
 )
 
 // TaskQueue manages a set of parallel tasks, and provides an interface for running tasks.
@@ -31,8 +44,8 @@ type TaskQueue struct {
 }
 
 type taskEtcd struct {
-	etcdClient                    *etcd.Client
-	taskCol, subtaskCol, claimCol col.Collection
+	etcdClient                                  *etcd.Client
+	taskCol, subtaskCol, claimCol, heartbeatCol col.Collection
 }
 
 // NewTaskQueue sets up a new task queue.
@@ -53,10 +66,11 @@ func NewTaskQueue(ctx context.Context, etcdClient *etcd.Client, etcdPrefix strin
 
 func newTaskEtcd(etcdClient *etcd.Client, etcdPrefix string, taskNamespace string) *taskEtcd {
 	return &taskEtcd{
-		etcdClient: etcdClient,
-		taskCol:    newCollection(etcdClient, path.Join(etcdPrefix, taskPrefix, taskNamespace), &Task{}),
-		subtaskCol: newCollection(etcdClient, path.Join(etcdPrefix, subtaskPrefix, taskNamespace), &TaskInfo{}),
-		claimCol:   newCollection(etcdClient, path.Join(etcdPrefix, claimPrefix, taskNamespace), &Claim{}),
+		etcdClient:   etcdClient,
+		taskCol:      newCollection(etcdClient, path.Join(etcdPrefix, taskPrefix, taskNamespace), &Task{}),
+		subtaskCol:   newCollection(etcdClient, path.Join(etcdPrefix, subtaskPrefix, taskNamespace), &TaskInfo{}),
+		claimCol:     newCollection(etcdClient, path.Join(etcdPrefix, claimPrefix, taskNamespace), &Claim{}),
+		heartbeatCol: newCollection(etcdClient, path.Join(etcdPrefix, heartbeatPrefix, taskNamespace), &Heartbeat{}),
 	}
 }
 
@@ -76,7 +90,7 @@ func newCollection(etcdClient *etcd.Client, etcdPrefix string, template proto.Me
 // The callback will receive a Master, which should be used for running subtasks in the task queue.
 // The task state will be cleaned up upon return of the callback.
 func (tq *TaskQueue) RunTask(ctx context.Context, f func(*Master)) (retErr error) {
-	task := &Task{ID: uuid.NewWithoutDashes()}
+	task := &Task{ID: uuid.NewWithoutDashes(), Created: types.TimestampNow()}
 	if _, err := col.NewSTM(ctx, tq.etcdClient, func(stm col.STM) error {
 		return tq.taskCol.ReadWrite(stm).Put(task.ID, task)
 	}); err != nil {
@@ -126,6 +140,23 @@ func (m *Master) Ctx() context.Context {
 	return m.taskEntry.ctx
 }
 
+// WithTimeout returns a master for the same task with a context that is
+// canceled after timeout. Subtasks run through the returned master are
+// bounded by the timeout, and are cleaned up when it is exceeded.
+// The returned cancel function should be called when the master is no longer used.
+func (m *Master) WithTimeout(timeout time.Duration) (*Master, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(m.taskEntry.ctx, timeout)
+	return &Master{
+		taskEtcd: m.taskEtcd,
+		taskID:   m.taskID,
+		taskEntry: &taskEntry{
+			ctx:             ctx,
+			cancel:          cancel,
+			subtaskFuncChan: m.taskEntry.subtaskFuncChan,
+		},
+	}, cancel
+}
+
 // CollectFunc is a callback that is used for collecting the results
 // from a subtask that has been processed.
 type CollectFunc func(context.Context, *TaskInfo) error
@@ -159,6 +190,12 @@ func (m *Master) RunSubtasksChan(subtaskChan chan *Task, collectFunc CollectFunc
 	var count int64
 	done := make(chan struct{})
 	ctx, cancel := context.WithCancel(m.taskEntry.ctx)
+	monitorCtx, monitorCancel := context.WithCancel(ctx)
+	monitorDone := make(chan struct{})
+	go func() {
+		defer close(monitorDone)
+		m.monitorHeartbeats(monitorCtx)
+	}()
 	eg.Go(func() error {
 		return m.subtaskCol.ReadOnly(ctx).WatchOneF(m.taskID, func(e *watch.Event) error {
 			var key string
@@ -198,6 +235,8 @@ func (m *Master) RunSubtasksChan(subtaskChan chan *Task, collectFunc CollectFunc
 		if err := eg.Wait(); retErr == nil && !errors.Is(ctx.Err(), context.Canceled) {
 			retErr = err
 		}
+		monitorCancel()
+		<-monitorDone
 		if err := m.deleteSubtasks(); err != nil {
 			fmt.Printf("errored deleting subtasks for task %v: %v\n", m.taskID, err)
 		}
@@ -216,6 +255,7 @@ func (m *Master) createSubtask(subtask *Task) error {
 	if subtask.ID == "" {
 		subtask.ID = uuid.NewWithoutDashes()
 	}
+	subtask.Created = types.TimestampNow()
 	subtaskKey := path.Join(m.taskID, subtask.ID)
 	subtaskInfo := &TaskInfo{Task: subtask}
 	if _, err := col.NewSTM(m.taskEntry.ctx, m.etcdClient, func(stm col.STM) error {
@@ -226,9 +266,103 @@ func (m *Master) createSubtask(subtask *Task) error {
 	return nil
 }
 
+// heartbeatState is the last heartbeat that a master saw for a claim, and
+// when the master saw it. The local time is used for detecting dead workers,
+// so the clocks of the master and workers do not need to agree.
+type heartbeatState struct {
+	claimID string
+	time    *types.Timestamp
+	seen    time.Time
+}
+
+// monitorHeartbeats periodically releases the claims on the task's running
+// subtasks that have not had a new heartbeat within the heartbeat timeout.
+func (m *Master) monitorHeartbeats(ctx context.Context) {
+	states := make(map[string]*heartbeatState)
+	for {
+		select {
+		case <-time.After(heartbeatInterval):
+		case <-ctx.Done():
+			return
+		}
+		var err error
+		if states, err = m.expireDeadClaims(ctx, states); err != nil && ctx.Err() == nil {
+			fmt.Printf("errored checking heartbeats for task %v: %v\n", m.taskID, err)
+		}
+	}
+}
+
+func (m *Master) expireDeadClaims(ctx context.Context, states map[string]*heartbeatState) (map[string]*heartbeatState, error) {
+	newStates := make(map[string]*heartbeatState)
+	// expired maps the keys of the subtasks with expired claims to the claim IDs.
+	expired := make(map[string]string)
+	subtaskInfo := &TaskInfo{}
+	if err := m.subtaskCol.ReadOnly(ctx).ListPrefix(m.taskID, subtaskInfo, col.DefaultOptions, func(string) error {
+		if subtaskInfo.State != State_RUNNING {
+			return nil
+		}
+		subtaskKey := path.Join(m.taskID, subtaskInfo.Task.ID)
+		claim := &Claim{}
+		if err := m.claimCol.ReadOnly(ctx).Get(subtaskKey, claim); err != nil {
+			if col.IsErrNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		// Claims without an ID are held by workers that do not write heartbeats.
+		if claim.ID == "" {
+			return nil
+		}
+		heartbeat := &Heartbeat{}
+		if err := m.heartbeatCol.ReadOnly(ctx).Get(subtaskKey, heartbeat); err != nil && !col.IsErrNotFound(err) {
+			return err
+		}
+		var heartbeatTime *types.Timestamp
+		if heartbeat.ClaimID == claim.ID {
+			heartbeatTime = heartbeat.Time
+		}
+		state, ok := states[subtaskKey]
+		if !ok || state.claimID != claim.ID || !proto.Equal(state.time, heartbeatTime) {
+			state = &heartbeatState{
+				claimID: claim.ID,
+				time:    heartbeatTime,
+				seen:    time.Now(),
+			}
+		}
+		if time.Since(state.seen) >= heartbeatTimeout {
+			expired[subtaskKey] = state.claimID
+			return nil
+		}
+		newStates[subtaskKey] = state
+		return nil
+	}); err != nil {
+		return states, err
+	}
+	for subtaskKey, claimID := range expired {
+		if _, err := col.NewSTM(ctx, m.etcdClient, func(stm col.STM) error {
+			claim := &Claim{}
+			if err := m.claimCol.ReadWrite(stm).Get(subtaskKey, claim); err != nil {
+				return err
+			}
+			// The subtask was claimed again since the heartbeat was checked.
+			if claim.ID != claimID {
+				return nil
+			}
+			if err := m.heartbeatCol.ReadWrite(stm).Delete(subtaskKey); err != nil && !col.IsErrNotFound(err) {
+				return err
+			}
+			return m.claimCol.ReadWrite(stm).Delete(subtaskKey)
+		}); err != nil && !col.IsErrNotFound(err) {
+			return newStates, err
+		}
+	}
+	return newStates, nil
+}
+
 func (m *Master) deleteSubtasks() error {
 	_, err := col.NewSTM(context.Background(), m.etcdClient, func(stm col.STM) error {
 		m.subtaskCol.ReadWrite(stm).DeleteAllPrefix(m.taskID)
+		m.heartbeatCol.ReadWrite(stm).DeleteAllPrefix(m.taskID)
 		return nil
 	})
 	return err
@@ -237,6 +371,7 @@ func (m *Master) deleteSubtasks() error {
 func (tq *TaskQueue) deleteTask(taskID string) error {
 	_, err := col.NewSTM(context.Background(), tq.etcdClient, func(stm col.STM) error {
 		tq.subtaskCol.ReadWrite(stm).DeleteAllPrefix(taskID)
+		tq.heartbeatCol.ReadWrite(stm).DeleteAllPrefix(taskID)
 		return tq.taskCol.ReadWrite(stm).Delete(taskID)
 	})
 	return err
@@ -245,16 +380,55 @@ func (tq *TaskQueue) deleteTask(taskID string) error {
 func (tq *TaskQueue) deleteAllTasks() error {
 	_, err := col.NewSTM(context.Background(), tq.etcdClient, func(stm col.STM) error {
 		tq.subtaskCol.ReadWrite(stm).DeleteAll()
+		tq.heartbeatCol.ReadWrite(stm).DeleteAll()
 		tq.taskCol.ReadWrite(stm).DeleteAll()
 		return nil
 	})
 	return err
 }
 
+// TaskStatus describes an outstanding task and its subtasks.
+type TaskStatus struct {
+	Namespace string
+	Task      *Task
+	Subtasks  []*TaskInfo
+}
+
+// ListTasks calls cb with the status of each outstanding task under
+// etcdPrefix, across all of the task namespaces, in the order that the tasks
+// were created.
+func ListTasks(ctx context.Context, etcdClient *etcd.Client, etcdPrefix string, cb func(*TaskStatus) error) error {
+	taskCol := newCollection(etcdClient, path.Join(etcdPrefix, taskPrefix), &Task{})
+	task := &Task{}
+	opts := &col.Options{Target: etcd.SortByCreateRevision, Order: etcd.SortAscend}
+	return taskCol.ReadOnly(ctx).List(task, opts, func(key string) error {
+		// The task keys are the task namespace joined with the task ID.
+		namespace := path.Dir(key)
+		if namespace == "." {
+			namespace = ""
+		}
+		status := &TaskStatus{
+			Namespace: namespace,
+			Task:      proto.Clone(task).(*Task),
+		}
+		subtaskCol := newTaskEtcd(etcdClient, etcdPrefix, namespace).subtaskCol
+		subtaskInfo := &TaskInfo{}
+		if err := subtaskCol.ReadOnly(ctx).ListPrefix(path.Base(key), subtaskInfo, opts, func(string) error {
+			status.Subtasks = append(status.Subtasks, proto.Clone(subtaskInfo).(*TaskInfo))
+			return nil
+		}); err != nil {
+			return err
+		}
+		return cb(status)
+	})
+}
+
 // Worker is a worker that will process subtasks in a task.
 // A worker watches the task collection for tasks to be created / deleted and appropriately
 // runs / deletes tasks in the internal task queue with a function that watches the
 // subtask and claim collections for subtasks that need to be processed.
+// While a subtask is being processed, the worker periodically writes a heartbeat for it,
+// so that the master can release the claim on the subtask if the worker dies.
 // The processFunc callback will be called for each subtask that needs to be processed
 // in the task.
 type Worker struct {
@@ -271,8 +445,13 @@ type ProcessFunc func(context.Context, *Task) error
 
 // Run runs the worker with the given context.
 // The worker will continue to watch the task collection until the context is canceled.
+// When the context is canceled, the subtask being processed (if any) is
+// abandoned and Run returns after its processFunc returns.
 func (w *Worker) Run(ctx context.Context, processFunc ProcessFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
 	taskQueue := newTaskQueue(ctx)
+	defer taskQueue.wait()
+	defer cancel()
 	return w.taskCol.ReadOnly(ctx).WatchF(func(e *watch.Event) error {
 		var taskID string
 		task := &Task{}
@@ -346,12 +525,27 @@ func (w *Worker) subtaskFunc(subtaskKey string, processFunc ProcessFunc) subtask
 			if subtaskInfo.State != State_RUNNING {
 				return nil
 			}
-			return w.claimCol.Claim(ctx, subtaskKey, &Claim{}, func(claimCtx context.Context) (retErr error) {
+			claimID := uuid.NewWithoutDashes()
+			return w.claimCol.Claim(ctx, subtaskKey, &Claim{ID: claimID}, func(claimCtx context.Context) (retErr error) {
+				// The claim context is also canceled if the master releases the claim.
+				claimCtx, cancel := context.WithCancel(claimCtx)
+				defer cancel()
+				go w.heartbeat(claimCtx, cancel, subtaskKey, claimID)
 				subtask := subtaskInfo.Task
 				defer func() {
 					// If the task context was canceled or the claim was lost, just return with no error.
 					if errors.Is(claimCtx.Err(), context.Canceled) {
 						retErr = nil
+						// If the task context was canceled, release the claim so the subtask
+						// can be picked up by another worker without waiting for the claim
+						// to expire.
+						if ctx.Err() != nil {
+							if _, err := col.NewSTM(context.Background(), w.etcdClient, func(stm col.STM) error {
+								return w.claimCol.ReadWrite(stm).Delete(subtaskKey)
+							}); err != nil && !col.IsErrNotFound(err) {
+								fmt.Printf("errored releasing claim for subtask %v: %v\n", subtaskKey, err)
+							}
+						}
 						return
 					}
 					subtaskInfo := &TaskInfo{}
@@ -386,3 +580,37 @@ func (w *Worker) subtaskFunc(subtaskKey string, processFunc ProcessFunc) subtask
 		}
 	}
 }
+
+// heartbeat writes a heartbeat for a claimed subtask every heartbeat interval
+// until ctx is canceled. If the claim is lost, cancel is called.
+func (w *Worker) heartbeat(ctx context.Context, cancel context.CancelFunc, subtaskKey, claimID string) {
+	for {
+		if _, err := col.NewSTM(ctx, w.etcdClient, func(stm col.STM) error {
+			claim := &Claim{}
+			if err := w.claimCol.ReadWrite(stm).Get(subtaskKey, claim); err != nil {
+				return err
+			}
+			if claim.ID != claimID {
+				return col.ErrNotClaimed
+			}
+			return w.heartbeatCol.ReadWrite(stm).Put(subtaskKey, &Heartbeat{
+				ClaimID: claimID,
+				Time:    types.TimestampNow(),
+			})
+		}); err != nil {
+			if col.IsErrNotFound(err) || errors.Is(err, col.ErrNotClaimed) {
+				cancel()
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Printf("errored writing heartbeat for subtask %v: %v\n", subtaskKey, err)
+		}
+		select {
+		case <-time.After(heartbeatInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
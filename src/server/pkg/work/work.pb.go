@@ -53,11 +53,13 @@ func (State) EnumDescriptor() ([]byte, []int) {
 }
 
 type Task struct {
-	ID                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Data                 *types.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	ID   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data *types.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// created is when the task (or subtask) was put in etcd.
+	Created              *types.Timestamp `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *Task) Reset()         { *m = Task{} }
@@ -107,6 +109,13 @@ func (m *Task) GetData() *types.Any {
 	return nil
 }
 
+func (m *Task) GetCreated() *types.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
 type TaskInfo struct {
 	Task                 *Task    `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	State                State    `protobuf:"varint,2,opt,name=state,proto3,enum=work.State" json:"state,omitempty"`
@@ -171,6 +180,9 @@ func (m *TaskInfo) GetReason() string {
 }
 
 type Claim struct {
+	// id identifies the claim, so that a worker can tell whether it still
+	// holds the claim on a subtask.
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -209,6 +221,72 @@ func (m *Claim) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Claim proto.InternalMessageInfo
 
+func (m *Claim) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+// Heartbeat is written periodically by the worker that is processing a
+// subtask, so that the master can detect a worker that died silently.
+type Heartbeat struct {
+	// claim_id is the id of the claim that the worker holds on the subtask.
+	ClaimID string `protobuf:"bytes,1,opt,name=claim_id,json=claimId,proto3" json:"claim_id,omitempty"`
+	// time is when the heartbeat was written.
+	Time                 *types.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Heartbeat) Reset()         { *m = Heartbeat{} }
+func (m *Heartbeat) String() string { return proto.CompactTextString(m) }
+func (*Heartbeat) ProtoMessage()    {}
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_58a68e4647f78187, []int{3}
+}
+func (m *Heartbeat) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Heartbeat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Heartbeat.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Heartbeat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Heartbeat.Merge(m, src)
+}
+func (m *Heartbeat) XXX_Size() int {
+	return m.Size()
+}
+func (m *Heartbeat) XXX_DiscardUnknown() {
+	xxx_messageInfo_Heartbeat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Heartbeat proto.InternalMessageInfo
+
+func (m *Heartbeat) GetClaimID() string {
+	if m != nil {
+		return m.ClaimID
+	}
+	return ""
+}
+
+func (m *Heartbeat) GetTime() *types.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
 type TestData struct {
 	Processed            bool     `protobuf:"varint,1,opt,name=processed,proto3" json:"processed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -220,7 +298,7 @@ func (m *TestData) Reset()         { *m = TestData{} }
 func (m *TestData) String() string { return proto.CompactTextString(m) }
 func (*TestData) ProtoMessage()    {}
 func (*TestData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_58a68e4647f78187, []int{3}
+	return fileDescriptor_58a68e4647f78187, []int{4}
 }
 func (m *TestData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -261,34 +339,40 @@ func init() {
 	proto.RegisterType((*Task)(nil), "work.Task")
 	proto.RegisterType((*TaskInfo)(nil), "work.TaskInfo")
 	proto.RegisterType((*Claim)(nil), "work.Claim")
+	proto.RegisterType((*Heartbeat)(nil), "work.Heartbeat")
 	proto.RegisterType((*TestData)(nil), "work.TestData")
 }
 
 func init() { proto.RegisterFile("server/pkg/work/work.proto", fileDescriptor_58a68e4647f78187) }
 
 var fileDescriptor_58a68e4647f78187 = []byte{
-	// 332 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0xcb, 0x6a, 0xe3, 0x30,
-	0x14, 0x86, 0xc7, 0x1e, 0xe7, 0x76, 0x0c, 0x43, 0x10, 0x21, 0x64, 0xc2, 0xe0, 0x49, 0xbd, 0x32,
-	0x5d, 0xd8, 0xe0, 0xbe, 0x40, 0x73, 0x6b, 0x6b, 0x28, 0x59, 0xc8, 0xc9, 0xa6, 0x3b, 0xc5, 0x56,
-	0x1c, 0xe3, 0xc4, 0x32, 0x92, 0xd2, 0x92, 0x37, 0xec, 0xb2, 0x4f, 0x50, 0x8a, 0x9f, 0xa4, 0x48,
-	0x6e, 0x69, 0xe9, 0x46, 0xfc, 0x97, 0xc3, 0xc7, 0x91, 0x04, 0x63, 0x41, 0xf9, 0x23, 0xe5, 0x41,
-	0x55, 0x64, 0xc1, 0x13, 0xe3, 0x85, 0x3e, 0xfc, 0x8a, 0x33, 0xc9, 0x90, 0xa5, 0xf4, 0x78, 0x90,
-	0xb1, 0x8c, 0xe9, 0x20, 0x50, 0xaa, 0xe9, 0xc6, 0x7f, 0x33, 0xc6, 0xb2, 0x03, 0x0d, 0xb4, 0xdb,
-	0x9e, 0x76, 0x01, 0x29, 0xcf, 0x4d, 0xe5, 0xde, 0x81, 0xb5, 0x26, 0xa2, 0x40, 0x43, 0x30, 0xf3,
-	0x74, 0x64, 0x4c, 0x0c, 0xaf, 0x37, 0x6b, 0xd7, 0xaf, 0xff, 0xcd, 0x68, 0x81, 0xcd, 0x3c, 0x45,
-	0x1e, 0x58, 0x29, 0x91, 0x64, 0x64, 0x4e, 0x0c, 0xcf, 0x0e, 0x07, 0x7e, 0x43, 0xf2, 0x3f, 0x49,
-	0xfe, 0xb4, 0x3c, 0x63, 0x3d, 0xe1, 0x52, 0xe8, 0x2a, 0x52, 0x54, 0xee, 0x18, 0x72, 0xc0, 0x92,
-	0x44, 0x14, 0x9a, 0x67, 0x87, 0xe0, 0xeb, 0x3d, 0x55, 0x8b, 0x75, 0x8e, 0x2e, 0xa0, 0x25, 0x24,
-	0x91, 0x54, 0x63, 0xff, 0x84, 0x76, 0x33, 0x10, 0xab, 0x08, 0x37, 0x0d, 0x1a, 0x42, 0x9b, 0x53,
-	0x22, 0x58, 0x39, 0xfa, 0xad, 0x96, 0xc2, 0x1f, 0xce, 0xed, 0x40, 0x6b, 0x7e, 0x20, 0xf9, 0xd1,
-	0xf5, 0xa0, 0xbb, 0xa6, 0x42, 0x2e, 0x88, 0x24, 0xe8, 0x1f, 0xf4, 0x2a, 0xce, 0x12, 0x2a, 0x04,
-	0x6d, 0x2e, 0xd1, 0xc5, 0x5f, 0xc1, 0xa5, 0x0f, 0x2d, 0x8d, 0x46, 0x36, 0x74, 0xf0, 0x66, 0xb5,
-	0x8a, 0x56, 0xb7, 0xfd, 0x5f, 0xca, 0xc4, 0x9b, 0xf9, 0x7c, 0x19, 0xc7, 0x7d, 0x43, 0x99, 0x9b,
-	0x69, 0x74, 0xbf, 0xc1, 0xcb, 0xbe, 0x39, 0xbb, 0x7e, 0xae, 0x1d, 0xe3, 0xa5, 0x76, 0x8c, 0xb7,
-	0xda, 0x31, 0x1e, 0xc2, 0x2c, 0x97, 0xfb, 0xd3, 0xd6, 0x4f, 0xd8, 0x31, 0xa8, 0x48, 0xb2, 0x3f,
-	0xa7, 0x94, 0x7f, 0x57, 0x82, 0x27, 0xc1, 0x8f, 0x7f, 0xd9, 0xb6, 0xf5, 0xfb, 0x5c, 0xbd, 0x07,
-	0x00, 0x00, 0xff, 0xff, 0xec, 0xad, 0x45, 0x2a, 0xb1, 0x01, 0x00, 0x00,
+	// 410 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x8a, 0xd4, 0x40,
+	0x10, 0xc6, 0x4d, 0xcc, 0xfc, 0xab, 0x80, 0x0c, 0xcd, 0xb2, 0x8c, 0x41, 0x92, 0x35, 0x07, 0x09,
+	0x1e, 0x3a, 0x10, 0x7d, 0x00, 0x77, 0x67, 0x56, 0x0d, 0xc8, 0x1c, 0x3a, 0x33, 0x17, 0x2f, 0xd2,
+	0x49, 0x7a, 0xb3, 0x21, 0x9b, 0x74, 0xe8, 0xee, 0x55, 0xe6, 0xe2, 0xf3, 0x79, 0xf4, 0x09, 0x16,
+	0xc9, 0x93, 0x48, 0x77, 0x76, 0x54, 0x56, 0xc4, 0x4b, 0xa8, 0xaa, 0xef, 0x47, 0xd5, 0xf7, 0x35,
+	0x01, 0x4f, 0x32, 0xf1, 0x99, 0x89, 0xb8, 0x6f, 0xaa, 0xf8, 0x0b, 0x17, 0x8d, 0xf9, 0xe0, 0x5e,
+	0x70, 0xc5, 0x91, 0xa3, 0x6b, 0xef, 0xa4, 0xe2, 0x15, 0x37, 0x83, 0x58, 0x57, 0xa3, 0xe6, 0x3d,
+	0xad, 0x38, 0xaf, 0x6e, 0x58, 0x6c, 0xba, 0xfc, 0xf6, 0x2a, 0xa6, 0xdd, 0xe1, 0x5e, 0x0a, 0x1e,
+	0x4a, 0xaa, 0x6e, 0x99, 0x54, 0xb4, 0xed, 0x47, 0x20, 0xfc, 0x0a, 0xce, 0x8e, 0xca, 0x06, 0x9d,
+	0x82, 0x5d, 0x97, 0x2b, 0xeb, 0xcc, 0x8a, 0x16, 0x17, 0xd3, 0xe1, 0x2e, 0xb0, 0xd3, 0x0d, 0xb1,
+	0xeb, 0x12, 0x45, 0xe0, 0x94, 0x54, 0xd1, 0x95, 0x7d, 0x66, 0x45, 0x6e, 0x72, 0x82, 0xc7, 0x7d,
+	0xf8, 0xb8, 0x0f, 0x9f, 0x77, 0x07, 0x62, 0x08, 0xf4, 0x1a, 0x66, 0x85, 0x60, 0x54, 0xb1, 0x72,
+	0xf5, 0xd8, 0xc0, 0xde, 0x5f, 0xf0, 0xee, 0x78, 0x9c, 0x1c, 0xd1, 0x90, 0xc1, 0x5c, 0xdf, 0x4f,
+	0xbb, 0x2b, 0x8e, 0x7c, 0x70, 0x14, 0x95, 0x8d, 0x71, 0xe1, 0x26, 0x80, 0x4d, 0x7c, 0xad, 0x12,
+	0x33, 0x47, 0xcf, 0x61, 0x22, 0x15, 0x55, 0xcc, 0x98, 0x79, 0x92, 0xb8, 0x23, 0x90, 0xe9, 0x11,
+	0x19, 0x15, 0x74, 0x0a, 0x53, 0xc1, 0xa8, 0xe4, 0x9d, 0xf1, 0xb0, 0x20, 0xf7, 0x5d, 0x18, 0xc0,
+	0x64, 0x7d, 0x43, 0xeb, 0xf6, 0x5f, 0x39, 0xc3, 0x02, 0x16, 0xef, 0x19, 0x15, 0x2a, 0x67, 0x54,
+	0xa1, 0x17, 0x30, 0x2f, 0x34, 0xfd, 0xe9, 0x17, 0xea, 0x0e, 0x77, 0xc1, 0xcc, 0x6c, 0x48, 0x37,
+	0x64, 0x66, 0xc4, 0xb4, 0x44, 0x18, 0x1c, 0xfd, 0x9e, 0x2b, 0xfb, 0xbf, 0x79, 0x0d, 0x17, 0x46,
+	0x30, 0xdf, 0x31, 0xa9, 0x36, 0xfa, 0xb9, 0x9e, 0xc1, 0xa2, 0x17, 0xbc, 0x60, 0x52, 0xb2, 0xf1,
+	0xc8, 0x9c, 0xfc, 0x1e, 0xbc, 0xc4, 0x30, 0x31, 0xb9, 0x90, 0x0b, 0x33, 0xb2, 0xdf, 0x6e, 0xd3,
+	0xed, 0xbb, 0xe5, 0x23, 0xdd, 0x64, 0xfb, 0xf5, 0xfa, 0x32, 0xcb, 0x96, 0x96, 0x6e, 0xde, 0x9e,
+	0xa7, 0x1f, 0xf6, 0xe4, 0x72, 0x69, 0x5f, 0xbc, 0xf9, 0x36, 0xf8, 0xd6, 0xf7, 0xc1, 0xb7, 0x7e,
+	0x0c, 0xbe, 0xf5, 0x31, 0xa9, 0x6a, 0x75, 0x7d, 0x9b, 0xe3, 0x82, 0xb7, 0x71, 0x4f, 0x8b, 0xeb,
+	0x43, 0xc9, 0xc4, 0x9f, 0x95, 0x14, 0x45, 0xfc, 0xe0, 0x5f, 0xcb, 0xa7, 0xc6, 0xf5, 0xab, 0x9f,
+	0x03, 0x00, 0xca, 0x84, 0x07, 0x6d, 0x85, 0x02, 0x00, 0x00,
 }
 
 func (m *Task) Marshal() (dAtA []byte, err error) {
@@ -315,6 +399,18 @@ func (m *Task) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Created != nil {
+		{
+			size, err := m.Created.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWork(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Data != nil {
 		{
 			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
@@ -412,6 +508,59 @@ func (m *Claim) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintWork(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Heartbeat) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Heartbeat) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Heartbeat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Time != nil {
+		{
+			size, err := m.Time.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWork(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClaimID) > 0 {
+		i -= len(m.ClaimID)
+		copy(dAtA[i:], m.ClaimID)
+		i = encodeVarintWork(dAtA, i, uint64(len(m.ClaimID)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -477,6 +626,10 @@ func (m *Task) Size() (n int) {
 		l = m.Data.Size()
 		n += 1 + l + sovWork(uint64(l))
 	}
+	if m.Created != nil {
+		l = m.Created.Size()
+		n += 1 + l + sovWork(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -512,6 +665,30 @@ func (m *Claim) Size() (n int) {
 	}
 	var l int
 	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovWork(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Heartbeat) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClaimID)
+	if l > 0 {
+		n += 1 + l + sovWork(uint64(l))
+	}
+	if m.Time != nil {
+		l = m.Time.Size()
+		n += 1 + l + sovWork(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -636,6 +813,42 @@ func (m *Task) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &types.Timestamp{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWork(dAtA[iNdEx:])
@@ -825,6 +1038,157 @@ func (m *Claim) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: Claim: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWork
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWork(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWork
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Heartbeat) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWork
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Heartbeat: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Heartbeat: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClaimID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWork
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClaimID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Time == nil {
+				m.Time = &types.Timestamp{}
+			}
+			if err := m.Time.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWork(dAtA[iNdEx:])
@@ -8,8 +8,10 @@ import (
 	"testing"
 	"time"
 
+	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 	"github.com/pachyderm/pachyderm/src/server/pkg/testetcd"
@@ -200,3 +202,80 @@ func TestRunZeroSubtasks(t *testing.T) {
 		})
 	}))
 }
+
+func TestDeadWorker(t *testing.T) {
+	defer func(interval, timeout time.Duration) {
+		heartbeatInterval, heartbeatTimeout = interval, timeout
+	}(heartbeatInterval, heartbeatTimeout)
+	heartbeatInterval = 100 * time.Millisecond
+	heartbeatTimeout = time.Second
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		clientURL := env.Etcd.Config().LCUrls[0]
+		deadClient, err := etcd.New(etcd.Config{
+			Context:     env.Context,
+			Endpoints:   []string{clientURL.String()},
+			DialOptions: client.DefaultDialOptions(),
+		})
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithCancel(env.Context)
+		var workerEg errgroup.Group
+		defer func() {
+			cancel()
+			workerEg.Wait()
+		}()
+		// The first worker hangs while processing the subtask, and then loses its
+		// connection to etcd without releasing its claim.
+		started := make(chan struct{}, 1)
+		workerEg.Go(func() error {
+			w := NewWorker(deadClient, "", "")
+			w.Run(ctx, func(ctx context.Context, _ *Task) error {
+				select {
+				case started <- struct{}{}:
+				default:
+				}
+				<-ctx.Done()
+				return nil
+			})
+			return nil
+		})
+		tq, err := NewTaskQueue(ctx, env.EtcdClient, "", "")
+		if err != nil {
+			return err
+		}
+		data, err := serializeTestData(&TestData{})
+		if err != nil {
+			return err
+		}
+		collected := make(map[string]bool)
+		var taskEg errgroup.Group
+		taskEg.Go(func() error {
+			return tq.RunTaskBlock(ctx, func(m *Master) error {
+				return m.RunSubtasks([]*Task{{ID: "0", Data: data}}, func(_ context.Context, subtaskInfo *TaskInfo) error {
+					return collectSubtask(subtaskInfo, collected)
+				})
+			})
+		})
+		<-started
+		if err := deadClient.Close(); err != nil {
+			return err
+		}
+		start := time.Now()
+		workerEg.Go(func() error {
+			w := NewWorker(env.EtcdClient, "", "")
+			if err := w.Run(ctx, func(_ context.Context, subtask *Task) error {
+				return processSubtask(t, subtask)
+			}); err != nil && !errors.Is(ctx.Err(), context.Canceled) {
+				return err
+			}
+			return nil
+		})
+		require.NoError(t, taskEg.Wait())
+		require.Equal(t, map[string]bool{"0": true}, collected)
+		// The subtask should be reassigned well before the dead worker's claim
+		// would have expired.
+		require.True(t, time.Since(start) < 10*time.Second)
+		return nil
+	}))
+}
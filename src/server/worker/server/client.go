@@ -82,13 +82,12 @@ func Cancel(ctx context.Context, pipelineRcName string, etcdClient *etcd.Client,
 // ppsutil.PipelineRcName. You can also pass "" for pipelineRcName to get all
 // clients for all workers.
 func Conns(ctx context.Context, pipelineRcName string, etcdClient *etcd.Client, etcdPrefix string, workerGrpcPort uint16, workerIP ...string) ([]*grpc.ClientConn, error) {
-	resp, err := etcdClient.Get(ctx, path.Join(etcdPrefix, WorkerEtcdPrefix, pipelineRcName), etcd.WithPrefix())
+	addresses, err := Addresses(ctx, pipelineRcName, etcdClient, etcdPrefix)
 	if err != nil {
 		return nil, err
 	}
 	var result []*grpc.ClientConn
-	for _, kv := range resp.Kvs {
-		wIP := path.Base(string(kv.Key))
+	for _, wIP := range addresses {
 		if len(workerIP) > 0 && wIP != workerIP[0] {
 			continue
 		}
@@ -104,6 +103,21 @@ func Conns(ctx context.Context, pipelineRcName string, etcdClient *etcd.Client,
 	return result, nil
 }
 
+// Addresses returns the IP addresses of the workers referenced by
+// pipelineRcName, as registered in etcd. You can also pass "" for
+// pipelineRcName to get the addresses of all workers.
+func Addresses(ctx context.Context, pipelineRcName string, etcdClient *etcd.Client, etcdPrefix string) ([]string, error) {
+	resp, err := etcdClient.Get(ctx, path.Join(etcdPrefix, WorkerEtcdPrefix, pipelineRcName), etcd.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, kv := range resp.Kvs {
+		result = append(result, path.Base(string(kv.Key)))
+	}
+	return result, nil
+}
+
 // Client combines the WorkerAPI and the DebugAPI into a single client.
 type Client struct {
 	WorkerClient